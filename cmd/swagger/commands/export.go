@@ -0,0 +1,16 @@
+// SPDX-FileCopyrightText: Copyright 2015-2025 go-swagger maintainers
+// SPDX-License-Identifier: Apache-2.0
+
+package commands
+
+import "github.com/go-swagger/go-swagger/cmd/swagger/commands/export"
+
+// ExportCmd is a command namespace for exporting a swagger spec to other formats.
+type ExportCmd struct {
+	JSONSchema *export.JSONSchema `command:"jsonschema"`
+}
+
+// Execute provides default empty implementation.
+func (e *ExportCmd) Execute(_ []string) error {
+	return nil
+}
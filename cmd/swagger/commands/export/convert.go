@@ -0,0 +1,470 @@
+// SPDX-FileCopyrightText: Copyright 2015-2025 go-swagger maintainers
+// SPDX-License-Identifier: Apache-2.0
+
+package export
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"slices"
+	"strings"
+
+	"github.com/go-openapi/jsonpointer"
+	"github.com/go-openapi/spec"
+)
+
+const (
+	definitionsPrefix = "#/definitions/"
+
+	// baseDef is the name of the nested definition holding the schema of a discriminated base type,
+	// while the definition of the base type itself becomes the union of all its subtypes.
+	baseDef = "base"
+
+	extNullable           = "x-nullable"
+	extIsNullable         = "x-isnullable"
+	extDiscriminatorValue = "x-discriminator-value"
+
+	octetStream = "application/octet-stream"
+)
+
+// formats maps the swagger formats to their JSON schema equivalent.
+//
+// Other formats are kept unchanged: JSON schema considers formats as annotations.
+var formats = map[string]string{
+	"datetime": "date-time",
+	"uuid3":    "uuid",
+	"uuid4":    "uuid",
+	"uuid5":    "uuid",
+	"uuid7":    "uuid",
+}
+
+// exclusiveBounds are the keywords which turn a bound into an exclusive one.
+var exclusiveBounds = map[string]string{
+	"maximum": "exclusiveMaximum",
+	"minimum": "exclusiveMinimum",
+}
+
+// converter translates swagger definitions into JSON schema draft 2020-12.
+//
+// Schemas are converted in their generic JSON form, so that any keyword carried over
+// by the swagger document (e.g. vendor extensions) is preserved.
+type converter struct {
+	definitions map[string]map[string]any
+	bases       map[string]string // discriminated base types -> discriminator property
+	split       bool
+	current     string
+}
+
+func newConverter(definitions spec.Definitions, split bool) (*converter, error) {
+	c := &converter{
+		definitions: make(map[string]map[string]any, len(definitions)),
+		bases:       make(map[string]string),
+		split:       split,
+	}
+
+	for name, schema := range definitions {
+		buf, err := json.Marshal(schema)
+		if err != nil {
+			return nil, fmt.Errorf("could not convert definition %q: %w", name, err)
+		}
+
+		var generic map[string]any
+		dec := json.NewDecoder(bytes.NewReader(buf))
+		dec.UseNumber()
+		if err := dec.Decode(&generic); err != nil {
+			return nil, fmt.Errorf("could not convert definition %q: %w", name, err)
+		}
+
+		c.definitions[name] = generic
+		if schema.Discriminator != "" {
+			c.bases[name] = schema.Discriminator
+		}
+	}
+
+	return c, nil
+}
+
+// Convert all definitions, indexed by name.
+func (c *converter) Convert() map[string]any {
+	defs := make(map[string]any, len(c.definitions))
+	for name := range c.definitions {
+		defs[name] = c.definition(name)
+	}
+
+	return defs
+}
+
+func (c *converter) definition(name string) map[string]any {
+	c.current = name
+	schema := c.definitions[name]
+
+	if _, isBase := c.bases[name]; isBase {
+		return c.union(name, schema)
+	}
+
+	out := c.schema(schema)
+
+	// a subtype extends the schema of its base, not the union of all subtypes
+	extended := out
+	if _, hasType := schema["type"]; !hasType && isNullable(schema) {
+		extended, _ = out["anyOf"].([]any)[0].(map[string]any)
+	}
+	allOf, _ := extended["allOf"].([]any)
+	for i, member := range allOf {
+		parent, ok := definitionRef(schema["allOf"].([]any)[i])
+		if !ok {
+			continue
+		}
+		if _, isBase := c.bases[parent]; isBase {
+			member.(map[string]any)["$ref"] = c.ref(parent, "$defs", baseDef)
+		}
+	}
+
+	return out
+}
+
+// union converts a discriminated base type into the union of its subtypes, each identified by a constant
+// value of the discriminator.
+//
+// The base type remains a member of the union, with its own name as value.
+func (c *converter) union(name string, schema map[string]any) map[string]any {
+	discriminator := c.bases[name]
+
+	base := make(map[string]any, len(schema))
+	for k, v := range schema {
+		if k == extNullable || k == extIsNullable {
+			continue
+		}
+		base[k] = v
+	}
+	converted := c.schema(base)
+
+	variants := []any{c.variant(c.ref(name, "$defs", baseDef), discriminator, c.discriminatorValue(name))}
+	for _, subtype := range c.subtypes(name) {
+		variants = append(variants, c.variant(c.ref(subtype), discriminator, c.discriminatorValue(subtype)))
+	}
+	if isNullable(schema) {
+		variants = append(variants, map[string]any{"type": "null"})
+	}
+
+	out := map[string]any{
+		"oneOf": variants,
+		"$defs": map[string]any{baseDef: converted},
+	}
+	for k, v := range converted {
+		if k == "title" || k == "description" || strings.HasPrefix(k, "x-") {
+			out[k] = v
+		}
+	}
+
+	return out
+}
+
+func (c *converter) variant(ref, discriminator, value string) map[string]any {
+	return map[string]any{
+		"$ref": ref,
+		"properties": map[string]any{
+			discriminator: map[string]any{"const": value},
+		},
+	}
+}
+
+func (c *converter) discriminatorValue(name string) string {
+	if value, ok := c.definitions[name][extDiscriminatorValue].(string); ok && value != "" {
+		return value
+	}
+
+	return name
+}
+
+// subtypes lists all definitions extending a base type, directly or through another subtype.
+func (c *converter) subtypes(base string) []string {
+	var subtypes []string
+	for name := range c.definitions {
+		if name != base && c.extends(name, base, map[string]bool{}) {
+			subtypes = append(subtypes, name)
+		}
+	}
+	slices.Sort(subtypes)
+
+	return subtypes
+}
+
+func (c *converter) extends(name, base string, visited map[string]bool) bool {
+	if visited[name] {
+		return false
+	}
+	visited[name] = true
+
+	allOf, _ := c.definitions[name]["allOf"].([]any)
+	for _, member := range allOf {
+		parent, ok := definitionRef(member)
+		if !ok {
+			continue
+		}
+		if parent == base || c.extends(parent, base, visited) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (c *converter) schema(in map[string]any) map[string]any {
+	out := make(map[string]any, len(in))
+
+	for k, v := range in {
+		switch k {
+		case "$ref":
+			ref, _ := v.(string)
+			out[k] = c.rewriteRef(ref)
+		case "properties", "patternProperties":
+			out[k] = c.schemaMap(v)
+		case "definitions":
+			out["$defs"] = c.schemaMap(v)
+		case "allOf", "anyOf", "oneOf":
+			out[k] = c.schemaSlice(v)
+		case "not", "additionalProperties":
+			out[k] = c.subSchema(v)
+		case "items":
+			if _, isTuple := v.([]any); isTuple {
+				out["prefixItems"] = c.schemaSlice(v)
+				if additional, ok := in["additionalItems"]; ok {
+					out["items"] = c.subSchema(additional)
+				}
+
+				continue
+			}
+			out[k] = c.subSchema(v)
+		case "dependencies":
+			c.dependencies(out, v)
+		case "maximum", "minimum":
+			if exclusive, _ := in[exclusiveBounds[k]].(bool); exclusive {
+				out[exclusiveBounds[k]] = v
+
+				continue
+			}
+			out[k] = v
+		case "exclusiveMaximum", "exclusiveMinimum":
+			// draft 2020-12 requires a numeric value, set with the bound
+		case "example":
+			out["examples"] = []any{v}
+		case "format":
+			c.format(out, v)
+		case "type":
+			c.schemaType(out, v)
+		case "additionalItems", "discriminator", "xml", "externalDocs", "id",
+			extNullable, extIsNullable, extDiscriminatorValue:
+			// either converted along with another keyword, or without equivalent in JSON schema
+		default:
+			out[k] = v
+		}
+	}
+
+	if isNullable(in) {
+		return nullable(out)
+	}
+
+	return out
+}
+
+func (c *converter) subSchema(v any) any {
+	schema, ok := v.(map[string]any)
+	if !ok {
+		// boolean schema
+		return v
+	}
+
+	return c.schema(schema)
+}
+
+func (c *converter) schemaSlice(v any) []any {
+	in, _ := v.([]any)
+	out := make([]any, 0, len(in))
+	for _, schema := range in {
+		out = append(out, c.subSchema(schema))
+	}
+
+	return out
+}
+
+func (c *converter) schemaMap(v any) map[string]any {
+	in, _ := v.(map[string]any)
+	out := make(map[string]any, len(in))
+	for k, schema := range in {
+		out[k] = c.subSchema(schema)
+	}
+
+	return out
+}
+
+// dependencies splits the draft 4 dependencies into dependentRequired and dependentSchemas.
+func (c *converter) dependencies(out map[string]any, v any) {
+	in, _ := v.(map[string]any)
+	required := make(map[string]any)
+	schemas := make(map[string]any)
+
+	for k, dependency := range in {
+		if properties, ok := dependency.([]any); ok {
+			required[k] = properties
+
+			continue
+		}
+		schemas[k] = c.subSchema(dependency)
+	}
+
+	if len(required) > 0 {
+		out["dependentRequired"] = required
+	}
+	if len(schemas) > 0 {
+		out["dependentSchemas"] = schemas
+	}
+}
+
+func (c *converter) format(out map[string]any, v any) {
+	format, _ := v.(string)
+
+	switch format {
+	case "byte":
+		out["contentEncoding"] = "base64"
+	case "binary":
+		out["contentMediaType"] = octetStream
+	default:
+		if mapped, ok := formats[format]; ok {
+			format = mapped
+		}
+		out["format"] = format
+	}
+}
+
+func (c *converter) schemaType(out map[string]any, v any) {
+	if v == "file" {
+		out["type"] = "string"
+		out["contentMediaType"] = octetStream
+
+		return
+	}
+
+	out["type"] = v
+}
+
+// rewriteRef points a reference to #/definitions to the converted definition.
+//
+// Other references are left unchanged.
+func (c *converter) rewriteRef(ref string) string {
+	if !strings.HasPrefix(ref, definitionsPrefix) {
+		return ref
+	}
+
+	fragment := strings.TrimPrefix(ref, "#")
+	if unescaped, err := url.PathUnescape(fragment); err == nil {
+		fragment = unescaped
+	}
+
+	tokens := strings.Split(strings.TrimPrefix(fragment, "/definitions/"), "/")
+	for i := range tokens {
+		tokens[i] = jsonpointer.Unescape(tokens[i])
+	}
+
+	name, path := tokens[0], tokens[1:]
+	for i, token := range path {
+		if token == "definitions" {
+			path[i] = "$defs"
+		}
+	}
+	if _, isBase := c.bases[name]; isBase && len(path) > 0 {
+		path = append([]string{"$defs", baseDef}, path...)
+	}
+
+	return c.ref(name, path...)
+}
+
+// ref builds a reference to a converted definition, or to a location inside it.
+func (c *converter) ref(name string, path ...string) string {
+	var pointer strings.Builder
+	if !c.split {
+		pointer.WriteString("/$defs/")
+		pointer.WriteString(jsonpointer.Escape(name))
+	}
+	for _, token := range path {
+		pointer.WriteByte('/')
+		pointer.WriteString(jsonpointer.Escape(token))
+	}
+
+	var document string
+	if c.split && name != c.current {
+		document = url.PathEscape(fileName(name))
+	}
+
+	if pointer.Len() == 0 {
+		if document == "" {
+			return "#"
+		}
+
+		return document
+	}
+
+	return document + "#" + (&url.URL{Fragment: pointer.String()}).EscapedFragment()
+}
+
+// fileName is the name of the file holding a definition, when definitions are split.
+func fileName(name string) string {
+	return name + ".json"
+}
+
+func definitionRef(v any) (string, bool) {
+	schema, ok := v.(map[string]any)
+	if !ok {
+		return "", false
+	}
+
+	ref, _ := schema["$ref"].(string)
+	if !strings.HasPrefix(ref, definitionsPrefix) {
+		return "", false
+	}
+
+	name := strings.TrimPrefix(ref, definitionsPrefix)
+	if unescaped, err := url.PathUnescape(name); err == nil {
+		name = unescaped
+	}
+	if strings.Contains(name, "/") {
+		// a location inside a definition
+		return "", false
+	}
+
+	return jsonpointer.Unescape(name), true
+}
+
+func isNullable(schema map[string]any) bool {
+	nullable, _ := schema[extNullable].(bool)
+	isNullable, _ := schema[extIsNullable].(bool)
+
+	return nullable || isNullable
+}
+
+// nullable admits null as a value: null is added to the types of the schema,
+// or the schema becomes an alternative with null, when it doesn't specify types.
+func nullable(schema map[string]any) map[string]any {
+	switch t := schema["type"].(type) {
+	case string:
+		if t != "null" {
+			schema["type"] = []any{t, "null"}
+		}
+	case []any:
+		if !slices.Contains(t, any("null")) {
+			schema["type"] = append(t, "null")
+		}
+	default:
+		return map[string]any{
+			"anyOf": []any{schema, map[string]any{"type": "null"}},
+		}
+	}
+
+	if enum, ok := schema["enum"].([]any); ok && !slices.Contains(enum, nil) {
+		schema["enum"] = append(enum, nil)
+	}
+
+	return schema
+}
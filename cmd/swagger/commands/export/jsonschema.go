@@ -0,0 +1,158 @@
+// SPDX-FileCopyrightText: Copyright 2015-2025 go-swagger maintainers
+// SPDX-License-Identifier: Apache-2.0
+
+package export
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"log"
+	"net/url"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	flags "github.com/jessevdk/go-flags"
+
+	"github.com/go-openapi/analysis"
+	"github.com/go-openapi/loads"
+)
+
+const (
+	draft202012 = "https://json-schema.org/draft/2020-12/schema"
+
+	readableMode fs.FileMode = 0o644 & fs.ModePerm
+	dirMode      fs.FileMode = 0o755 & fs.ModePerm
+)
+
+var defaultWriter io.Writer = os.Stdout
+
+// JSONSchema is a command that exports the definitions of a swagger document as JSON schema (draft 2020-12).
+//
+// Definitions are bundled as $defs of a single schema, or written one file per definition.
+type JSONSchema struct {
+	Output  flags.Filename `description:"the file to write the bundle to, or the directory to write definitions to with --split" long:"output" short:"o"`
+	Split   bool           `description:"writes one file per definition, named after the definition"                             long:"split"`
+	ID      string         `description:"the base URI identifying the exported schemas"                                          long:"id"`
+	Compact bool           `description:"when present, doesn't prettify the json"                                                long:"compact"`
+}
+
+// Execute exports the definitions.
+func (c *JSONSchema) Execute(args []string) error {
+	if len(args) != 1 {
+		return errors.New("export jsonschema command requires the single swagger document url to be specified")
+	}
+
+	specDoc, err := loads.Spec(args[0])
+	if err != nil {
+		return err
+	}
+
+	// remote $ref's are bundled into definitions
+	if err := analysis.Flatten(analysis.FlattenOpts{
+		Minimal:  true,
+		BasePath: specDoc.SpecFilePath(),
+		Spec:     analysis.New(specDoc.Spec()),
+	}); err != nil {
+		return err
+	}
+
+	swspec := specDoc.Spec()
+	conv, err := newConverter(swspec.Definitions, c.Split)
+	if err != nil {
+		return err
+	}
+	defs := conv.Convert()
+
+	if c.Split {
+		return c.writeSplit(defs)
+	}
+
+	bundle := map[string]any{
+		"$schema": draft202012,
+		"$defs":   defs,
+	}
+	if c.ID != "" {
+		bundle["$id"] = c.ID
+	}
+	if swspec.Info != nil && swspec.Info.Title != "" {
+		bundle["title"] = swspec.Info.Title
+	}
+
+	b, err := c.marshal(bundle)
+	if err != nil {
+		return err
+	}
+
+	switch output := string(c.Output); output {
+	case "", "-":
+		_, err = fmt.Fprintf(defaultWriter, "%s\n", b)
+
+		return err
+	default:
+		return os.WriteFile(output, b, readableMode)
+	}
+}
+
+func (c *JSONSchema) writeSplit(defs map[string]any) error {
+	target := string(c.Output)
+	if target == "" || target == "-" {
+		return errors.New("export jsonschema --split requires an output directory")
+	}
+
+	var base *url.URL
+	if c.ID != "" {
+		var err error
+		base, err = url.Parse(strings.TrimSuffix(c.ID, "/") + "/")
+		if err != nil {
+			return fmt.Errorf("invalid base URI %q: %w", c.ID, err)
+		}
+	}
+
+	if err := os.MkdirAll(target, dirMode); err != nil {
+		return err
+	}
+
+	names := make([]string, 0, len(defs))
+	for name := range defs {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		if strings.ContainsAny(name, `/\`) {
+			return fmt.Errorf("definition %q cannot be written to a file of its own", name)
+		}
+
+		schema, _ := defs[name].(map[string]any)
+		schema["$schema"] = draft202012
+		if base != nil {
+			schema["$id"] = base.ResolveReference(&url.URL{Path: fileName(name)}).String()
+		}
+
+		b, err := c.marshal(schema)
+		if err != nil {
+			return err
+		}
+
+		file := filepath.Join(target, fileName(name))
+		if err := os.WriteFile(file, b, readableMode); err != nil {
+			return err
+		}
+		log.Printf("exported definition %q to %s", name, file)
+	}
+
+	return nil
+}
+
+func (c *JSONSchema) marshal(schema map[string]any) ([]byte, error) {
+	if c.Compact {
+		return json.Marshal(schema)
+	}
+
+	return json.MarshalIndent(schema, "", "  ")
+}
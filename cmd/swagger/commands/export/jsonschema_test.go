@@ -0,0 +1,142 @@
+// SPDX-FileCopyrightText: Copyright 2015-2025 go-swagger maintainers
+// SPDX-License-Identifier: Apache-2.0
+
+package export
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	flags "github.com/jessevdk/go-flags"
+
+	"github.com/go-openapi/testify/v2/assert"
+	"github.com/go-openapi/testify/v2/require"
+)
+
+func fixture() string {
+	return filepath.Join("..", "..", "..", "..", "fixtures", "codegen", "jsonschema.yml")
+}
+
+func readSchema(t *testing.T, file string) map[string]any {
+	t.Helper()
+
+	b, err := os.ReadFile(file)
+	require.NoError(t, err)
+
+	var schema map[string]any
+	require.NoError(t, json.Unmarshal(b, &schema))
+
+	return schema
+}
+
+func TestJSONSchema_RequiresSpec(t *testing.T) {
+	require.Error(t, (&JSONSchema{}).Execute(nil))
+	require.Error(t, (&JSONSchema{}).Execute([]string{"nowhere.yaml"}))
+}
+
+func TestJSONSchema_Bundle(t *testing.T) {
+	output := filepath.Join(t.TempDir(), "schemas.json")
+	require.NoError(t, (&JSONSchema{Output: flags.Filename(output), ID: "https://example.com/pets.json"}).Execute([]string{fixture()}))
+
+	bundle := readSchema(t, output)
+	assert.EqualT(t, draft202012, bundle["$schema"].(string))
+	assert.EqualT(t, "https://example.com/pets.json", bundle["$id"].(string))
+	assert.EqualT(t, "Pet store schemas", bundle["title"].(string))
+
+	defs := bundle["$defs"].(map[string]any)
+	require.Len(t, defs, 5)
+
+	t.Run("discriminator becomes a union of subtypes", func(t *testing.T) {
+		pet := defs["Pet"].(map[string]any)
+		assert.EqualT(t, "a pet", pet["description"].(string))
+		assert.Equal(t, []any{
+			map[string]any{"$ref": "#/$defs/Pet/$defs/base", "properties": map[string]any{"kind": map[string]any{"const": "Pet"}}},
+			map[string]any{"$ref": "#/$defs/Cat", "properties": map[string]any{"kind": map[string]any{"const": "cat"}}},
+			map[string]any{"$ref": "#/$defs/Dog", "properties": map[string]any{"kind": map[string]any{"const": "Dog"}}},
+			map[string]any{"$ref": "#/$defs/Kitten", "properties": map[string]any{"kind": map[string]any{"const": "Kitten"}}},
+		}, pet["oneOf"])
+
+		base := pet["$defs"].(map[string]any)["base"].(map[string]any)
+		assert.NotContains(t, base, "discriminator")
+		properties := base["properties"].(map[string]any)
+		assert.EqualT(t, "PetName", properties["name"].(map[string]any)["x-go-name"].(string))
+		assert.EqualT(t, "date-time", properties["born"].(map[string]any)["format"].(string))
+
+		dog := defs["Dog"].(map[string]any)
+		assert.EqualT(t, "#/$defs/Pet/$defs/base", dog["allOf"].([]any)[0].(map[string]any)["$ref"].(string))
+
+		kitten := defs["Kitten"].(map[string]any)
+		assert.EqualT(t, "#/$defs/Cat", kitten["allOf"].([]any)[0].(map[string]any)["$ref"].(string))
+	})
+
+	t.Run("keywords are converted", func(t *testing.T) {
+		cat := defs["Cat"].(map[string]any)
+		assert.NotContains(t, cat, "x-discriminator-value")
+		lives := cat["allOf"].([]any)[1].(map[string]any)["properties"].(map[string]any)["lives"].(map[string]any)
+		assert.Equal(t, map[string]any{"type": "integer", "format": "int32", "minimum": float64(0), "exclusiveMaximum": float64(10)}, lives)
+
+		owner := defs["Owner"].(map[string]any)
+		assert.EqualT(t, "platform", owner["x-owner-team"].(string))
+
+		properties := owner["properties"].(map[string]any)
+		assert.Equal(t, map[string]any{"type": "string", "format": "uuid"}, properties["id"])
+		assert.Equal(t, map[string]any{"type": []any{"string", "null"}}, properties["nickname"])
+		assert.Equal(t, map[string]any{"type": []any{"string", "null"}, "enum": []any{"active", "retired", nil}}, properties["status"])
+		assert.Equal(t, map[string]any{"type": "string", "contentEncoding": "base64"}, properties["avatar"])
+		assert.Equal(t, map[string]any{"anyOf": []any{map[string]any{"$ref": "#/$defs/Pet"}, map[string]any{"type": "null"}}}, properties["best"])
+		assert.Equal(t, map[string]any{
+			"type":        "array",
+			"prefixItems": []any{map[string]any{"type": "number"}, map[string]any{"type": "number"}},
+			"items":       false,
+		}, properties["position"])
+		assert.Equal(t, map[string]any{"type": "integer", "examples": []any{float64(42)}}, properties["age"])
+	})
+}
+
+func TestJSONSchema_Stdout(t *testing.T) {
+	var buf bytes.Buffer
+	defaultWriter = &buf
+	t.Cleanup(func() { defaultWriter = os.Stdout })
+
+	require.NoError(t, (&JSONSchema{Compact: true}).Execute([]string{fixture()}))
+	assert.StringContainsT(t, buf.String(), `{"$defs":{"Cat":`)
+}
+
+func TestJSONSchema_Split(t *testing.T) {
+	require.Error(t, (&JSONSchema{Split: true}).Execute([]string{fixture()}))
+
+	target := filepath.Join(t.TempDir(), "schemas")
+	require.NoError(t, (&JSONSchema{Output: flags.Filename(target), Split: true, ID: "https://example.com/schemas"}).Execute([]string{fixture()}))
+
+	for _, name := range []string{"Pet", "Dog", "Cat", "Kitten", "Owner"} {
+		assert.FileExists(t, filepath.Join(target, name+".json"))
+	}
+
+	pet := readSchema(t, filepath.Join(target, "Pet.json"))
+	assert.EqualT(t, draft202012, pet["$schema"].(string))
+	assert.EqualT(t, "https://example.com/schemas/Pet.json", pet["$id"].(string))
+	oneOf := pet["oneOf"].([]any)
+	assert.EqualT(t, "#/$defs/base", oneOf[0].(map[string]any)["$ref"].(string))
+	assert.EqualT(t, "Cat.json", oneOf[1].(map[string]any)["$ref"].(string))
+
+	dog := readSchema(t, filepath.Join(target, "Dog.json"))
+	assert.EqualT(t, "Pet.json#/$defs/base", dog["allOf"].([]any)[0].(map[string]any)["$ref"].(string))
+}
+
+func TestRewriteRef(t *testing.T) {
+	c := &converter{bases: map[string]string{"Pet": "kind"}, current: "Owner"}
+
+	assert.EqualT(t, "#/$defs/Pet", c.rewriteRef("#/definitions/Pet"))
+	assert.EqualT(t, "#/$defs/Pet/$defs/base/properties/name", c.rewriteRef("#/definitions/Pet/properties/name"))
+	assert.EqualT(t, "#/$defs/a~1b/$defs/c", c.rewriteRef("#/definitions/a~1b/definitions/c"))
+	assert.EqualT(t, "#/$defs/with%20space", c.rewriteRef("#/definitions/with%20space"))
+	assert.EqualT(t, "#/parameters/p", c.rewriteRef("#/parameters/p"))
+
+	c.split = true
+	assert.EqualT(t, "Pet.json", c.rewriteRef("#/definitions/Pet"))
+	assert.EqualT(t, "#", c.rewriteRef("#/definitions/Owner"))
+	assert.EqualT(t, "#/properties/id", c.rewriteRef("#/definitions/Owner/properties/id"))
+}
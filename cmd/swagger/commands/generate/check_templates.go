@@ -0,0 +1,95 @@
+// SPDX-FileCopyrightText: Copyright 2015-2025 go-swagger maintainers
+// SPDX-License-Identifier: Apache-2.0
+
+package generate
+
+import (
+	"fmt"
+	"io"
+	"log"
+	"os"
+
+	"github.com/jessevdk/go-flags"
+
+	"github.com/go-swagger/go-swagger/generator"
+)
+
+// CheckTemplates checks custom templates statically, against the data model of the generator.
+type CheckTemplates struct {
+	TemplateDir           flags.Filename `description:"the template override directory to check"                             long:"template-dir"                      required:"true" short:"T"`
+	Template              string         `choice:"stratoscale"                                                               description:"load contributed templates" long:"template"`
+	TemplatePack          string         `description:"the template pack to check the templates with"                         long:"template-pack"`
+	ConfigFile            flags.Filename `description:"configuration file with the layout and generation options to check"   long:"config-file"                       short:"C"`
+	Profile               string         `description:"the profile of generation options to use from the configuration file" long:"profile"`
+	AllowTemplateOverride bool           `description:"allows overriding protected templates"                                long:"allow-template-override"`
+
+	parsed      *flags.Command
+	packOptions map[string]string
+}
+
+func (c *CheckTemplates) setParsed(command *flags.Command, packOptions map[string]string) {
+	c.parsed = command
+	c.packOptions = packOptions
+}
+
+// Execute runs this command.
+func (c *CheckTemplates) Execute(_ []string) error {
+	return c.check(os.Stdout)
+}
+
+func (c *CheckTemplates) check(w io.Writer) error {
+	var def *generator.LanguageDefinition
+	if c.ConfigFile != "" {
+		cfg, err := readConfig(string(c.ConfigFile), log.Printf)
+		if err != nil {
+			return err
+		}
+
+		def, err = configDefinition(cfg, c.Profile)
+		if err != nil {
+			return err
+		}
+
+		if err = applyConfigOptions(c, c.parsed, def.Options, log.Printf); err != nil {
+			return err
+		}
+	}
+
+	opts := new(generator.GenOpts)
+	opts.TemplateDir = string(c.TemplateDir)
+	opts.Template = c.Template
+	opts.TemplatePack = c.TemplatePack
+	opts.TemplatePackOptions = c.packOptions
+	opts.AllowTemplateOverride = c.AllowTemplateOverride
+
+	if opts.Template != "" || opts.TemplatePack != "" {
+		contribOptionsOverride(opts)
+	}
+
+	if err := opts.EnsureDefaults(); err != nil {
+		return err
+	}
+
+	if def != nil {
+		if err := def.ConfigureOpts(opts); err != nil {
+			return err
+		}
+	}
+
+	issues, err := generator.CheckTemplates(opts)
+	if err != nil {
+		return err
+	}
+
+	for _, issue := range issues {
+		fmt.Fprintln(w, issue)
+	}
+
+	if len(issues) > 0 {
+		return fmt.Errorf("found %d issues in templates", len(issues))
+	}
+
+	fmt.Fprintf(w, "no issue found in templates from %s\n", c.TemplateDir)
+
+	return nil
+}
@@ -0,0 +1,63 @@
+// SPDX-FileCopyrightText: Copyright 2015-2025 go-swagger maintainers
+// SPDX-License-Identifier: Apache-2.0
+
+package generate_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	flags "github.com/jessevdk/go-flags"
+
+	"github.com/go-openapi/testify/v2/assert"
+	"github.com/go-openapi/testify/v2/require"
+
+	"github.com/go-swagger/go-swagger/cmd/swagger/commands/generate"
+)
+
+func TestCheckTemplates(t *testing.T) {
+	t.Run("should pass with valid templates", func(t *testing.T) {
+		dir := t.TempDir()
+		require.NoError(t, os.WriteFile(filepath.Join(dir, "stream.gotmpl"), []byte(`package {{ .Package }}`), 0o600))
+
+		c := &generate.CheckTemplates{}
+		_, err := flags.ParseArgs(c, []string{"--template-dir", dir})
+		require.NoError(t, err)
+		require.NoError(t, c.Execute(nil))
+	})
+
+	t.Run("should fail with invalid templates", func(t *testing.T) {
+		dir := t.TempDir()
+		require.NoError(t, os.WriteFile(filepath.Join(dir, "unused.gotmpl"), []byte(`{{ define "unused" }}{{ pascalize }}{{ end }}`), 0o600))
+
+		c := &generate.CheckTemplates{}
+		_, err := flags.ParseArgs(c, []string{"--template-dir", dir})
+		require.NoError(t, err)
+
+		err = c.Execute(nil)
+		require.Error(t, err)
+		assert.StringContainsT(t, err.Error(), "found 1 issues in templates")
+	})
+
+	t.Run("should check the layout of a config file", func(t *testing.T) {
+		dir := t.TempDir()
+		require.NoError(t, os.WriteFile(filepath.Join(dir, "stream.gotmpl"), []byte(`package {{ .Package }}`), 0o600))
+		config := filepath.Join(t.TempDir(), "config.yml")
+		require.NoError(t, os.WriteFile(config, []byte(`layout:
+  operations:
+    - name: stream
+      source: stream.gotmpl
+      target: "{{ .Target }}"
+      file_name: "{{ .Nmae }}.go"
+`), 0o600))
+
+		c := &generate.CheckTemplates{}
+		_, err := flags.ParseArgs(c, []string{"--template-dir", dir, "--config-file", config})
+		require.NoError(t, err)
+
+		err = c.Execute(nil)
+		require.Error(t, err)
+		assert.StringContainsT(t, err.Error(), "found 1 issues in templates")
+	})
+}
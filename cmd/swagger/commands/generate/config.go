@@ -0,0 +1,271 @@
+// SPDX-FileCopyrightText: Copyright 2015-2025 go-swagger maintainers
+// SPDX-License-Identifier: Apache-2.0
+
+package generate
+
+import (
+	"errors"
+	"fmt"
+	"maps"
+	"reflect"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/go-viper/mapstructure/v2"
+	flags "github.com/jessevdk/go-flags"
+	"github.com/spf13/viper"
+
+	"github.com/go-swagger/go-swagger/generator"
+)
+
+// configFlags are the flags which cannot be set from a configuration file.
+var configFlags = map[string]bool{
+	"config-file": true,
+	"profile":     true,
+	"watch":       true,
+}
+
+// commandFlag is a command line flag, which value may be set from a configuration file.
+type commandFlag struct {
+	value         reflect.Value
+	choices       []string
+	optionalValue string // the value of a flag given without value, e.g. --dump-data
+}
+
+// configDefinition resolves the definition found in a configuration file, for some profile.
+func configDefinition(cfg *viper.Viper, profile string) (*generator.LanguageDefinition, error) {
+	var def generator.LanguageDefinition
+	if err := cfg.Unmarshal(&def); err != nil {
+		return nil, err
+	}
+
+	return def.WithProfile(profile)
+}
+
+// parsedCommand is a command which is told how its flags have been parsed.
+type parsedCommand interface {
+	setParsed(command *flags.Command, packOptions map[string]string)
+}
+
+// ConfigureParser prepares a parser for the generate commands.
+//
+// Generate commands are told which flags are set on the command line: the options of a configuration file
+// only apply to the other flags.
+//
+// The options of a template pack are flags in the "pack" namespace, e.g. --pack.title=Todo.
+// Unless they are added to the commands with AddTemplatePackOptions, they are collected from
+// unknown flags. They are checked when the pack is loaded for the generation.
+func ConfigureParser(parser *flags.Parser) {
+	packOptions := make(map[string]string)
+
+	parser.UnknownOptionHandler = func(option string, arg flags.SplitArgument, args []string) ([]string, error) {
+		name, ok := strings.CutPrefix(option, templatePackNamespace+".")
+		if !ok || name == "" || !isGenerateCommand(parser) {
+			return nil, &flags.Error{Type: flags.ErrUnknownFlag, Message: fmt.Sprintf("unknown flag `%s'", option)}
+		}
+
+		value, ok := arg.Value()
+		if !ok {
+			// a flag without value sets a bool option
+			value = "true"
+		}
+		packOptions[name] = value
+
+		return args, nil
+	}
+
+	parser.CommandHandler = func(command flags.Commander, args []string) error {
+		if command == nil {
+			return nil
+		}
+
+		cmd, ok := command.(parsedCommand)
+		if ok {
+			active := parser.Command
+			for active.Active != nil {
+				active = active.Active
+			}
+			setPackOptions(active, packOptions)
+			cmd.setParsed(active, packOptions)
+		} else if len(packOptions) > 0 {
+			return errors.New("the options of a template pack only apply to commands generating code")
+		}
+
+		return command.Execute(args)
+	}
+}
+
+// isGenerateCommand tells if the command being parsed is a generate command.
+func isGenerateCommand(parser *flags.Parser) bool {
+	for active := parser.Active; active != nil; active = active.Active {
+		if active.Name == "generate" {
+			return true
+		}
+	}
+
+	return false
+}
+
+// applyConfigOptions sets the flags of a command from the options found in a configuration file.
+//
+// Options are keyed like the long name of flags, e.g. "model-package" or "model_package".
+// Flags set on the command line take precedence: an option only applies to a flag which is not set
+// when the command is parsed. Without a parsed command, all options apply.
+//
+// Options which are known flags of other generate commands are ignored.
+// Options overridden by the command line are reported with warnf.
+func applyConfigOptions(cmd any, parsed *flags.Command, options map[string]any, warnf func(string, ...any)) error {
+	flags := commandFlags(cmd)
+	known := allCommandFlags()
+
+	var errs []error
+	for _, key := range slices.Sorted(maps.Keys(options)) {
+		name := strings.ReplaceAll(strings.ToLower(key), "_", "-")
+
+		if configFlags[name] {
+			errs = append(errs, fmt.Errorf("option %q cannot be set in a config file", key))
+			continue
+		}
+
+		flag, ok := flags[name]
+		if !ok {
+			if _, isKnown := known[name]; !isKnown {
+				errs = append(errs, fmt.Errorf("unknown option %q in config file", key))
+			}
+			continue
+		}
+
+		if isSetOnCommandLine(parsed, name) {
+			warnf("option %q in config file is overridden by the command line", key)
+			continue
+		}
+
+		if err := flag.set(options[key]); err != nil {
+			errs = append(errs, fmt.Errorf("invalid value for option %q in config file: %w", key, err))
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// allCommandFlags collects the flags of all generate commands.
+func allCommandFlags() map[string]commandFlag {
+	all := make(map[string]commandFlag)
+	for _, cmd := range []any{&Server{}, &Client{}, &Cli{}, &Model{}, &Operation{}, &Support{}, &Markdown{}, &TypeScript{}, &Proto{}} {
+		maps.Copy(all, commandFlags(cmd))
+	}
+
+	return all
+}
+
+// commandFlags collects the flags of a command, by their long name.
+func commandFlags(cmd any) map[string]commandFlag {
+	flags := make(map[string]commandFlag)
+	collectFlags(reflect.ValueOf(cmd).Elem(), flags)
+
+	return flags
+}
+
+func collectFlags(v reflect.Value, flags map[string]commandFlag) {
+	t := v.Type()
+	for i := range t.NumField() {
+		field := t.Field(i)
+		value := v.Field(i)
+
+		if long := field.Tag.Get("long"); long != "" {
+			if value.CanSet() {
+				flags[long] = commandFlag{
+					value:         value,
+					choices:       tagValues(field.Tag, "choice"),
+					optionalValue: field.Tag.Get("optional-value"),
+				}
+			}
+
+			continue
+		}
+
+		if field.Type.Kind() == reflect.Struct {
+			// embedded structs and option groups
+			collectFlags(value, flags)
+		}
+	}
+}
+
+// isSetOnCommandLine tells if a flag of a parsed command is set on the command line, rather than left to its default value.
+func isSetOnCommandLine(parsed *flags.Command, name string) bool {
+	if parsed == nil {
+		return false
+	}
+
+	option := parsed.FindOptionByLongName(name)
+
+	return option != nil && option.IsSet() && !option.IsSetDefault()
+}
+
+func (f commandFlag) set(input any) error {
+	if enabled, isBool := input.(bool); isBool && f.optionalValue != "" && f.value.Kind() == reflect.String {
+		// like on the command line, a flag with an optional value is given without value, e.g. dump-data: true
+		input = ""
+		if enabled {
+			input = f.optionalValue
+		}
+	}
+
+	target := reflect.New(f.value.Type())
+	if err := mapstructure.WeakDecode(input, target.Interface()); err != nil {
+		return err
+	}
+
+	if len(f.choices) > 0 {
+		values := []string{fmt.Sprint(target.Elem().Interface())}
+		if target.Elem().Kind() == reflect.Slice {
+			values = values[:0]
+			for i := range target.Elem().Len() {
+				values = append(values, fmt.Sprint(target.Elem().Index(i).Interface()))
+			}
+		}
+
+		for _, value := range values {
+			if !slices.Contains(f.choices, value) {
+				return fmt.Errorf("%q is not one of: %s", value, strings.Join(f.choices, ", "))
+			}
+		}
+	}
+
+	f.value.Set(target.Elem())
+
+	return nil
+}
+
+// tagValues returns all the values of a repeated key in a struct tag, e.g. `choice:"a" choice:"b"`.
+func tagValues(tag reflect.StructTag, key string) []string {
+	var values []string
+	for rest := strings.TrimSpace(string(tag)); rest != ""; rest = strings.TrimSpace(rest) {
+		name, quoted, ok := strings.Cut(rest, ":")
+		if !ok || !strings.HasPrefix(quoted, `"`) {
+			return values
+		}
+
+		// find the closing quote, skipping escaped characters
+		end := 1
+		for end < len(quoted) && quoted[end] != '"' {
+			if quoted[end] == '\\' {
+				end++
+			}
+			end++
+		}
+		if end >= len(quoted) {
+			return values
+		}
+
+		if name == key {
+			if value, err := strconv.Unquote(quoted[:end+1]); err == nil {
+				values = append(values, value)
+			}
+		}
+		rest = quoted[end+1:]
+	}
+
+	return values
+}
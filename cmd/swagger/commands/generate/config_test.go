@@ -0,0 +1,208 @@
+// SPDX-FileCopyrightText: Copyright 2015-2025 go-swagger maintainers
+// SPDX-License-Identifier: Apache-2.0
+
+package generate
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	flags "github.com/jessevdk/go-flags"
+
+	"github.com/go-openapi/testify/v2/assert"
+	"github.com/go-openapi/testify/v2/require"
+)
+
+func TestApplyConfigOptions(t *testing.T) {
+	parse := func(t *testing.T, args ...string) *Server {
+		t.Helper()
+
+		s := new(Server)
+		parser := flags.NewParser(s, flags.None)
+		_, err := parser.ParseArgs(args)
+		require.NoError(t, err)
+		s.setParsed(parser.Command, nil)
+
+		return s
+	}
+
+	t.Run("should set flags left to their default value", func(t *testing.T) {
+		s := parse(t)
+		require.NoError(t, applyConfigOptions(s, s.parsed, map[string]any{
+			"model-package":  "dto",
+			"server_package": "api",
+			"principal":      "dto.Principal",
+			"struct-tags":    []any{"json", "yaml"},
+			"with-flatten":   []any{"full", "remove-unused"},
+			"exclude-main":   true,
+			"concurrency":    "4",
+			"flag-strategy":  "pflag",
+		}, t.Logf))
+
+		assert.EqualT(t, "dto", s.Models.ModelPackage)
+		assert.EqualT(t, "api", s.ServerPackage)
+		assert.EqualT(t, "dto.Principal", s.Principal)
+		assert.Equal(t, []string{"json", "yaml"}, s.Models.StructTags)
+		assert.Equal(t, []string{"full", "remove-unused"}, s.Shared.WithFlatten)
+		assert.TrueT(t, s.ExcludeMain)
+		assert.EqualT(t, 4, s.Shared.Concurrency)
+		assert.EqualT(t, "pflag", s.FlagStrategy)
+	})
+
+	t.Run("should not override flags set on the command line", func(t *testing.T) {
+		s := parse(t, "--model-package=cli", "--with-flatten=expand")
+		var warnings []string
+		require.NoError(t, applyConfigOptions(s, s.parsed, map[string]any{
+			"model-package": "dto",
+			"with-flatten":  []any{"full"},
+		}, func(format string, args ...any) {
+			warnings = append(warnings, fmt.Sprintf(format, args...))
+		}))
+
+		assert.EqualT(t, "cli", s.Models.ModelPackage)
+		assert.Equal(t, []string{"expand"}, s.Shared.WithFlatten)
+		assert.Equal(t, []string{
+			`option "model-package" in config file is overridden by the command line`,
+			`option "with-flatten" in config file is overridden by the command line`,
+		}, warnings)
+	})
+
+	t.Run("should not override flags set on the command line to their default value", func(t *testing.T) {
+		s := parse(t, "--default-scheme=http", "--with-flatten=minimal", "--with-flatten=verbose")
+		require.NoError(t, applyConfigOptions(s, s.parsed, map[string]any{
+			"default-scheme": "https",
+			"with-flatten":   []any{"full"},
+		}, t.Logf))
+
+		assert.EqualT(t, "http", s.DefaultScheme)
+		assert.Equal(t, []string{"minimal", "verbose"}, s.Shared.WithFlatten)
+	})
+
+	t.Run("should set flags with an optional value from a boolean", func(t *testing.T) {
+		s := parse(t)
+		require.NoError(t, applyConfigOptions(s, s.parsed, map[string]any{"dump-data": true}, t.Logf))
+		assert.EqualT(t, "$", s.Shared.DumpData)
+
+		require.NoError(t, applyConfigOptions(s, s.parsed, map[string]any{"dump-data": false}, t.Logf))
+		assert.Empty(t, s.Shared.DumpData)
+
+		require.NoError(t, applyConfigOptions(s, s.parsed, map[string]any{"dump-data": "$.Models[0]"}, t.Logf))
+		assert.EqualT(t, "$.Models[0]", s.Shared.DumpData)
+	})
+
+	t.Run("should ignore options for other commands", func(t *testing.T) {
+		s := parse(t)
+		require.NoError(t, applyConfigOptions(s, s.parsed, map[string]any{
+			"client-package": "sdk",
+		}, t.Logf))
+	})
+
+	t.Run("should reject invalid options", func(t *testing.T) {
+		for _, options := range []map[string]any{
+			{"no-such-option": true},
+			{"config-file": "other.yml"},
+			{"profile": "server"},
+			{"flag-strategy": "cobra"},
+			{"concurrency": "many"},
+		} {
+			require.Error(t, applyConfigOptions(parse(t), nil, options, t.Logf))
+		}
+	})
+}
+
+type parsedProbe struct {
+	Flag string `default:"x" long:"flag"`
+
+	parsed      *flags.Command
+	packOptions map[string]string
+}
+
+func (p *parsedProbe) setParsed(command *flags.Command, packOptions map[string]string) {
+	p.parsed = command
+	p.packOptions = packOptions
+}
+
+func (p *parsedProbe) Execute(_ []string) error { return nil }
+
+func TestConfigureParser(t *testing.T) {
+	for _, tc := range []struct {
+		args     []string
+		expected bool
+	}{
+		{args: []string{"generate", "probe"}, expected: false},
+		{args: []string{"generate", "probe", "--flag=x"}, expected: true},
+	} {
+		probe := new(parsedProbe)
+		parser := flags.NewNamedParser("swagger", flags.None)
+		generate, err := parser.AddCommand("generate", "", "", &struct{}{})
+		require.NoError(t, err)
+		_, err = generate.AddCommand("probe", "", "", probe)
+		require.NoError(t, err)
+		ConfigureParser(parser)
+
+		_, err = parser.ParseArgs(tc.args)
+		require.NoError(t, err)
+		require.NotNil(t, probe.parsed)
+		assert.EqualT(t, "probe", probe.parsed.Name)
+		assert.EqualT(t, tc.expected, isSetOnCommandLine(probe.parsed, "flag"))
+	}
+}
+
+func TestTagValues(t *testing.T) {
+	field, ok := reflect.TypeFor[FlattenCmdOptions]().FieldByName("WithFlatten")
+	require.TrueT(t, ok)
+
+	assert.Equal(t, []string{"minimal", "verbose"}, tagValues(field.Tag, "default"))
+	assert.Equal(t, []string{"minimal", "full", "expand", "verbose", "noverbose", "remove-unused", "keep-names"}, tagValues(field.Tag, "choice"))
+	assert.Equal(t, []string{"with-flatten"}, tagValues(field.Tag, "long"))
+	assert.Empty(t, tagValues(field.Tag, "short"))
+}
+
+func TestCreateSwagger_ConfigProfile(t *testing.T) {
+	base := t.TempDir()
+	target := filepath.Join(base, "generated")
+	require.NoError(t, os.MkdirAll(target, 0o755))
+	require.NoError(t, os.WriteFile(filepath.Join(target, "go.mod"), []byte("module generated\n"), 0o600))
+
+	spec, err := filepath.Abs(filepath.Join("..", "..", "..", "..", "fixtures", "codegen", "todolist.simple.yml"))
+	require.NoError(t, err)
+
+	t.Setenv("TEST_MODEL_PACKAGE", "dto")
+	config := filepath.Join(base, "swagger.yml")
+	require.NoError(t, os.WriteFile(config, []byte(`
+options:
+  spec: `+spec+`
+profiles:
+  base:
+    options:
+      model-package: ${TEST_MODEL_PACKAGE}
+      skip-validation: true
+  models:
+    extends: base
+    options:
+      target: `+target+`
+`), 0o600))
+
+	m := new(Model)
+	_, err = flags.NewParser(m, flags.None).ParseArgs([]string{"--config-file", config, "--profile", "models"})
+	require.NoError(t, err)
+	require.NoError(t, m.Execute(nil))
+
+	assert.FileExists(t, filepath.Join(target, "dto", "task.go"))
+
+	t.Run("should fail with an unknown profile", func(t *testing.T) {
+		m := new(Model)
+		_, err := flags.NewParser(m, flags.None).ParseArgs([]string{"--config-file", config, "--profile", "nope"})
+		require.NoError(t, err)
+		require.Error(t, m.Execute(nil))
+	})
+
+	t.Run("should fail with a profile and no config file", func(t *testing.T) {
+		m := new(Model)
+		m.Shared.Profile = "models"
+		require.Error(t, m.Execute(nil))
+	})
+}
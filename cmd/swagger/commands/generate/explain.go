@@ -0,0 +1,79 @@
+// SPDX-FileCopyrightText: Copyright 2015-2025 go-swagger maintainers
+// SPDX-License-Identifier: Apache-2.0
+
+package generate
+
+import (
+	"fmt"
+	"io"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/go-swagger/go-swagger/generator"
+)
+
+// explainReport collects how generated files are produced, as reported with the --explain flag.
+type explainReport struct {
+	files []generator.Event
+}
+
+// newExplainReport collects explanations from the events of the generation, then forwards events
+// to the progress renderer.
+func newExplainReport(opts *generator.GenOpts) *explainReport {
+	report := &explainReport{}
+	next := opts.Events
+
+	opts.Events = func(e generator.Event) {
+		if (e.Kind == generator.EventFileWritten || e.Kind == generator.EventFileSkipped) && e.Explain != nil {
+			report.files = append(report.files, e)
+		}
+
+		if next != nil {
+			next(e)
+		}
+	}
+
+	return report
+}
+
+// print the report, with the paths of generated files relative to the target.
+func (r *explainReport) print(w io.Writer, target string) {
+	sort.Slice(r.files, func(i, j int) bool { return r.files[i].Path < r.files[j].Path })
+	target, _ = filepath.Abs(target)
+
+	for _, file := range r.files {
+		pth := file.Path
+		if abs, err := filepath.Abs(pth); err == nil {
+			if rel, err := filepath.Rel(target, abs); err == nil && !strings.HasPrefix(rel, "..") {
+				pth = rel
+			}
+		}
+		explanation := file.Explain
+
+		fmt.Fprintln(w, pth)
+		fmt.Fprintf(w, "  template:  %s (%s)\n", explanation.Template, explanation.Source)
+		fmt.Fprintf(w, "  resolved:  %s\n", describeTemplateSource(explanation.Resolved))
+		for i, include := range explanation.Includes {
+			label := "           "
+			if i == 0 {
+				label = "  includes:"
+			}
+			fmt.Fprintf(w, "%s %s\n", label, describeTemplateSource(include))
+		}
+		fmt.Fprintf(w, "  data type: %s\n", explanation.DataType)
+	}
+}
+
+func describeTemplateSource(source generator.TemplateSource) string {
+	if source.Origin == "" {
+		return source.Name + " (undefined)"
+	}
+
+	var protected string
+	if source.Protected {
+		protected = ", protected"
+	}
+
+	return fmt.Sprintf("%s (%s %s%s)", source.Name, source.Origin, source.File, protected)
+}
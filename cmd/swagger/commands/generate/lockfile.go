@@ -0,0 +1,250 @@
+// SPDX-FileCopyrightText: Copyright 2015-2025 go-swagger maintainers
+// SPDX-License-Identifier: Apache-2.0
+
+package generate
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"slices"
+	"strings"
+
+	flags "github.com/jessevdk/go-flags"
+
+	"github.com/go-swagger/go-swagger/generator"
+)
+
+// unlockedFlags are the flags which are not recorded in the lockfile: the spec is recorded on its own,
+// the target is the directory where the generation is replayed, and the others do not change the generated code.
+var unlockedFlags = map[string]bool{
+	"spec":        true,
+	"target":      true,
+	"watch":       true,
+	"dump-data":   true,
+	"dump-format": true,
+	"progress":    true,
+	"explain":     true,
+	"force":       true,
+}
+
+// lockedOption is an option of the generation, to be recorded in the lockfile.
+type lockedOption struct {
+	values []string
+	path   bool // values are file names, recorded relative to the target
+}
+
+// resolvedOptions collects the options of the generation, by long name.
+//
+// They are collected once the options of the configuration file are applied to the command, with the default values:
+// the generation is replayed with the same options, even if the configuration file or the defaults change.
+// Options left empty are not recorded.
+func resolvedOptions(s sharedCommand) map[string]lockedOption {
+	options := make(map[string]lockedOption)
+	for name, flag := range commandFlags(s) {
+		if unlockedFlags[name] || flag.value.IsZero() || (flag.value.Kind() == reflect.Slice && flag.value.Len() == 0) {
+			continue
+		}
+
+		options[name] = lockedOption{values: flag.strings(), path: flag.isPath() || isLocalTemplatePack(name, flag.strings())}
+	}
+
+	for name, value := range s.getPackOptions() {
+		options[templatePackNamespace+"."+name] = lockedOption{values: []string{value}}
+	}
+
+	return options
+}
+
+func (f commandFlag) strings() []string {
+	if f.value.Kind() != reflect.Slice {
+		return []string{fmt.Sprint(f.value.Interface())}
+	}
+
+	values := make([]string, 0, f.value.Len())
+	for i := range f.value.Len() {
+		values = append(values, fmt.Sprint(f.value.Index(i).Interface()))
+	}
+
+	return values
+}
+
+func (f commandFlag) isPath() bool {
+	t := f.value.Type()
+	if t.Kind() == reflect.Slice {
+		t = t.Elem()
+	}
+
+	return t == reflect.TypeFor[flags.Filename]()
+}
+
+// isLocalTemplatePack tells if a template pack is a local directory, rather than a contributed pack or a go module.
+func isLocalTemplatePack(name string, values []string) bool {
+	if name != templatePackFlag || len(values) == 0 {
+		return false
+	}
+
+	info, err := os.Stat(values[0])
+
+	return err == nil && info.IsDir()
+}
+
+// commandName is the name of a generate command, as recorded in the lockfile.
+func commandName(s sharedCommand) string {
+	switch s.(type) {
+	case *Server:
+		return "server"
+	case *Client:
+		return "client"
+	case *Cli:
+		return "cli"
+	case *Model:
+		return "model"
+	case *Operation:
+		return "operation"
+	case *Support:
+		return "support"
+	case *Markdown:
+		return "markdown"
+	case *TypeScript:
+		return "typescript"
+	case *Proto:
+		return "proto"
+	default:
+		return ""
+	}
+}
+
+// newCommand creates a generate command from its name in the lockfile.
+func newCommand(name string) (sharedCommand, bool) {
+	switch name {
+	case "server":
+		return &Server{}, true
+	case "client":
+		return &Client{}, true
+	case "cli":
+		return &Cli{}, true
+	case "model":
+		return &Model{}, true
+	case "operation":
+		return &Operation{}, true
+	case "support":
+		return &Support{}, true
+	case "markdown":
+		return &Markdown{}, true
+	case "typescript":
+		return &TypeScript{}, true
+	case "proto":
+		return &Proto{}, true
+	default:
+		return nil, false
+	}
+}
+
+// newLockfile records a generation, with its resolved options.
+func newLockfile(s sharedCommand, opts *generator.GenOpts, options map[string]lockedOption) (*generator.Lockfile, error) {
+	target, err := filepath.Abs(opts.Target)
+	if err != nil {
+		return nil, err
+	}
+
+	recorded := make(map[string][]string, len(options))
+	for name, option := range options {
+		if !option.path {
+			recorded[name] = option.values
+			continue
+		}
+
+		for _, value := range option.values {
+			abs, err := filepath.Abs(value)
+			if err != nil {
+				return nil, err
+			}
+
+			rel, err := filepath.Rel(target, abs)
+			if err != nil {
+				return nil, err
+			}
+			recorded[name] = append(recorded[name], filepath.ToSlash(rel))
+		}
+	}
+
+	return opts.NewLockfile(commandName(s), recorded)
+}
+
+// replayCommand builds the command line of a recorded generation, for the doc.go and generate.go files.
+func replayCommand(lock *generator.Lockfile) generator.ReplayFunc {
+	return func(dir string) []string {
+		args, err := ReplayArgs(lock, dir)
+		if err != nil {
+			return nil
+		}
+
+		return append([]string{"swagger", "generate"}, args...)
+	}
+}
+
+// ReplayArgs builds the command line of the generation recorded in the lockfile of a directory,
+// e.g. ["server", "--spec=../swagger.yml", "--target=dir"].
+func ReplayArgs(lock *generator.Lockfile, dir string) ([]string, error) {
+	cmd, ok := newCommand(lock.Command)
+	if !ok {
+		return nil, fmt.Errorf("unknown generate command %q", lock.Command)
+	}
+	known := commandFlags(cmd)
+
+	args := []string{lock.Command}
+	if lock.Spec.Path != "" {
+		args = append(args, "--spec="+resolvePath(dir, lock.Spec.Path))
+	}
+	args = append(args, "--target="+dir)
+
+	names := make([]string, 0, len(lock.Options))
+	for name := range lock.Options {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+
+	for _, name := range names {
+		values := lock.Options[name]
+		if strings.HasPrefix(name, templatePackNamespace+".") {
+			for _, value := range values {
+				args = append(args, "--"+name+"="+value)
+			}
+
+			continue
+		}
+
+		flag, ok := known[name]
+		if !ok || unlockedFlags[name] {
+			return nil, fmt.Errorf("unknown option %q for generate %s", name, lock.Command)
+		}
+
+		if flag.value.Kind() == reflect.Bool {
+			if slices.Equal(values, []string{"true"}) {
+				args = append(args, "--"+name)
+			}
+
+			continue
+		}
+
+		for _, value := range values {
+			if flag.isPath() || (name == templatePackFlag && isLocalTemplatePack(name, []string{resolvePath(dir, value)})) {
+				value = resolvePath(dir, value)
+			}
+			args = append(args, "--"+name+"="+value)
+		}
+	}
+
+	return args, nil
+}
+
+// resolvePath resolves a file name recorded relative to a target directory.
+func resolvePath(dir, value string) string {
+	if strings.HasPrefix(value, "http://") || strings.HasPrefix(value, "https://") || filepath.IsAbs(value) {
+		return value
+	}
+
+	return filepath.Join(dir, filepath.FromSlash(value))
+}
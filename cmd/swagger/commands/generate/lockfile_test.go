@@ -0,0 +1,114 @@
+// SPDX-FileCopyrightText: Copyright 2015-2025 go-swagger maintainers
+// SPDX-License-Identifier: Apache-2.0
+
+package generate
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	flags "github.com/jessevdk/go-flags"
+
+	"github.com/go-openapi/testify/v2/assert"
+	"github.com/go-openapi/testify/v2/require"
+
+	"github.com/go-swagger/go-swagger/generator"
+)
+
+func TestLockfile_Replay(t *testing.T) {
+	dir := t.TempDir()
+	spec := filepath.Join(dir, "api", "swagger.yml")
+	target := filepath.Join(dir, "gen")
+	require.NoError(t, os.MkdirAll(filepath.Dir(spec), 0o700))
+	require.NoError(t, os.WriteFile(spec, []byte("swagger: \"2.0\"\n"), 0o600))
+
+	s := new(Server)
+	parser := flags.NewParser(s, flags.None)
+	_, err := parser.ParseArgs([]string{
+		"--spec", spec,
+		"--target", target,
+		"--name", "petstore",
+		"--exclude-main",
+		"--struct-tags=json", "--struct-tags=yaml",
+		"--config-file", filepath.Join(dir, "swagger-gen.yml"),
+		"--watch",
+	})
+	require.NoError(t, err)
+	s.setParsed(parser.Command, nil)
+	require.NoError(t, applyConfigOptions(s, s.getParsed(), map[string]any{"model-package": "entities", "force": true}, t.Logf))
+
+	opts := new(generator.GenOpts)
+	s.apply(opts)
+	recorded, err := newLockfile(s, opts, resolvedOptions(s))
+	require.NoError(t, err)
+	require.NoError(t, opts.WriteLockfile(recorded))
+
+	lock, err := generator.ReadLockfile(target)
+	require.NoError(t, err)
+	assert.EqualT(t, "server", lock.Command)
+	assert.EqualT(t, "../api/swagger.yml", lock.Spec.Path)
+	// options are recorded as resolved with the config file and the defaults
+	assert.Equal(t, map[string][]string{
+		"name":               {"petstore"},
+		"exclude-main":       {"true"},
+		"struct-tags":        {"json", "yaml"},
+		"config-file":        {"../swagger-gen.yml"},
+		"model-package":      {"entities"},
+		"api-package":        {"operations"},
+		"server-package":     {"restapi"},
+		"compatibility-mode": {"modern"},
+		"default-consumes":   {"application/json"},
+		"default-produces":   {"application/json"},
+		"default-scheme":     {"http"},
+		"flag-strategy":      {"go-flags"},
+		"operation-grouping": {"tag"},
+		"with-flatten":       {"minimal", "verbose"},
+	}, lock.Options)
+	assert.FalseT(t, lock.SpecChanged(target))
+
+	// the generation is replayed from another directory
+	args, err := ReplayArgs(lock, filepath.Join("elsewhere", "gen"))
+	require.NoError(t, err)
+	assert.Equal(t, []string{
+		"server",
+		"--spec=" + filepath.Join("elsewhere", "api", "swagger.yml"),
+		"--target=" + filepath.Join("elsewhere", "gen"),
+		"--api-package=operations",
+		"--compatibility-mode=modern",
+		"--config-file=" + filepath.Join("elsewhere", "swagger-gen.yml"),
+		"--default-consumes=application/json",
+		"--default-produces=application/json",
+		"--default-scheme=http",
+		"--exclude-main",
+		"--flag-strategy=go-flags",
+		"--model-package=entities",
+		"--name=petstore",
+		"--operation-grouping=tag",
+		"--server-package=restapi",
+		"--struct-tags=json",
+		"--struct-tags=yaml",
+		"--with-flatten=minimal",
+		"--with-flatten=verbose",
+	}, args)
+
+	replayed := new(Server)
+	_, err = flags.NewParser(replayed, flags.None).ParseArgs(args[1:])
+	require.NoError(t, err)
+	assert.EqualT(t, "petstore", replayed.Name)
+	assert.Equal(t, []string{"json", "yaml"}, replayed.Models.StructTags)
+	assert.EqualT(t, "entities", replayed.Models.ModelPackage)
+	assert.FalseT(t, replayed.Shared.Force)
+
+	t.Run("should detect a change of the spec", func(t *testing.T) {
+		require.NoError(t, os.WriteFile(spec, []byte("swagger: \"2.0\"\ninfo: {}\n"), 0o600))
+		assert.TrueT(t, lock.SpecChanged(target))
+	})
+
+	t.Run("should reject an unknown option", func(t *testing.T) {
+		lock.Options["not-an-option"] = []string{"true"}
+		_, err := ReplayArgs(lock, target)
+		require.Error(t, err)
+		assert.StringContainsT(t, err.Error(), `unknown option "not-an-option" for generate server`)
+	})
+}
@@ -19,6 +19,7 @@ type modelOptions struct {
 	AllDefinitions             bool     `description:"generate all model definitions regardless of usage in operations"                                      hidden:"deprecated"                          long:"all-definitions"`
 	StructTags                 []string `description:"the struct tags to generate, repeat for multiple (defaults to json)"                                   long:"struct-tags"`
 	RootedErrorPath            bool     `description:"extends validation errors with the type name instead of an empty path, in the case of arrays and maps" long:"rooted-error-path"`
+	SplitModelsByFile          bool     `description:"generate definitions imported from other spec files in sub-packages named after these files"           long:"split-models-by-file"`
 }
 
 func (mo modelOptions) apply(opts *generator.GenOpts) {
@@ -30,6 +31,7 @@ func (mo modelOptions) apply(opts *generator.GenOpts) {
 	opts.IgnoreOperations = mo.AllDefinitions
 	opts.StructTags = mo.StructTags
 	opts.WantsRootedErrorPath = mo.RootedErrorPath
+	opts.SplitModelsByFile = mo.SplitModelsByFile
 }
 
 // WithModels adds the model options group.
@@ -53,7 +55,7 @@ type Model struct {
 
 // Execute generates a model file.
 func (m *Model) Execute(_ []string) error {
-	if m.Shared.DumpData && len(append(m.Name, m.Models.Models...)) > 1 {
+	if m.Shared.DumpData != "" && len(append(m.Name, m.Models.Models...)) > 1 {
 		return errors.New("only 1 model at a time is supported for dumping data")
 	}
 
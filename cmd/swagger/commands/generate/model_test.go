@@ -56,7 +56,7 @@ func TestGenerateModel(t *testing.T) {
 func TestGenerateModel_Check(t *testing.T) {
 	m := &generate.Model{}
 	_, _ = flags.Parse(m)
-	m.Shared.DumpData = true
+	m.Shared.DumpData = "$"
 	m.Name = []string{"model1", "model2"}
 	require.Error(t, m.Execute([]string{}))
 }
@@ -16,7 +16,8 @@ type operationOptions struct {
 	WithEnumCI bool     `description:"allow case-insensitive enumerations"                                    long:"with-enum-ci"`
 
 	// tags handling
-	SkipTagPackages bool `description:"skips the generation of tag-based operation packages, resulting in a flat generation" long:"skip-tag-packages"`
+	SkipTagPackages   bool   `description:"skips the generation of tag-based operation packages, resulting in a flat generation" long:"skip-tag-packages"`
+	OperationGrouping string `choice:"tag"                                                                                       choice:"path" choice:"extension" choice:"rules" default:"tag" description:"the strategy to group operations into packages: by first tag, first path segment, x-go-package extension or operation_packages rules in the config file" long:"operation-grouping"`
 }
 
 func (oo operationOptions) apply(opts *generator.GenOpts) {
@@ -25,6 +26,7 @@ func (oo operationOptions) apply(opts *generator.GenOpts) {
 	opts.APIPackage = oo.APIPackage
 	opts.AllowEnumCI = oo.WithEnumCI
 	opts.SkipTagPackages = oo.SkipTagPackages
+	opts.OperationGrouping = oo.OperationGrouping
 }
 
 // WithOperations adds the operations options group.
@@ -54,7 +56,7 @@ type Operation struct {
 
 // Execute generates a model file.
 func (o *Operation) Execute(_ []string) error {
-	if o.Shared.DumpData && len(append(o.Name, o.Operations.Operations...)) > 1 {
+	if o.Shared.DumpData != "" && len(append(o.Name, o.Operations.Operations...)) > 1 {
 		return errors.New("only 1 operation at a time is supported for dumping data")
 	}
 
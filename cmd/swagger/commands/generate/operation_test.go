@@ -57,7 +57,7 @@ func testGenerateOperation(strict bool) func(*testing.T) {
 func TestGenerateOperation_Check(t *testing.T) {
 	m := &generate.Operation{}
 	_, _ = flags.ParseArgs(m, []string{"--name=op1", "--name=op2"})
-	m.Shared.DumpData = true
+	m.Shared.DumpData = "$"
 	m.Name = []string{"op1", "op2"}
 
 	require.Error(t, m.Execute([]string{}))
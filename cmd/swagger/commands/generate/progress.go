@@ -0,0 +1,163 @@
+// SPDX-FileCopyrightText: Copyright 2015-2025 go-swagger maintainers
+// SPDX-License-Identifier: Apache-2.0
+
+package generate
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"log/slog"
+	"strings"
+	"time"
+
+	"github.com/go-swagger/go-swagger/generator"
+)
+
+// progress reporting modes, as selected with the --progress flag.
+const (
+	progressLog   = "log"
+	progressQuiet = "quiet"
+	progressBar   = "bar"
+	progressJSON  = "json"
+)
+
+const progressBarWidth = 30
+
+// progressRenderer displays the progress of a generation.
+type progressRenderer interface {
+	// apply configures the generation options to report progress to this renderer.
+	apply(opts *generator.GenOpts)
+	// logf reports an informational message, e.g. about the configuration of the generation.
+	logf(format string, args ...any)
+	// warnf reports a warning.
+	warnf(format string, args ...any)
+	// finish completes the display once the generation is over.
+	finish()
+}
+
+func newProgressRenderer(mode string, stdout, stderr io.Writer) progressRenderer {
+	switch mode {
+	case progressQuiet:
+		return quietProgress{stderr: stderr}
+	case progressBar:
+		return &barProgress{quietProgress: quietProgress{stderr: stderr}, start: time.Now()}
+	case progressJSON:
+		return &jsonProgress{quietProgress: quietProgress{stderr: stderr}, enc: json.NewEncoder(stdout)}
+	default:
+		return logProgress{}
+	}
+}
+
+// logProgress keeps the historical log messages.
+type logProgress struct{}
+
+func (logProgress) apply(*generator.GenOpts) {}
+func (logProgress) finish()                  {}
+
+func (logProgress) logf(format string, args ...any) {
+	log.Printf(format, args...)
+}
+
+func (logProgress) warnf(format string, args ...any) {
+	log.Printf("warning: "+format, args...)
+}
+
+// quietProgress only reports warnings.
+type quietProgress struct {
+	stderr io.Writer
+}
+
+func (p quietProgress) apply(opts *generator.GenOpts) {
+	opts.Logger = p.logger()
+}
+
+func (p quietProgress) logger() *slog.Logger {
+	return slog.New(slog.NewTextHandler(p.stderr, &slog.HandlerOptions{Level: slog.LevelWarn}))
+}
+
+func (p quietProgress) logf(format string, args ...any) {
+	p.logger().Info(fmt.Sprintf(format, args...))
+}
+
+func (p quietProgress) warnf(format string, args ...any) {
+	p.logger().Warn(fmt.Sprintf(format, args...))
+}
+
+func (quietProgress) finish() {}
+
+// barProgress draws a progress bar, followed by a summary of the generation.
+type barProgress struct {
+	quietProgress
+
+	start   time.Time
+	total   int
+	done    int
+	written int
+	skipped int
+	drawn   bool
+}
+
+func (p *barProgress) apply(opts *generator.GenOpts) {
+	p.quietProgress.apply(opts)
+	opts.Events = p.handle
+}
+
+func (p *barProgress) handle(e generator.Event) {
+	switch e.Kind {
+	case generator.EventItemsPlanned:
+		p.total += e.Total
+	case generator.EventModelRendered, generator.EventOperationRendered, generator.EventOperationGroupRendered:
+		p.done++
+	case generator.EventFileWritten:
+		p.written++
+	case generator.EventFileSkipped:
+		p.skipped++
+	default:
+		return
+	}
+
+	p.draw()
+}
+
+func (p *barProgress) draw() {
+	filled := 0
+	if p.total > 0 {
+		filled = min(progressBarWidth*p.done/p.total, progressBarWidth)
+	}
+
+	fmt.Fprintf(p.stderr, "\r[%s%s] %d/%d items, %d files written, %d skipped",
+		strings.Repeat("=", filled), strings.Repeat(" ", progressBarWidth-filled),
+		p.done, p.total, p.written, p.skipped,
+	)
+	p.drawn = true
+}
+
+func (p *barProgress) finish() {
+	if p.drawn {
+		fmt.Fprintln(p.stderr)
+	}
+
+	fmt.Fprintf(p.stderr, "generated %d files (%d skipped) in %s\n",
+		p.written, p.skipped, time.Since(p.start).Round(time.Millisecond),
+	)
+}
+
+// jsonProgress writes every event as a line of JSON.
+type jsonProgress struct {
+	quietProgress
+
+	enc *json.Encoder
+}
+
+func (p *jsonProgress) apply(opts *generator.GenOpts) {
+	p.quietProgress.apply(opts)
+	opts.Events = p.handle
+}
+
+func (p *jsonProgress) handle(e generator.Event) {
+	if err := p.enc.Encode(e); err != nil {
+		fmt.Fprintf(p.stderr, "could not report progress: %v\n", err)
+	}
+}
@@ -0,0 +1,94 @@
+// SPDX-FileCopyrightText: Copyright 2015-2025 go-swagger maintainers
+// SPDX-License-Identifier: Apache-2.0
+
+package generate
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/go-openapi/testify/v2/assert"
+	"github.com/go-openapi/testify/v2/require"
+
+	"github.com/go-swagger/go-swagger/generator"
+)
+
+func TestProgressRenderer(t *testing.T) {
+	events := []generator.Event{
+		{Kind: generator.EventSpecLoaded, Path: "swagger.yml"},
+		{Kind: generator.EventItemsPlanned, Total: 2},
+		{Kind: generator.EventFileWritten, Path: "models/a.go", Template: "definition"},
+		{Kind: generator.EventModelRendered, Name: "A"},
+		{Kind: generator.EventFileSkipped, Path: "models/b.go", Reason: generator.SkipReasonUpToDate},
+		{Kind: generator.EventModelRendered, Name: "B"},
+		{Kind: generator.EventGenerationDone},
+	}
+
+	run := func(mode string) (string, string, *generator.GenOpts) {
+		var stdout, stderr bytes.Buffer
+		opts := new(generator.GenOpts)
+		progress := newProgressRenderer(mode, &stdout, &stderr)
+		progress.apply(opts)
+
+		if opts.Events != nil {
+			for _, e := range events {
+				opts.Events(e)
+			}
+		}
+		if opts.Logger != nil {
+			opts.Logger.Info("an info message")
+			opts.Logger.Warn("a warning")
+		}
+		progress.logf("reading config from %s", "swagger.yml")
+		progress.warnf("option %q in config file is overridden by the command line", "target")
+		progress.finish()
+
+		return stdout.String(), stderr.String(), opts
+	}
+
+	t.Run("should keep log messages by default", func(t *testing.T) {
+		stdout, stderr, opts := run(progressLog)
+		assert.Nil(t, opts.Logger)
+		assert.Nil(t, opts.Events)
+		assert.Empty(t, stdout)
+		assert.Empty(t, stderr)
+	})
+
+	t.Run("should only report warnings when quiet", func(t *testing.T) {
+		stdout, stderr, opts := run(progressQuiet)
+		assert.Nil(t, opts.Events)
+		assert.Empty(t, stdout)
+		assert.StringContainsT(t, stderr, "a warning")
+		assert.StringContainsT(t, stderr, "overridden by the command line")
+		assert.NotContains(t, stderr, "an info message")
+		assert.NotContains(t, stderr, "reading config")
+	})
+
+	t.Run("should draw a progress bar", func(t *testing.T) {
+		stdout, stderr, _ := run(progressBar)
+		assert.Empty(t, stdout)
+		assert.StringContainsT(t, stderr, "\r[==============================] 2/2 items, 1 files written, 1 skipped")
+		assert.StringContainsT(t, stderr, "generated 1 files (1 skipped) in ")
+		assert.NotContains(t, stderr, "an info message")
+	})
+
+	t.Run("should write events as JSON lines", func(t *testing.T) {
+		stdout, stderr, _ := run(progressJSON)
+		assert.NotContains(t, stderr, "an info message")
+		assert.NotContains(t, stdout, "reading config")
+		assert.NotContains(t, stdout, "overridden by the command line")
+
+		lines := strings.Split(strings.TrimSpace(stdout), "\n")
+		require.Len(t, lines, len(events))
+
+		for i, line := range lines {
+			var e generator.Event
+			require.NoError(t, json.Unmarshal([]byte(line), &e))
+			assert.EqualT(t, events[i].Kind, e.Kind)
+			assert.EqualT(t, events[i].Name, e.Name)
+			assert.EqualT(t, events[i].Path, e.Path)
+		}
+	})
+}
@@ -0,0 +1,43 @@
+// SPDX-FileCopyrightText: Copyright 2015-2025 go-swagger maintainers
+// SPDX-License-Identifier: Apache-2.0
+
+package generate
+
+import (
+	"github.com/go-swagger/go-swagger/generator"
+)
+
+// Proto generates protocol buffers definitions from the definitions of the spec.
+type Proto struct {
+	WithShared
+	WithModels
+
+	Name            string `description:"the name of the application, defaults to a mangled value of info.title"                   long:"name"             short:"A"`
+	ProtoPackage    string `description:"the package of the .proto file, defaults to the name of the application"                long:"proto-package"`
+	GoPackage       string `description:"the go_package option of the .proto file, defaults to the proto package in the target" long:"go-package"`
+	WithConversions bool   `description:"generate go functions converting the models to and from the types generated by protoc" long:"with-conversions"`
+}
+
+// Execute runs this command.
+func (p *Proto) Execute(_ []string) error {
+	return createSwagger(p)
+}
+
+// apply options.
+func (p Proto) apply(opts *generator.GenOpts) {
+	p.Shared.apply(opts)
+	p.Models.apply(opts)
+
+	opts.LanguageOpts = generator.ProtoOpts(opts.WithExtraInitialisms...)
+	opts.Name = p.Name
+	opts.ProtoPackage = p.ProtoPackage
+	opts.ProtoGoPackage = p.GoPackage
+	opts.ProtoConversions = p.WithConversions
+}
+
+func (p *Proto) generate(opts *generator.GenOpts) error {
+	return generator.GenerateProto(p.Name, p.Models.Models, opts)
+}
+
+func (p Proto) log(_ string) {
+}
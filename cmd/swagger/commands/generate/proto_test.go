@@ -0,0 +1,38 @@
+// SPDX-FileCopyrightText: Copyright 2015-2025 go-swagger maintainers
+// SPDX-License-Identifier: Apache-2.0
+
+package generate_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	flags "github.com/jessevdk/go-flags"
+
+	"github.com/go-openapi/testify/v2/assert"
+	"github.com/go-openapi/testify/v2/require"
+
+	"github.com/go-swagger/go-swagger/cmd/swagger/commands/generate"
+)
+
+func TestProto(t *testing.T) {
+	generated := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(generated, "go.mod"), []byte("module example.com/petstore\n\ngo 1.25\n"), 0o600))
+
+	m := &generate.Proto{}
+	_, _ = flags.ParseArgs(m, []string{"--skip-validation"})
+	m.Shared.Spec = flags.Filename(filepath.Join(testBase(), "fixtures", "codegen", "proto.yml"))
+	m.Shared.Target = flags.Filename(generated)
+	m.ProtoPackage = "petstore.v1"
+	m.GoPackage = "example.com/petstore/proto;petstorev1"
+	m.WithConversions = true
+	require.NoError(t, m.Execute([]string{}))
+
+	assert.FileExists(t, filepath.Join(generated, "proto", "proto.lock.json"))
+
+	conversions, err := os.ReadFile(filepath.Join(generated, "protoconv", "conversions.go"))
+	require.NoError(t, err)
+	assert.StringContainsT(t, string(conversions), `"example.com/petstore/models"`)
+	assert.StringContainsT(t, string(conversions), `pb "example.com/petstore/proto"`)
+}
@@ -4,6 +4,7 @@
 package generate
 
 import (
+	"errors"
 	"fmt"
 	"log"
 	"os"
@@ -87,6 +88,12 @@ func (f *FlattenCmdOptions) SetFlattenOptions(dflt *analysis.FlattenOpts) (res *
 type sharedCommand interface {
 	apply(options *generator.GenOpts)
 	getConfigFile() string
+	getProgress() string
+	getProfile() string
+	getExplain() bool
+	getWatch() bool
+	getParsed() *flags.Command
+	getPackOptions() map[string]string
 	generate(options *generator.GenOpts) error
 	log(command string)
 }
@@ -120,43 +127,97 @@ func (m mediaOptions) apply(opts *generator.GenOpts) {
 // WithShared adds the shared options group.
 type WithShared struct {
 	Shared sharedOptions `group:"Options common to all code generation commands"`
+
+	parsed      *flags.Command    // the command as parsed, to know which flags are set on the command line
+	packOptions map[string]string // the options of the template pack set on the command line
+}
+
+func (w *WithShared) setParsed(command *flags.Command, packOptions map[string]string) {
+	w.parsed = command
+	w.packOptions = packOptions
+}
+
+func (w WithShared) getParsed() *flags.Command {
+	return w.parsed
+}
+
+func (w WithShared) getPackOptions() map[string]string {
+	return w.packOptions
 }
 
 func (w WithShared) getConfigFile() string {
 	return string(w.Shared.ConfigFile)
 }
 
+func (w WithShared) getProgress() string {
+	return w.Shared.Progress
+}
+
+func (w WithShared) getProfile() string {
+	return w.Shared.Profile
+}
+
+func (w WithShared) getExplain() bool {
+	return w.Shared.Explain
+}
+
+func (w WithShared) getWatch() bool {
+	return w.Shared.Watch
+}
+
 type sharedOptionsCommon struct {
 	FlattenCmdOptions
 
 	Spec                  flags.Filename `description:"the spec file to use (default swagger.{json,yml,yaml})"                             group:"shared"                                            long:"spec"                    short:"f"`
 	Target                flags.Filename `default:"./"                                                                                     description:"the base directory for generating the files" group:"shared"                 long:"target"   short:"t"`
 	Template              string         `choice:"stratoscale"                                                                             description:"load contributed templates"                  group:"shared"                 long:"template"`
+	TemplatePack          string         `description:"load a template pack: a contributed pack, a directory or a go module path[@version][//subdir]" group:"shared"                                 long:"template-pack"`
 	TemplateDir           flags.Filename `description:"alternative template override directory"                                            group:"shared"                                            long:"template-dir"            short:"T"`
-	ConfigFile            flags.Filename `description:"configuration file to use for overriding template options"                          group:"shared"                                            long:"config-file"             short:"C"`
+	ConfigFile            flags.Filename `description:"configuration file to use for overriding template and generation options"           group:"shared"                                            long:"config-file"             short:"C"`
+	Profile               string         `description:"the profile of generation options to use from the configuration file"             group:"shared"                                            long:"profile"`
 	CopyrightFile         flags.Filename `description:"copyright file used to add copyright header"                                        group:"shared"                                            long:"copyright-file"          short:"r"`
 	AdditionalInitialisms []string       `description:"consecutive capitals that should be considered intialisms"                          group:"shared"                                            long:"additional-initialism"`
 	AllowTemplateOverride bool           `description:"allows overriding protected templates"                                              group:"shared"                                            long:"allow-template-override"`
 	SkipValidation        bool           `description:"skips validation of spec prior to generation"                                       group:"shared"                                            long:"skip-validation"`
-	DumpData              bool           `description:"when present dumps the json for the template generator instead of generating files" group:"shared"                                            long:"dump-data"`
+	DumpData              string         `description:"when present dumps the json for the template generator instead of generating files. Takes an optional selector: the name of a model or an operation, or a JSONPath like $.Models[0]" group:"shared" long:"dump-data" optional:"yes" optional-value:"$"`
+	DumpFormat            string         `choice:"json" choice:"yaml" default:"json" description:"the format of the data dumped with --dump-data" group:"shared" long:"dump-format"`
 	StrictResponders      bool           `description:"Use strict type for the handler return value"                                       long:"strict-responders"`
 	ReturnErrors          bool           `description:"handlers explicitly return an error as the second value"                            group:"shared"                                            long:"return-errors"           short:"e"`
+	Force                 bool           `description:"regenerate all files, even those found up to date since the previous generation"     group:"shared"                                            long:"force"`
+	Concurrency           int            `description:"maximum number of files rendered and formatted in parallel (defaults to the number of CPUs)" group:"shared"                                    long:"concurrency"             short:"j"`
+	Progress              string         `choice:"log"                                                                                     choice:"quiet"                                            choice:"bar"                   choice:"json" default:"log" description:"how to report progress: log messages, quiet (warnings only), a progress bar or JSON lines events on stdout" group:"shared" long:"progress"`
+	Explain               bool           `description:"report the template which produced every generated file, where it was found, the templates it includes and its data" group:"shared" long:"explain"`
+	Watch                 bool           `description:"generate again whenever the spec, a file it references, the templates or the configuration file change" group:"shared" long:"watch"`
+	MergeEdits            bool           `description:"merge your edits of files generated once, like configure_{name}.go, with their new content" group:"shared" long:"merge-edits"`
+	Verify                bool           `description:"type-check the generated packages, and report errors with the template and the spec element which produced them" group:"shared" long:"verify"`
+	PackageDocs           bool           `description:"generate a doc.go describing the spec and the regeneration command in generated packages" group:"shared" long:"package-docs"`
+	GoGenerate            string         `choice:"swagger" choice:"go-run" description:"generate generate.go at the root of the target, with a go:generate directive running the swagger binary or go run of the go-swagger module" group:"shared" long:"go-generate"`
 }
 
 func (s sharedOptionsCommon) apply(opts *generator.GenOpts) {
 	opts.Spec = string(s.Spec)
 	opts.Target = string(s.Target)
 	opts.Template = s.Template
+	opts.TemplatePack = s.TemplatePack
 	opts.TemplateDir = string(s.TemplateDir)
 	opts.AllowTemplateOverride = s.AllowTemplateOverride
 	opts.ValidateSpec = !s.SkipValidation
-	opts.DumpData = s.DumpData
+	opts.DumpData = s.DumpData != ""
+	opts.DumpSelector = s.DumpData
+	opts.DumpFormat = s.DumpFormat
 	opts.FlattenOpts = s.SetFlattenOptions(opts.FlattenOpts)
 	opts.Copyright = string(s.CopyrightFile)
 	opts.StrictResponders = s.StrictResponders
 	opts.ReturnErrors = s.ReturnErrors
 	opts.WithCustomFormatter = s.WithCustomFormatter
 	opts.WithExtraInitialisms = s.AdditionalInitialisms
+	opts.Force = s.Force
+	opts.Concurrency = s.Concurrency
+	opts.Explain = s.Explain
+	opts.MergeEdits = s.MergeEdits
+	opts.Verify = s.Verify
+	opts.PackageDocs = s.PackageDocs
+	opts.GoGenerate = s.GoGenerate
 }
 
 func setCopyright(copyrightFile string) (string, error) {
@@ -172,30 +233,79 @@ func setCopyright(copyrightFile string) (string, error) {
 }
 
 func createSwagger(s sharedCommand) error {
+	if s.getWatch() {
+		return watchSwagger(s)
+	}
+
+	return generateSwagger(s, nil)
+}
+
+// generateSwagger runs a generation. The files read by the generation are recorded in inputs, if not nil.
+func generateSwagger(s sharedCommand, inputs *watchedInputs) error {
 	var (
-		cfg *viper.Viper
+		def *generator.LanguageDefinition
 		err error
 	)
 
+	progress := newProgressRenderer(s.getProgress(), os.Stdout, os.Stderr)
+
 	if configFile := s.getConfigFile(); configFile != "" {
+		inputs.addFile(configFile)
+
 		// process explicit config file argument
-		cfg, err = readConfig(configFile)
+		cfg, err := readConfig(configFile, progress.logf)
 		if err != nil {
 			return err
 		}
 
 		setDebug(cfg) // viper config Debug
+
+		def, err = configDefinition(cfg, s.getProfile())
+		if err != nil {
+			return err
+		}
+
+		// options from the config file are applied to flags which are not set on the command line
+		if err = applyConfigOptions(s, s.getParsed(), def.Options, progress.warnf); err != nil {
+			return err
+		}
+
+		// the progress mode may be set by the config file
+		progress = newProgressRenderer(s.getProgress(), os.Stdout, os.Stderr)
+	} else if s.getProfile() != "" {
+		return errors.New("a profile requires a configuration file (--config-file)")
 	}
 
+	// the options are recorded in the lockfile as resolved with the config file
+	options := resolvedOptions(s)
+
 	opts := new(generator.GenOpts)
 	s.apply(opts)
+	opts.TemplatePackOptions = s.getPackOptions()
+	if def != nil && len(def.Specs) > 0 {
+		for _, target := range def.Specs {
+			inputs.addSpec(target.Spec)
+		}
+	} else {
+		inputs.addSpec(opts.Spec)
+	}
+	inputs.addTree(opts.TemplateDir)
+	inputs.addFile(opts.Copyright)
+
+	progress.apply(opts)
+
+	var explained *explainReport
+	if s.getExplain() && s.getProgress() != progressJSON {
+		// with JSON progress, explanations are reported with the events
+		explained = newExplainReport(opts)
+	}
 
 	opts.Copyright, err = setCopyright(opts.Copyright)
 	if err != nil {
 		return fmt.Errorf("could not load copyright file: %w", err)
 	}
 
-	if opts.Template != "" {
+	if opts.Template != "" || opts.TemplatePack != "" {
 		contribOptionsOverride(opts)
 	}
 
@@ -203,13 +313,49 @@ func createSwagger(s sharedCommand) error {
 		return err
 	}
 
-	if err = configureOptsFromConfig(cfg, opts); err != nil {
-		return err
+	if def != nil {
+		if err = def.ConfigureOpts(opts); err != nil {
+			return err
+		}
 	}
 
-	if err = s.generate(opts); err != nil {
+	if pack := opts.LoadedTemplatePack(); pack != nil {
+		inputs.addTree(pack.Dir)
+	}
+
+	lock, err := newLockfile(s, opts, options)
+	if err != nil {
+		log.Printf("could not record the generation in %s: %v", opts.Target, err)
+	} else {
+		opts.ReplayCommand = replayCommand(lock)
+	}
+
+	err = generator.RunWithHooks(opts, func(opts *generator.GenOpts) error {
+		if def != nil && len(def.Specs) > 0 {
+			// several specs are generated in one run, with the models they share
+			return generator.GenerateMultiSpec(def.Specs, def.Shared, opts, s.generate)
+		}
+
+		return s.generate(opts)
+	})
+	if err != nil {
 		return err
 	}
+	progress.finish()
+
+	if lock != nil && !opts.DumpData {
+		if err := opts.WriteLockfile(lock); err != nil {
+			log.Printf("could not record the generation in %s: %v", opts.Target, err)
+		}
+	}
+
+	if explained != nil {
+		explained.print(os.Stdout, opts.Target)
+	}
+
+	if s.getProgress() == progressQuiet {
+		return nil
+	}
 
 	basepath, err := filepath.Abs(".")
 	if err != nil {
@@ -233,29 +379,17 @@ func createSwagger(s sharedCommand) error {
 	return nil
 }
 
-func readConfig(filename string) (*viper.Viper, error) {
+func readConfig(filename string, logf func(string, ...any)) (*viper.Viper, error) {
 	abspath, err := filepath.Abs(filename)
 	if err != nil {
 		return nil, err
 	}
 
-	log.Println("reading config from", abspath)
+	logf("reading config from %s", abspath)
 
 	return generator.ReadConfig(abspath)
 }
 
-func configureOptsFromConfig(cfg *viper.Viper, opts *generator.GenOpts) error {
-	if cfg == nil {
-		return nil
-	}
-
-	var def generator.LanguageDefinition
-	if err := cfg.Unmarshal(&def); err != nil {
-		return err
-	}
-	return def.ConfigureOpts(opts)
-}
-
 func setDebug(cfg *viper.Viper) {
 	if os.Getenv("DEBUG") == "" && os.Getenv("SWAGGER_DEBUG") == "" {
 		return
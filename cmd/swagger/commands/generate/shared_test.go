@@ -149,7 +149,7 @@ other: abc
 	} {
 		testCase := toPin
 		t.Run(testCase.Name, func(t *testing.T) {
-			v, err := readConfig(testCase.Filename)
+			v, err := readConfig(testCase.Filename, t.Logf)
 			if testCase.ExpectError {
 				require.Error(t, err)
 				return
@@ -0,0 +1,108 @@
+// SPDX-FileCopyrightText: Copyright 2015-2025 go-swagger maintainers
+// SPDX-License-Identifier: Apache-2.0
+
+package generate
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"strings"
+
+	flags "github.com/jessevdk/go-flags"
+
+	"github.com/go-swagger/go-swagger/generator"
+)
+
+const (
+	templatePackFlag      = "template-pack"
+	templatePackNamespace = "pack"
+)
+
+// AddTemplatePackOptions adds the options declared by the template pack given in args to the generate commands
+// which use template packs, so they show up in --help with their description and default.
+//
+// Options are named after the pack options, in the "pack" namespace, e.g. --pack.with-mocks.
+// A template pack which cannot be loaded is ignored here: the error is reported by the generation.
+func AddTemplatePackOptions(ctx context.Context, parser *flags.Parser, args []string) error {
+	ref := templatePackArg(args)
+	generate := parser.Find("generate")
+	if ref == "" || generate == nil {
+		return nil
+	}
+
+	pack, err := generator.LoadTemplatePack(ctx, ref)
+	if err != nil || len(pack.Options) == 0 {
+		return nil //nolint:nilerr // reported by the generation
+	}
+
+	// options are declared by struct tags: the struct of options is built from the manifest
+	fields := make([]reflect.StructField, 0, len(pack.Options))
+	for i, declared := range pack.Options {
+		tag := fmt.Sprintf("long:%q description:%q", declared.Name, declared.Description)
+		if declared.Default != "" {
+			tag += fmt.Sprintf(" default:%q", declared.Default)
+		}
+		if declared.Type == generator.PackOptionBool {
+			tag += ` optional:"yes" optional-value:"true"`
+		}
+
+		fields = append(fields, reflect.StructField{
+			Name: fmt.Sprintf("Option%d", i),
+			Type: reflect.TypeFor[string](),
+			Tag:  reflect.StructTag(tag),
+		})
+	}
+	optionsType := reflect.StructOf(fields)
+
+	for _, cmd := range generate.Commands() {
+		if cmd.FindOptionByLongName(templatePackFlag) == nil {
+			continue
+		}
+
+		group, err := cmd.AddGroup("Options of template pack "+pack.String(), pack.Description, reflect.New(optionsType).Interface())
+		if err != nil {
+			return fmt.Errorf("could not add the options of template pack %s: %w", pack, err)
+		}
+		group.Namespace = templatePackNamespace
+	}
+
+	return nil
+}
+
+// templatePackArg finds the value of the --template-pack flag in the command line.
+func templatePackArg(args []string) string {
+	prefix := "--" + templatePackFlag
+
+	for i, arg := range args {
+		if arg == "--" {
+			break
+		}
+
+		if value, ok := strings.CutPrefix(arg, prefix+"="); ok {
+			return value
+		}
+
+		if arg == prefix && i+1 < len(args) {
+			return args[i+1]
+		}
+	}
+
+	return ""
+}
+
+// setPackOptions collects the options of the template pack set on the command line,
+// when they are flags of the command.
+func setPackOptions(command *flags.Command, values map[string]string) {
+	for _, group := range command.Groups() {
+		if group.Namespace != templatePackNamespace {
+			continue
+		}
+
+		for _, option := range group.Options() {
+			if option.IsSet() && !option.IsSetDefault() {
+				values[option.LongName] = fmt.Sprint(option.Value())
+			}
+		}
+	}
+}
@@ -0,0 +1,133 @@
+// SPDX-FileCopyrightText: Copyright 2015-2025 go-swagger maintainers
+// SPDX-License-Identifier: Apache-2.0
+
+package generate
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	flags "github.com/jessevdk/go-flags"
+
+	"github.com/go-openapi/testify/v2/assert"
+	"github.com/go-openapi/testify/v2/require"
+)
+
+func TestTemplatePackOptions(t *testing.T) {
+	newParser := func(t *testing.T, probe *parsedProbe) *flags.Parser {
+		t.Helper()
+
+		parser := flags.NewNamedParser("swagger", flags.None)
+		generate, err := parser.AddCommand("generate", "", "", &struct{}{})
+		require.NoError(t, err)
+		_, err = generate.AddCommand("probe", "", "", probe)
+		require.NoError(t, err)
+		_, err = parser.AddCommand("other", "", "", &struct{}{})
+		require.NoError(t, err)
+		ConfigureParser(parser)
+
+		return parser
+	}
+
+	t.Run("pack options are collected for generate commands", func(t *testing.T) {
+		probe := new(parsedProbe)
+		_, err := newParser(t, probe).ParseArgs([]string{"generate", "probe", "--pack.with-mocks", "--pack.prefix=Todo", "--flag=y"})
+		require.NoError(t, err)
+
+		assert.Equal(t, map[string]string{"with-mocks": "true", "prefix": "Todo"}, probe.packOptions)
+		assert.EqualT(t, "y", probe.Flag)
+	})
+
+	t.Run("other unknown flags are rejected", func(t *testing.T) {
+		for _, args := range [][]string{
+			{"generate", "probe", "--unknown"},
+			{"generate", "probe", "--pack."},
+			{"generate", "probe", "-z"},
+			{"other", "--pack.with-mocks"},
+		} {
+			_, err := newParser(t, new(parsedProbe)).ParseArgs(args)
+			require.Error(t, err)
+
+			var flagsErr *flags.Error
+			require.ErrorAs(t, err, &flagsErr)
+			assert.EqualT(t, flags.ErrUnknownFlag, flagsErr.Type)
+		}
+	})
+}
+
+type packProbe struct {
+	parsedProbe
+
+	TemplatePack string `long:"template-pack"`
+}
+
+func TestAddTemplatePackOptions(t *testing.T) {
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "pack.yml"), []byte(`name: mypack
+version: 1.0.0
+options:
+  - name: with-mocks
+    description: generate mocks
+    type: bool
+  - name: title
+    description: the title of the API
+    default: API
+  - name: prefix
+`), 0o600))
+
+	newParser := func(t *testing.T, probe *packProbe, args []string) *flags.Parser {
+		t.Helper()
+
+		parser := flags.NewNamedParser("swagger", flags.HelpFlag)
+		generate, err := parser.AddCommand("generate", "", "", &struct{}{})
+		require.NoError(t, err)
+		_, err = generate.AddCommand("probe", "", "", probe)
+		require.NoError(t, err)
+		_, err = generate.AddCommand("spec", "", "", &struct{}{})
+		require.NoError(t, err)
+		require.NoError(t, AddTemplatePackOptions(t.Context(), parser, args))
+		ConfigureParser(parser)
+
+		return parser
+	}
+
+	t.Run("should show the options of the pack in --help", func(t *testing.T) {
+		args := []string{"generate", "probe", "--template-pack", dir, "--help"}
+		parser := newParser(t, new(packProbe), args)
+		assert.Nil(t, parser.Find("generate").Find("spec").FindOptionByLongName("pack.title"))
+
+		_, err := parser.ParseArgs(args)
+		var flagsErr *flags.Error
+		require.ErrorAs(t, err, &flagsErr)
+		require.EqualT(t, flags.ErrHelp, flagsErr.Type)
+
+		assert.StringContainsT(t, flagsErr.Message, "Options of template pack mypack 1.0.0")
+		assert.StringContainsT(t, flagsErr.Message, "--pack.with-mocks")
+		assert.StringContainsT(t, flagsErr.Message, "the title of the API (default: API)")
+		assert.StringContainsT(t, flagsErr.Message, "--pack.prefix=")
+	})
+
+	t.Run("should collect the options of the pack set on the command line", func(t *testing.T) {
+		args := []string{"generate", "probe", "--template-pack=" + dir, "--pack.with-mocks", "--pack.prefix", "Todo"}
+		probe := new(packProbe)
+		_, err := newParser(t, probe, args).ParseArgs(args)
+		require.NoError(t, err)
+
+		// defaults are resolved with the manifest of the pack
+		assert.Equal(t, map[string]string{"with-mocks": "true", "prefix": "Todo"}, probe.packOptions)
+	})
+
+	t.Run("should ignore a pack which cannot be loaded", func(t *testing.T) {
+		args := []string{"generate", "probe", "--template-pack", t.TempDir()}
+		parser := newParser(t, new(packProbe), args)
+		assert.Nil(t, parser.Find("generate").Find("probe").FindOptionByLongName("pack.title"))
+	})
+}
+
+func TestTemplatePackArg(t *testing.T) {
+	assert.EqualT(t, "./pack", templatePackArg([]string{"generate", "server", "--template-pack", "./pack"}))
+	assert.EqualT(t, "./pack", templatePackArg([]string{"generate", "server", "--template-pack=./pack", "-f", "spec.yml"}))
+	assert.Empty(t, templatePackArg([]string{"generate", "server", "--template-pack"}))
+	assert.Empty(t, templatePackArg([]string{"generate", "server", "--", "--template-pack", "./pack"}))
+}
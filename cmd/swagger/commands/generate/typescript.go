@@ -0,0 +1,40 @@
+// SPDX-FileCopyrightText: Copyright 2015-2025 go-swagger maintainers
+// SPDX-License-Identifier: Apache-2.0
+
+package generate
+
+import (
+	"github.com/go-swagger/go-swagger/generator"
+)
+
+// TypeScript generates TypeScript models and a fetch-based client from the spec.
+type TypeScript struct {
+	WithShared
+	WithModels
+	WithOperations
+
+	Name string `description:"the name of the application, defaults to a mangled value of info.title" long:"name" short:"A"`
+}
+
+// Execute runs this command.
+func (t *TypeScript) Execute(_ []string) error {
+	return createSwagger(t)
+}
+
+// apply options.
+func (t TypeScript) apply(opts *generator.GenOpts) {
+	t.Shared.apply(opts)
+	t.Models.apply(opts)
+	t.Operations.apply(opts)
+
+	// names are mangled by the functions of templates with the rules of TypeScript
+	opts.LanguageOpts = generator.TypeScriptOpts()
+	opts.Name = t.Name
+}
+
+func (t *TypeScript) generate(opts *generator.GenOpts) error {
+	return generator.GenerateTypeScript(t.Name, t.Models.Models, t.Operations.Operations, opts)
+}
+
+func (t TypeScript) log(_ string) {
+}
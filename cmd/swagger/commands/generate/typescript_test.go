@@ -0,0 +1,36 @@
+// SPDX-FileCopyrightText: Copyright 2015-2025 go-swagger maintainers
+// SPDX-License-Identifier: Apache-2.0
+
+package generate_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	flags "github.com/jessevdk/go-flags"
+
+	"github.com/go-openapi/testify/v2/assert"
+	"github.com/go-openapi/testify/v2/require"
+
+	"github.com/go-swagger/go-swagger/cmd/swagger/commands/generate"
+)
+
+func TestTypeScript(t *testing.T) {
+	generated := t.TempDir()
+
+	m := &generate.TypeScript{}
+	_, _ = flags.ParseArgs(m, []string{"--skip-validation"})
+	m.Shared.Spec = flags.Filename(filepath.Join(testBase(), "fixtures", "codegen", "typescript.yml"))
+	m.Shared.Target = flags.Filename(generated)
+	m.Name = "store"
+	require.NoError(t, m.Execute([]string{}))
+
+	for _, name := range []string{"models.ts", "runtime.ts", "index.ts", filepath.Join("operations", "pets.ts")} {
+		assert.FileExists(t, filepath.Join(generated, name))
+	}
+
+	index, err := os.ReadFile(filepath.Join(generated, "index.ts"))
+	require.NoError(t, err)
+	assert.StringContainsT(t, string(index), "export class StoreClient {")
+}
@@ -0,0 +1,220 @@
+// SPDX-FileCopyrightText: Copyright 2015-2025 go-swagger maintainers
+// SPDX-License-Identifier: Apache-2.0
+
+package generate
+
+import (
+	"context"
+	"errors"
+	"io/fs"
+	"log"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"time"
+
+	"github.com/fsnotify/fsnotify"
+
+	"github.com/go-swagger/go-swagger/generator"
+)
+
+// watchDebounce is the delay to wait for more changes, before generating again.
+//
+// Editors often save a file in several steps, and several files may be saved at once.
+const watchDebounce = 200 * time.Millisecond
+
+// watchSwagger generates again whenever an input of the generation changes, until interrupted.
+//
+// Errors are reported, and the generation runs again at the next change.
+func watchSwagger(s sharedCommand) error {
+	watcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		return err
+	}
+	defer func() {
+		_ = watcher.Close()
+	}()
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+
+	// options from the config file are applied to the flags of the command: they are restored before
+	// every generation, so changes to the config file are applied again
+	command := reflect.ValueOf(s).Elem()
+	flags := reflect.New(command.Type()).Elem()
+	flags.Set(command)
+
+	return watchInputs(ctx, watcher, func(inputs *watchedInputs) error {
+		command.Set(flags)
+
+		return generateSwagger(s, inputs)
+	})
+}
+
+// watchInputs runs a generation, then waits for a change in the files it reads, and starts over.
+func watchInputs(ctx context.Context, watcher *fsnotify.Watcher, generate func(*watchedInputs) error) error {
+	for {
+		inputs := newWatchedInputs()
+		if err := generate(inputs); err != nil {
+			log.Printf("generation failed: %v", err)
+		}
+
+		inputs.watch(watcher)
+		log.Printf("watching %d files and %d template directories for changes", len(inputs.files), len(inputs.trees))
+
+		changed, err := inputs.waitForChange(ctx, watcher)
+		if err != nil || changed == "" {
+			return err
+		}
+
+		log.Printf("%s changed: generating again", changed)
+	}
+}
+
+// watchedInputs are the files read by a generation.
+type watchedInputs struct {
+	files map[string]bool // files, like the spec and the config file
+	trees map[string]bool // directories, watched with all their sub-directories, like the template directory
+}
+
+func newWatchedInputs() *watchedInputs {
+	return &watchedInputs{
+		files: make(map[string]bool),
+		trees: make(map[string]bool),
+	}
+}
+
+func (in *watchedInputs) addFile(name string) {
+	if in == nil || name == "" {
+		return
+	}
+
+	if abs, err := filepath.Abs(name); err == nil {
+		in.files[abs] = true
+	}
+}
+
+// addSpec adds a spec, with all the files it references.
+func (in *watchedInputs) addSpec(spec string) {
+	if in == nil {
+		return
+	}
+
+	files, err := generator.SpecFiles(spec)
+	if err != nil {
+		// the spec may be created later
+		in.addFile(spec)
+
+		return
+	}
+
+	for _, file := range files {
+		in.addFile(file)
+	}
+}
+
+func (in *watchedInputs) addTree(dir string) {
+	if in == nil || dir == "" {
+		return
+	}
+
+	if abs, err := filepath.Abs(dir); err == nil {
+		in.trees[abs] = true
+	}
+}
+
+// dirs lists the directories to watch: changes to files are notified for their directory,
+// since editors often replace files rather than write them.
+func (in *watchedInputs) dirs() map[string]bool {
+	dirs := make(map[string]bool, len(in.files)+len(in.trees))
+	for file := range in.files {
+		dirs[filepath.Dir(file)] = true
+	}
+
+	for tree := range in.trees {
+		_ = filepath.WalkDir(tree, func(pth string, d fs.DirEntry, err error) error {
+			if err == nil && d.IsDir() {
+				dirs[pth] = true
+			}
+
+			return nil
+		})
+	}
+
+	return dirs
+}
+
+// watch updates the directories watched for changes.
+func (in *watchedInputs) watch(watcher *fsnotify.Watcher) {
+	dirs := in.dirs()
+
+	for _, dir := range watcher.WatchList() {
+		if !dirs[dir] {
+			_ = watcher.Remove(dir)
+		}
+	}
+
+	for dir := range dirs {
+		if err := watcher.Add(dir); err != nil {
+			log.Printf("cannot watch %s: %v", dir, err)
+		}
+	}
+}
+
+func (in *watchedInputs) matches(name string) bool {
+	if in.files[name] {
+		return true
+	}
+
+	for tree := range in.trees {
+		if name == tree || strings.HasPrefix(name, tree+string(filepath.Separator)) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// waitForChange waits for a change in the inputs, and returns the first changed file.
+//
+// It returns an empty name when the context is done.
+func (in *watchedInputs) waitForChange(ctx context.Context, watcher *fsnotify.Watcher) (string, error) {
+	var (
+		changed string
+		settled <-chan time.Time
+	)
+
+	for {
+		select {
+		case <-ctx.Done():
+			return "", nil
+		case e, ok := <-watcher.Events:
+			if !ok {
+				return "", errors.New("file watcher closed")
+			}
+
+			if !e.Has(fsnotify.Write) && !e.Has(fsnotify.Create) && !e.Has(fsnotify.Remove) && !e.Has(fsnotify.Rename) {
+				continue
+			}
+
+			if name, err := filepath.Abs(e.Name); err != nil || !in.matches(name) {
+				continue
+			}
+
+			if changed == "" {
+				changed = e.Name
+			}
+			settled = time.After(watchDebounce)
+		case err, ok := <-watcher.Errors:
+			if !ok {
+				return "", errors.New("file watcher closed")
+			}
+
+			log.Printf("watching files: %v", err)
+		case <-settled:
+			return changed, nil
+		}
+	}
+}
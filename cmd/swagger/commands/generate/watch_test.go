@@ -0,0 +1,82 @@
+// SPDX-FileCopyrightText: Copyright 2015-2025 go-swagger maintainers
+// SPDX-License-Identifier: Apache-2.0
+
+package generate
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/fsnotify/fsnotify"
+
+	"github.com/go-openapi/testify/v2/assert"
+	"github.com/go-openapi/testify/v2/require"
+)
+
+func TestWatchInputs(t *testing.T) {
+	dir := t.TempDir()
+	spec := filepath.Join(dir, "swagger.yml")
+	types := filepath.Join(dir, "types.yml")
+	templates := filepath.Join(dir, "templates", "server")
+	require.NoError(t, os.WriteFile(spec, []byte("swagger: \"2.0\"\ndefinitions:\n  Pet:\n    $ref: ./types.yml#/definitions/Pet\n"), 0o600))
+	require.NoError(t, os.WriteFile(types, []byte("definitions:\n  Pet:\n    type: object\n"), 0o600))
+	require.NoError(t, os.MkdirAll(templates, 0o700))
+
+	watcher, err := fsnotify.NewWatcher()
+	require.NoError(t, err)
+	defer func() {
+		_ = watcher.Close()
+	}()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Second)
+	defer cancel()
+
+	// every change triggers a generation: the first generation fails, without stopping the watch
+	changes := []func(){
+		func() {
+			require.NoError(t, os.WriteFile(types, []byte("definitions:\n  Pet:\n    type: string\n"), 0o600))
+		},
+		func() {
+			require.NoError(t, os.WriteFile(filepath.Join(templates, "operation.gotmpl"), []byte("{{ .Name }}"), 0o600))
+		},
+		func() {
+			require.NoError(t, os.WriteFile(filepath.Join(dir, "unrelated.txt"), []byte("ignored"), 0o600))
+		},
+	}
+
+	var generations int
+	err = watchInputs(ctx, watcher, func(inputs *watchedInputs) error {
+		generations++
+		inputs.addSpec(spec)
+		inputs.addTree(filepath.Join(dir, "templates"))
+
+		if generations > 2 {
+			// the last change is not an input: the watch ends with the context
+			go func() {
+				changes[2]()
+				time.Sleep(2 * watchDebounce)
+				cancel()
+			}()
+
+			return nil
+		}
+
+		// make a change once the watcher is set
+		go func(change func()) {
+			time.Sleep(watchDebounce)
+			change()
+		}(changes[generations-1])
+
+		if generations == 1 {
+			return errors.New("invalid spec")
+		}
+
+		return nil
+	})
+	require.NoError(t, err)
+	assert.EqualT(t, 3, generations)
+}
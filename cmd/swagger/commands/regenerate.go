@@ -0,0 +1,63 @@
+// SPDX-FileCopyrightText: Copyright 2015-2025 go-swagger maintainers
+// SPDX-License-Identifier: Apache-2.0
+
+package commands
+
+import (
+	"cmp"
+	"log"
+	"strings"
+
+	flags "github.com/jessevdk/go-flags"
+
+	"github.com/go-swagger/go-swagger/cmd/swagger/commands/generate"
+	"github.com/go-swagger/go-swagger/generator"
+)
+
+// Regenerate is a command that replays the generation recorded in the lockfile of a generated directory.
+type Regenerate struct {
+	DryRun bool `description:"print the recorded command instead of generating" long:"dry-run"`
+	Args   struct {
+		Dir string `positional-arg-name:"{dir}"`
+	} `description:"the generated directory (defaults to the current directory)" positional-args:"dir"`
+}
+
+// Execute replays the generation recorded in the directory given as argument, or in the current directory.
+func (c *Regenerate) Execute(_ []string) error {
+	dir := cmp.Or(c.Args.Dir, ".")
+
+	lock, err := generator.ReadLockfile(dir)
+	if err != nil {
+		return err
+	}
+
+	if err := lock.CheckGenerator(); err != nil {
+		log.Printf("warning: %v: the generated code may differ", err)
+	}
+
+	if lock.SpecChanged(dir) {
+		log.Printf("the spec %s has changed since the last generation", lock.Spec.Path)
+	}
+
+	replayed, err := generate.ReplayArgs(lock, dir)
+	if err != nil {
+		return err
+	}
+	replayed = append([]string{"generate"}, replayed...)
+
+	log.Printf("regenerating %s: swagger %s", dir, strings.Join(replayed, " "))
+	if c.DryRun {
+		return nil
+	}
+
+	// the recorded command is run like on the command line, with the options of the template pack
+	parser := flags.NewNamedParser("swagger", flags.HelpFlag|flags.PassDoubleDash)
+	if _, err := parser.AddCommand("generate", "generate go code", "generate go code for the swagger spec file", &Generate{}); err != nil {
+		return err
+	}
+	generate.ConfigureParser(parser)
+
+	_, err = parser.ParseArgs(replayed)
+
+	return err
+}
@@ -0,0 +1,34 @@
+// SPDX-FileCopyrightText: Copyright 2015-2025 go-swagger maintainers
+// SPDX-License-Identifier: Apache-2.0
+
+package commands
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/go-openapi/testify/v2/assert"
+	"github.com/go-openapi/testify/v2/require"
+)
+
+func TestCmd_Regenerate(t *testing.T) {
+	t.Run("should require a recorded generation", func(t *testing.T) {
+		var c Regenerate
+		c.Args.Dir = t.TempDir()
+		err := c.Execute(nil)
+		require.Error(t, err)
+		assert.StringContainsT(t, err.Error(), "no generation is recorded")
+	})
+
+	t.Run("should reject an unknown command", func(t *testing.T) {
+		target := t.TempDir()
+		require.NoError(t, os.WriteFile(filepath.Join(target, ".swagger-lock.json"), []byte(`{"command":"spec"}`), 0o600))
+
+		c := Regenerate{DryRun: true}
+		c.Args.Dir = target
+		err := c.Execute(nil)
+		require.Error(t, err)
+		assert.StringContainsT(t, err.Error(), `unknown generate command "spec"`)
+	})
+}
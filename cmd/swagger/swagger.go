@@ -4,6 +4,7 @@
 package main
 
 import (
+	"context"
 	"io"
 	"log"
 	"os"
@@ -11,6 +12,7 @@ import (
 	flags "github.com/jessevdk/go-flags"
 
 	"github.com/go-swagger/go-swagger/cmd/swagger/commands"
+	"github.com/go-swagger/go-swagger/cmd/swagger/commands/generate"
 )
 
 var opts struct {
@@ -58,6 +60,17 @@ It aims to represent the contract of your API with a language agnostic descripti
 		log.Fatal(err)
 	}
 
+	exppar, err := parser.AddCommand("export", "export a swagger spec to other formats", "export the definitions of a swagger spec to other formats", &commands.ExportCmd{})
+	if err != nil {
+		log.Fatal(err)
+	}
+	for _, cmd := range exppar.Commands() {
+		if cmd.Name == "jsonschema" {
+			cmd.ShortDescription = "export the definitions as JSON schema (draft 2020-12)"
+			cmd.LongDescription = cmd.ShortDescription
+		}
+	}
+
 	_, err = parser.AddCommand("mixin", "merge swagger documents", "merge additional specs into first/primary spec by copying their paths and definitions", &commands.MixinSpec{})
 	if err != nil {
 		log.Fatal(err)
@@ -68,10 +81,20 @@ It aims to represent the contract of your API with a language agnostic descripti
 		log.Fatal(err)
 	}
 
+	_, err = parser.AddCommand("regenerate", "replay a recorded generation", "generate again a directory, exactly like recorded in its lockfile", &commands.Regenerate{})
+	if err != nil {
+		log.Fatal(err)
+	}
+
 	genpar, err := parser.AddCommand("generate", "generate go code", "generate go code for the swagger spec file", &commands.Generate{})
 	if err != nil {
 		log.Fatalln(err)
 	}
+	if err = generate.AddTemplatePackOptions(context.Background(), parser, os.Args[1:]); err != nil {
+		log.Fatalln(err)
+	}
+	generate.ConfigureParser(parser)
+
 	for _, cmd := range genpar.Commands() {
 		switch cmd.Name {
 		case "spec":
@@ -95,9 +118,18 @@ It aims to represent the contract of your API with a language agnostic descripti
 		case "markdown":
 			cmd.ShortDescription = "generate a markdown representation from the swagger spec"
 			cmd.LongDescription = cmd.ShortDescription
+		case "typescript":
+			cmd.ShortDescription = "generate typescript models and a fetch-based client from the swagger spec"
+			cmd.LongDescription = cmd.ShortDescription
+		case "proto":
+			cmd.ShortDescription = "generate protocol buffers definitions from the swagger spec"
+			cmd.LongDescription = cmd.ShortDescription
 		case "cli":
 			cmd.ShortDescription = "generate a command line client tool from the swagger spec"
 			cmd.LongDescription = cmd.ShortDescription
+		case "check-templates":
+			cmd.ShortDescription = "check custom templates against the data model of the generator"
+			cmd.LongDescription = cmd.ShortDescription
 		}
 	}
 
@@ -0,0 +1,100 @@
+// SPDX-FileCopyrightText: Copyright 2015-2025 go-swagger maintainers
+// SPDX-License-Identifier: Apache-2.0
+
+// Command funcplugin is a function plugin for templates, speaking JSON-RPC 2.0 on its standard input and output.
+//
+// It provides the functions "slug", "words" and "fail".
+package main
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+)
+
+type request struct {
+	ID     int    `json:"id"`
+	Method string `json:"method"`
+	Params struct {
+		Name string `json:"name"`
+		Args []any  `json:"args"`
+	} `json:"params"`
+}
+
+type response struct {
+	JSONRPC string    `json:"jsonrpc"`
+	ID      int       `json:"id"`
+	Result  any       `json:"result,omitempty"`
+	Error   *rpcError `json:"error,omitempty"`
+}
+
+type rpcError struct {
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+}
+
+var functions = map[string]func(args []any) (any, error){
+	"slug": func(args []any) (any, error) {
+		if len(args) != 1 {
+			return nil, errors.New("slug expects 1 argument")
+		}
+
+		return strings.Join(strings.Fields(strings.ToLower(fmt.Sprint(args[0]))), "-"), nil
+	},
+	"words": func(args []any) (any, error) {
+		words := make([]string, 0, len(args))
+		for _, arg := range args {
+			words = append(words, strings.Fields(fmt.Sprint(arg))...)
+		}
+
+		return words, nil
+	},
+	"fail": func([]any) (any, error) {
+		return nil, errors.New("failed on purpose")
+	},
+}
+
+func main() {
+	scanner := bufio.NewScanner(os.Stdin)
+	scanner.Buffer(nil, 64<<20)
+	enc := json.NewEncoder(os.Stdout)
+
+	for scanner.Scan() {
+		var req request
+		if err := json.Unmarshal(scanner.Bytes(), &req); err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(1)
+		}
+
+		resp := response{JSONRPC: "2.0", ID: req.ID}
+		switch req.Method {
+		case "functions":
+			resp.Result = map[string]any{"functions": []string{"fail", "slug", "words"}, "version": "1.0.0"}
+		case "call":
+			fn, ok := functions[req.Params.Name]
+			if !ok {
+				resp.Error = &rpcError{Code: -32601, Message: "unknown function " + req.Params.Name}
+
+				break
+			}
+
+			result, err := fn(req.Params.Args)
+			if err != nil {
+				resp.Error = &rpcError{Code: -32000, Message: err.Error()}
+
+				break
+			}
+			resp.Result = result
+		default:
+			resp.Error = &rpcError{Code: -32601, Message: "unknown method " + req.Method}
+		}
+
+		if err := enc.Encode(resp); err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(1)
+		}
+	}
+}
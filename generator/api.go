@@ -0,0 +1,199 @@
+// SPDX-FileCopyrightText: Copyright 2015-2025 go-swagger maintainers
+// SPDX-License-Identifier: Apache-2.0
+
+package generator
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"log/slog"
+)
+
+// Generator generates code from a swagger specification.
+//
+// It is the programmatic counterpart of the "swagger generate" commands: generated files
+// may be written to any [WriteFS], progress is reported to a structured logger,
+// and a generation may be interrupted by cancelling its context.
+//
+// A Generator must not be used to run several generations concurrently.
+type Generator struct {
+	opts *GenOpts
+}
+
+// Option alters the generation options of a [Generator].
+type Option func(*GenOpts)
+
+// WithFS writes generated files to some file system, e.g. [NewMemFS] or [NewZipFS].
+//
+// By default, files are written to the local file system.
+func WithFS(fsys WriteFS) Option {
+	return func(g *GenOpts) {
+		g.FS = fsys
+	}
+}
+
+// WithLogger reports the progress of the generation to a structured logger.
+//
+// By default, progress is reported to the standard logger.
+func WithLogger(logger *slog.Logger) Option {
+	return func(g *GenOpts) {
+		g.Logger = logger
+	}
+}
+
+// WithEvents reports the progress of the generation as structured events.
+func WithEvents(handler EventHandler) Option {
+	return func(g *GenOpts) {
+		g.Events = handler
+	}
+}
+
+// WithConcurrency sets the maximum number of files rendered in parallel.
+func WithConcurrency(workers int) Option {
+	return func(g *GenOpts) {
+		g.Concurrency = workers
+	}
+}
+
+// NewGenerator builds a [Generator] with some generation options.
+//
+// When opts is nil, the default options are used.
+func NewGenerator(opts *GenOpts, options ...Option) *Generator {
+	if opts == nil {
+		opts = new(GenOpts)
+	}
+
+	for _, apply := range options {
+		apply(opts)
+	}
+
+	return &Generator{opts: opts}
+}
+
+// Options returns the generation options used by this [Generator].
+func (g *Generator) Options() *GenOpts {
+	return g.opts
+}
+
+// Server generates a server application, like [GenerateServer].
+func (g *Generator) Server(ctx context.Context, name string, modelNames, operationIDs []string) error {
+	return g.run(ctx, func(opts *GenOpts) error {
+		return GenerateServer(name, modelNames, operationIDs, opts)
+	})
+}
+
+// Support generates the supporting files of a server application, like [GenerateSupport].
+func (g *Generator) Support(ctx context.Context, name string, modelNames, operationIDs []string) error {
+	return g.run(ctx, func(opts *GenOpts) error {
+		return GenerateSupport(name, modelNames, operationIDs, opts)
+	})
+}
+
+// Client generates a client library, like [GenerateClient].
+func (g *Generator) Client(ctx context.Context, name string, modelNames, operationIDs []string) error {
+	return g.run(ctx, func(opts *GenOpts) error {
+		return GenerateClient(name, modelNames, operationIDs, opts)
+	})
+}
+
+// Models generates models, like [GenerateModels].
+func (g *Generator) Models(ctx context.Context, modelNames []string) error {
+	return g.run(ctx, func(opts *GenOpts) error {
+		return GenerateModels(modelNames, opts)
+	})
+}
+
+// Markdown generates a markdown documentation of the API, like [GenerateMarkdown].
+func (g *Generator) Markdown(ctx context.Context, output string, modelNames, operationIDs []string) error {
+	return g.run(ctx, func(opts *GenOpts) error {
+		return GenerateMarkdown(output, modelNames, operationIDs, opts)
+	})
+}
+
+// TypeScript generates TypeScript models and a fetch-based client, like [GenerateTypeScript].
+func (g *Generator) TypeScript(ctx context.Context, name string, modelNames, operationIDs []string) error {
+	return g.run(ctx, func(opts *GenOpts) error {
+		return GenerateTypeScript(name, modelNames, operationIDs, opts)
+	})
+}
+
+// Proto generates protocol buffers definitions for models, like [GenerateProto].
+func (g *Generator) Proto(ctx context.Context, name string, modelNames []string) error {
+	return g.run(ctx, func(opts *GenOpts) error {
+		return GenerateProto(name, modelNames, opts)
+	})
+}
+
+func (g *Generator) run(ctx context.Context, generate func(*GenOpts) error) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	if err := g.opts.EnsureDefaults(); err != nil {
+		return err
+	}
+
+	g.opts.ctx = ctx
+	defer func() {
+		g.opts.ctx = nil
+	}()
+
+	if err := RunWithHooks(g.opts, generate); err != nil {
+		return err
+	}
+
+	// a cancellation may have interrupted the generation without any other error
+	return ctx.Err()
+}
+
+// context returns the context of the current generation.
+func (g *GenOptsCommon) context() context.Context {
+	if g.ctx == nil {
+		return context.Background()
+	}
+
+	return g.ctx
+}
+
+// fs returns the file system where generated files are written.
+func (g *GenOpts) fs() WriteFS {
+	if g.FS == nil {
+		return osFS{}
+	}
+
+	return g.FS
+}
+
+// logf reports the progress of the generation.
+func (g *GenOpts) logf(format string, args ...any) {
+	if g == nil || g.Logger == nil {
+		log.Printf(format, args...)
+
+		return
+	}
+
+	g.Logger.InfoContext(g.context(), fmt.Sprintf(format, args...))
+}
+
+// debugf reports details about the generation, at debug level.
+func (g *GenOpts) debugf(format string, args ...any) {
+	if g == nil || g.Logger == nil {
+		debugLogf(format, args...)
+
+		return
+	}
+
+	g.Logger.DebugContext(g.context(), fmt.Sprintf(format, args...))
+}
+
+// warnf reports a warning about the generation.
+func (g *GenOpts) warnf(format string, args ...any) {
+	if g == nil || g.Logger == nil {
+		log.Printf("warning: "+format, args...)
+
+		return
+	}
+
+	g.Logger.WarnContext(g.context(), fmt.Sprintf(format, args...))
+}
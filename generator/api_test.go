@@ -0,0 +1,136 @@
+// SPDX-FileCopyrightText: Copyright 2015-2025 go-swagger maintainers
+// SPDX-License-Identifier: Apache-2.0
+
+package generator
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/go-openapi/testify/v2/assert"
+	"github.com/go-openapi/testify/v2/require"
+)
+
+func TestGenerator_Models(t *testing.T) {
+	defer discardOutput()()
+
+	// the target must still be located in a go module, to resolve imports
+	target := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(target, "go.mod"), []byte("module inmemory\n"), readableFile))
+
+	newOpts := func() *GenOpts {
+		opts := testGenOpts()
+		opts.Spec = "../fixtures/bugs/1042/fixture-1042.yaml"
+		opts.Target = target
+
+		return opts
+	}
+
+	t.Run("should generate models in memory", func(t *testing.T) {
+		var logs bytes.Buffer
+		fsys := NewMemFS()
+		gen := NewGenerator(newOpts(),
+			WithFS(fsys),
+			WithLogger(slog.New(slog.NewJSONHandler(&logs, nil))),
+		)
+
+		require.NoError(t, gen.Models(t.Context(), nil))
+
+		modelFile := filepath.Join(target, defaultModelsTarget, "a.go")
+		assert.Contains(t, fsys.Files(), modelFile)
+		assert.Contains(t, fsys.Files(), filepath.Join(target, cacheDir, cacheFile))
+		_, err := os.Stat(modelFile)
+		require.ErrorIs(t, err, os.ErrNotExist)
+
+		content, err := fsys.ReadFile(modelFile)
+		require.NoError(t, err)
+		assert.StringContainsT(t, string(content), "type A struct")
+
+		assert.StringContainsT(t, logs.String(), `"level":"INFO"`)
+		assert.StringContainsT(t, logs.String(), `"msg":"creating generated file`)
+		assert.StringNotContainsT(t, logs.String(), "rendering 1 templates for model A", "the rendering of every model is reported at debug level")
+
+		t.Run("should regenerate a model altered in memory", func(t *testing.T) {
+			require.NoError(t, fsys.WriteFile(modelFile, []byte("package models\n"), readableFile))
+			require.NoError(t, gen.Models(t.Context(), nil))
+
+			content, err := fsys.ReadFile(modelFile)
+			require.NoError(t, err)
+			assert.StringContainsT(t, string(content), "type A struct")
+		})
+	})
+
+	t.Run("should report the loading of contributed templates to the logger", func(t *testing.T) {
+		var logs bytes.Buffer
+		opts := newOpts()
+		opts.Template = "stratoscale"
+		gen := NewGenerator(opts, WithFS(NewMemFS()), WithLogger(slog.New(slog.NewJSONHandler(&logs, nil))))
+
+		require.NoError(t, gen.Models(t.Context(), nil))
+		assert.StringContainsT(t, logs.String(), `"msg":"loading contrib stratoscale"`)
+	})
+
+	t.Run("should generate models in a zip archive", func(t *testing.T) {
+		var buf bytes.Buffer
+		archive := zip.NewWriter(&buf)
+		gen := NewGenerator(newOpts(), WithFS(NewZipFS(archive, target)))
+
+		require.NoError(t, gen.Models(t.Context(), nil))
+		require.NoError(t, archive.Close())
+
+		reader, err := zip.NewReader(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+		require.NoError(t, err)
+
+		names := make([]string, 0, len(reader.File))
+		for _, f := range reader.File {
+			names = append(names, f.Name)
+		}
+		assert.Contains(t, names, "models/a.go")
+		assert.NotContains(t, names, cacheDir+"/"+cacheFile, "a zip archive cannot be read back: there is no generation cache")
+	})
+
+	t.Run("should stop when cancelled", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(t.Context())
+		cancel()
+
+		fsys := NewMemFS()
+		gen := NewGenerator(newOpts(), WithFS(fsys))
+
+		require.ErrorIs(t, gen.Models(ctx, nil), context.Canceled)
+		assert.Empty(t, fsys.Files())
+	})
+}
+
+func TestMemFS(t *testing.T) {
+	fsys := NewMemFS()
+	dir := filepath.Join("a", "b")
+	file := filepath.Join(dir, "c.go")
+
+	require.NoError(t, fsys.MkdirAll(dir, readAllDir))
+	require.NoError(t, fsys.WriteFile(file, []byte("package b\n"), readAllFile))
+
+	info, err := fsys.Stat("a")
+	require.NoError(t, err)
+	assert.TrueT(t, info.IsDir())
+
+	info, err = fsys.Stat(file)
+	require.NoError(t, err)
+	assert.FalseT(t, info.IsDir())
+	assert.EqualT(t, int64(10), info.Size())
+
+	_, err = fsys.Stat(filepath.Join(dir, "missing.go"))
+	require.ErrorIs(t, err, os.ErrNotExist)
+
+	t.Run("should not write over a directory", func(t *testing.T) {
+		require.Error(t, fsys.WriteFile(dir, []byte("x"), readAllFile))
+	})
+
+	t.Run("should not create a directory over a file", func(t *testing.T) {
+		require.Error(t, fsys.MkdirAll(filepath.Join(file, "d"), readAllDir))
+	})
+}
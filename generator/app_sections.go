@@ -0,0 +1,174 @@
+// SPDX-FileCopyrightText: Copyright 2015-2025 go-swagger maintainers
+// SPDX-License-Identifier: Apache-2.0
+
+package generator
+
+import (
+	"fmt"
+	"slices"
+	"sort"
+
+	"github.com/go-openapi/spec"
+)
+
+// makeTagDefinitions lists the tags declared by the spec, with the operations which carry them.
+//
+// Tags carried by operations but not declared by the spec follow the declared tags, in alphabetical order.
+func (a *appGenerator) makeTagDefinitions(operations GenOperations, baseImport string) GenTags {
+	declared := a.SpecDoc.Spec().Tags
+	byTag := make(map[string]GenOperations)
+	var undeclared []string
+
+	for _, op := range operations {
+		for _, tag := range op.Tags {
+			if _, seen := byTag[tag]; !seen && !slices.ContainsFunc(declared, func(t spec.Tag) bool { return t.Name == tag }) {
+				undeclared = append(undeclared, tag)
+			}
+			byTag[tag] = append(byTag[tag], op)
+		}
+	}
+	sort.Strings(undeclared)
+
+	tags := make(GenTags, 0, len(declared)+len(undeclared))
+	newTag := func(name string) GenTag {
+		return GenTag{
+			GenCommon: GenCommon{
+				Copyright:        a.GenOpts.Copyright,
+				TargetImportPath: baseImport,
+			},
+			Name:       name,
+			Operations: byTag[name],
+			GenOpts:    a.GenOpts,
+		}
+	}
+
+	for _, declaredTag := range declared {
+		tag := newTag(declaredTag.Name)
+		tag.Description = trimBOM(declaredTag.Description)
+		tag.ExternalDocs = trimExternalDoc(declaredTag.ExternalDocs)
+		tag.Extensions = declaredTag.Extensions
+		tags = append(tags, tag)
+	}
+
+	for _, name := range undeclared {
+		tags = append(tags, newTag(name))
+	}
+
+	return tags
+}
+
+// namedItemBuilder builds the responses and parameters declared at the top level of the spec.
+//
+// They are resolved in the context of the main operations package.
+func (a *appGenerator) namedItemBuilder(name string, defaultImports, imports map[string]string) *codeGenOpBuilder {
+	return &codeGenOpBuilder{
+		ModelsPackage:   a.ModelsPackage,
+		Principal:       a.GenOpts.PrincipalAlias(),
+		Target:          a.Target,
+		DefaultImports:  defaultImports,
+		Imports:         imports,
+		DefaultScheme:   a.DefaultScheme,
+		Doc:             a.SpecDoc,
+		PristineDefs:    a.SpecDoc.Pristine(),
+		Analyzed:        a.Analyzed,
+		BasePath:        a.SpecDoc.BasePath(),
+		GenOpts:         a.GenOpts,
+		Name:            name,
+		APIPackage:      a.APIPackage,
+		DefaultProduces: a.DefaultProduces,
+		DefaultConsumes: a.DefaultConsumes,
+	}
+}
+
+// makeNamedResponses builds the responses declared in #/responses.
+func (a *appGenerator) makeNamedResponses(defaultImports, imports map[string]string) ([]GenResponse, error) {
+	declared := a.SpecDoc.Spec().Responses
+	responses := make([]GenResponse, 0, len(declared))
+
+	for _, name := range sortedKeys(declared) {
+		bldr := a.namedItemBuilder(name, defaultImports, imports)
+		resolver := newTypeResolver(
+			a.GenOpts.LanguageOpts.ManglePackageName(a.ModelsPackage, defaultModelsTarget),
+			a.SpecDoc,
+			a.GenOpts,
+		)
+
+		response, err := bldr.MakeResponse("o", name, false, resolver, 0, declared[name])
+		if err != nil {
+			return nil, fmt.Errorf("error in response %q: %w", name, err)
+		}
+		responses = append(responses, response)
+	}
+
+	return responses, nil
+}
+
+// makeNamedParameters builds the parameters declared in #/parameters.
+//
+// The ID of a parameter is derived from its key in #/parameters, unless it has an x-go-name extension:
+// several declared parameters may share the same name in different locations.
+func (a *appGenerator) makeNamedParameters(defaultImports, imports map[string]string) (GenParameters, error) {
+	declared := a.SpecDoc.Spec().Parameters
+	parameters := make(GenParameters, 0, len(declared))
+	mangle := a.GenOpts.LanguageOpts.Mangler.ToGoName
+
+	for _, key := range sortedKeys(declared) {
+		param := declared[key]
+		bldr := a.namedItemBuilder(key, defaultImports, imports)
+		resolver := newTypeResolver(
+			a.GenOpts.LanguageOpts.ManglePackageName(a.ModelsPackage, defaultModelsTarget),
+			a.SpecDoc,
+			a.GenOpts,
+		)
+		idMapping := map[string]map[string]string{param.In: {param.Name: mangle(key)}}
+
+		parameter, err := bldr.MakeParameter("o", resolver, param, idMapping)
+		if err != nil {
+			return nil, fmt.Errorf("error in parameter %q: %w", key, err)
+		}
+		parameters = append(parameters, parameter)
+	}
+
+	return parameters, nil
+}
+
+// renderSections renders the templates of the sections which iterate over security schemes, tags,
+// named responses and named parameters.
+func (g *GenOpts) renderSections(app *GenApp) error {
+	g.logf("rendering sections for %d security schemes, %d tags, %d responses and %d parameters",
+		len(app.SecurityDefinitions), len(app.TagDefinitions), len(app.NamedResponses), len(app.NamedParameters))
+
+	for _, templ := range g.Sections.SecuritySchemes {
+		for _, scheme := range app.SecurityDefinitions {
+			if err := g.write(&templ, &scheme); err != nil {
+				return err
+			}
+		}
+	}
+
+	for _, templ := range g.Sections.Tags {
+		for _, tag := range app.TagDefinitions {
+			if err := g.write(&templ, &tag); err != nil {
+				return err
+			}
+		}
+	}
+
+	for _, templ := range g.Sections.Responses {
+		for _, response := range app.NamedResponses {
+			if err := g.write(&templ, &response); err != nil {
+				return err
+			}
+		}
+	}
+
+	for _, templ := range g.Sections.Parameters {
+		for _, parameter := range app.NamedParameters {
+			if err := g.write(&templ, &parameter); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
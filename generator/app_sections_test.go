@@ -0,0 +1,70 @@
+// SPDX-FileCopyrightText: Copyright 2015-2025 go-swagger maintainers
+// SPDX-License-Identifier: Apache-2.0
+
+package generator
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/go-openapi/testify/v2/assert"
+	"github.com/go-openapi/testify/v2/require"
+)
+
+func TestRenderSections(t *testing.T) {
+	defer discardOutput()()
+
+	templates := t.TempDir()
+	for name, content := range map[string]string{
+		"scheme.gotmpl":    `{{ .ID }}: {{ .Type }}`,
+		"tag.gotmpl":       `{{ .Name }}: {{ .Description }}{{ range .Operations }} {{ .Name }}{{ end }}`,
+		"response.gotmpl":  `{{ .Name }}: {{ .Description }} {{ .Schema.GoType }}`,
+		"parameter.gotmpl": `{{ .ID }}: {{ .Name }} in {{ .Location }}`,
+	} {
+		require.NoError(t, os.WriteFile(filepath.Join(templates, name), []byte(content), readableFile))
+	}
+
+	opts := testGenOpts()
+	opts.Spec = filepath.FromSlash("../fixtures/codegen/sections.yml")
+	opts.Target = t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(opts.Target, "go.mod"), []byte("module sections\n"), readableFile))
+	opts.TemplateDir = templates
+	opts.Sections = SectionOpts{
+		SecuritySchemes: []TemplateOpts{{Name: "scheme", Source: "scheme.gotmpl", Target: "{{ .Target }}", FileName: "auth_{{ snakize .Name }}.txt", SkipFormat: true}},
+		Tags:            []TemplateOpts{{Name: "tag", Source: "tag.gotmpl", Target: "{{ joinFilePath .Target \"docs\" }}", FileName: "{{ .Name }}.md", SkipFormat: true}},
+		Responses:       []TemplateOpts{{Name: "response", Source: "response.gotmpl", Target: "{{ .Target }}", FileName: "{{ snakize .Name }}_response.txt", SkipFormat: true}},
+		Parameters:      []TemplateOpts{{Name: "parameter", Source: "parameter.gotmpl", Target: "{{ .Target }}", FileName: "{{ snakize .Name }}_param.txt", SkipFormat: true}},
+	}
+	require.NoError(t, opts.EnsureDefaults())
+
+	appGen, err := newAppGenerator("sections", nil, nil, opts)
+	require.NoError(t, err)
+
+	app, err := appGen.makeCodegenApp()
+	require.NoError(t, err)
+	require.NoError(t, opts.renderSections(&app))
+
+	for file, expected := range map[string]string{
+		"auth_api_key.txt":       "apiKey: apikey",
+		"auth_basic.txt":         "basic: basic",
+		"docs/pets.md":           "pets: Everything about pets listPets",
+		"docs/stores.md":         "stores: ",
+		"docs/catalog.md":        "catalog:  listPets",
+		"not_found_response.txt": "NotFound: the resource is not found models.Error",
+		"limit_query_param.txt":  "LimitQuery: limit in query",
+		"limit_header_param.txt": "LimitHeader: limit in header",
+	} {
+		content, err := os.ReadFile(filepath.Join(opts.Target, filepath.FromSlash(file)))
+		require.NoError(t, err, file)
+		assert.EqualT(t, expected, string(content), file)
+	}
+
+	t.Run("should list declared tags first", func(t *testing.T) {
+		names := make([]string, 0, len(app.TagDefinitions))
+		for _, tag := range app.TagDefinitions {
+			names = append(names, tag.Name)
+		}
+		assert.Equal(t, []string{"pets", "stores", "catalog"}, names)
+	})
+}
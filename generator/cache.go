@@ -0,0 +1,368 @@
+// SPDX-FileCopyrightText: Copyright 2015-2025 go-swagger maintainers
+// SPDX-License-Identifier: Apache-2.0
+
+package generator
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"maps"
+	"os"
+	"path/filepath"
+	"runtime/debug"
+	"slices"
+	"strings"
+	"sync"
+)
+
+const (
+	// cacheDir is the hidden directory in the generation target where the generator keeps its state.
+	cacheDir = ".go-swagger"
+	// cacheFile is the file name of the fingerprints cache used for incremental generation.
+	cacheFile = "cache.json"
+
+	generatorModule = "github.com/go-swagger/go-swagger"
+	develVersion    = "(devel)"
+	unknownVersion  = "unknown"
+)
+
+// generationCache records, for every generated item (model, operation, operation group),
+// a fingerprint of the inputs used to render it and a digest of the files it produced.
+//
+// When a subsequent run computes the same fingerprint and finds the generated files unaltered on disk,
+// rendering and formatting are skipped.
+type generationCache struct {
+	Version string                `json:"version"`
+	Entries map[string]cacheEntry `json:"entries"`
+
+	path string
+	fsys WriteFS
+	mx   sync.Mutex
+}
+
+type cacheEntry struct {
+	Fingerprint string            `json:"fingerprint"`
+	Files       map[string]string `json:"files"`
+}
+
+// loadGenerationCache reads the cache stored in the generation target.
+//
+// A missing or unreadable cache yields an empty cache: the next generation is then complete.
+func loadGenerationCache(fsys WriteFS, target string) *generationCache {
+	c := &generationCache{
+		Version: cacheVersion(),
+		Entries: make(map[string]cacheEntry),
+		path:    filepath.Join(target, cacheDir, cacheFile),
+		fsys:    fsys,
+	}
+
+	buf, err := fsys.ReadFile(c.path)
+	if err != nil {
+		return c
+	}
+
+	var stored generationCache
+	if err := json.Unmarshal(buf, &stored); err != nil {
+		debugLogf("ignoring invalid generation cache at %q: %v", c.path, err)
+
+		return c
+	}
+
+	if stored.Version != c.Version || stored.Entries == nil {
+		return c
+	}
+
+	c.Entries = stored.Entries
+
+	return c
+}
+
+// Save the cache in the generation target.
+func (c *generationCache) Save() error {
+	c.mx.Lock()
+	defer c.mx.Unlock()
+
+	buf, err := json.MarshalIndent(c, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	if err := c.fsys.MkdirAll(filepath.Dir(c.path), readAllDir); err != nil {
+		return fmt.Errorf("could not create generation cache directory: %w", err)
+	}
+
+	return c.fsys.WriteFile(c.path, buf, readAllFile)
+}
+
+// UpToDate tells if the item identified by key has been generated with the same fingerprint
+// and that the generated files are still present and unaltered.
+func (c *generationCache) UpToDate(key, fingerprint string, files []string) bool {
+	c.mx.Lock()
+	entry, ok := c.Entries[key]
+	c.mx.Unlock()
+
+	if !ok || entry.Fingerprint != fingerprint || len(entry.Files) != len(files) {
+		return false
+	}
+
+	for _, file := range files {
+		expected, ok := entry.Files[file]
+		if !ok {
+			return false
+		}
+
+		sum, err := fileDigest(c.fsys, file)
+		if err != nil || sum != expected {
+			return false
+		}
+	}
+
+	return true
+}
+
+// Record the fingerprint and the digest of the files generated for the item identified by key.
+func (c *generationCache) Record(key, fingerprint string, files []string) {
+	entry := cacheEntry{
+		Fingerprint: fingerprint,
+		Files:       make(map[string]string, len(files)),
+	}
+
+	for _, file := range files {
+		sum, err := fileDigest(c.fsys, file)
+		if err != nil {
+			// the file has not been written (e.g. skip_exists): never consider this item up to date
+			return
+		}
+		entry.Files[file] = sum
+	}
+
+	c.mx.Lock()
+	c.Entries[key] = entry
+	c.mx.Unlock()
+}
+
+// fingerprint computes a digest of the data passed to the templates of a section,
+// together with the template set and layout in use and the version of the generator.
+func (g *GenOpts) fingerprint(section []TemplateOpts, data any) (string, error) {
+	h := sha256.New()
+	enc := json.NewEncoder(h)
+
+	if err := enc.Encode(cacheVersion()); err != nil {
+		return "", err
+	}
+
+	if err := enc.Encode(g.templates.Fingerprint()); err != nil {
+		return "", err
+	}
+
+	if err := enc.Encode(g.PackOptions); err != nil {
+		return "", err
+	}
+
+	if err := enc.Encode(g.funcPluginVersions()); err != nil {
+		return "", err
+	}
+
+	if err := enc.Encode(section); err != nil {
+		return "", err
+	}
+
+	if err := enc.Encode(g.templateFileDigests(section)); err != nil {
+		return "", err
+	}
+
+	if err := enc.Encode(data); err != nil {
+		return "", fmt.Errorf("could not compute fingerprint: %w", err)
+	}
+
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
+
+// templateFileDigests computes a digest of the templates of a section which may be read from disk when rendered,
+// rather than from the repository of templates.
+func (g *GenOpts) templateFileDigests(section []TemplateOpts) map[string]string {
+	digests := make(map[string]string)
+	for i := range section {
+		t := &section[i]
+		if strings.HasPrefix(strings.ToLower(t.Source), "asset:") {
+			continue
+		}
+
+		file := g.templateFile(t)
+		if sum, err := fileDigest(osFS{}, file); err == nil {
+			digests[file] = sum
+		}
+	}
+
+	return digests
+}
+
+// cachedRender renders all templates in a section for some data, unless the cache tells that
+// the generated files are up to date.
+func (g *GenOpts) cachedRender(key string, section []TemplateOpts, data any, render func() error) error {
+	if g.cache == nil {
+		return render()
+	}
+
+	fingerprint, err := g.fingerprint(section, data)
+	if err != nil {
+		// some data cannot be fingerprinted (e.g. recursive schemas): always render such items
+		debugLogf("generation cache disabled for %s: %v", key, err)
+
+		return render()
+	}
+
+	templates, complete, err := g.cachedFiles(section, data)
+	if err != nil {
+		return err
+	}
+	files := slices.Sorted(maps.Keys(templates))
+
+	if complete && g.cache.UpToDate(key, fingerprint, files) {
+		debugLogf("skipping generation of %s: generated files are up to date", key)
+		for _, file := range files {
+			g.emit(Event{Kind: EventFileSkipped, Path: file, Template: templates[file], Element: specElement(data), Reason: SkipReasonUpToDate})
+		}
+
+		return nil
+	}
+
+	if err := render(); err != nil {
+		return err
+	}
+
+	g.cache.Record(key, fingerprint, files)
+
+	return nil
+}
+
+// cachedFiles lists the files tracked by the cache for the templates of a section, with the name of the template
+// producing them: only the files actually written for this data are tracked.
+//
+// Templates with a "when" condition which is not met produce no file. Files generated only once (skip_exists)
+// then belong to the user and are not tracked: when such a file is missing, the item is not complete and must be rendered.
+func (g *GenOpts) cachedFiles(section []TemplateOpts, data any) (map[string]string, bool, error) {
+	files := make(map[string]string, len(section))
+	complete := true
+
+	for i := range section {
+		t := &section[i]
+
+		matches, err := g.matchesCondition(t, data)
+		if err != nil {
+			return nil, false, err
+		}
+		if !matches {
+			continue
+		}
+
+		dir, fname, err := g.location(t, data)
+		if err != nil {
+			return nil, false, fmt.Errorf("failed to resolve template location for template %s: %w", t.Name, err)
+		}
+
+		if t.SkipExists {
+			if !g.fileExists(dir, fname) {
+				complete = false
+			}
+
+			continue
+		}
+
+		files[filepath.Join(dir, fname)] = t.Name
+	}
+
+	return files, complete, nil
+}
+
+// openCache enables incremental generation, unless forced to regenerate everything.
+//
+// Incremental generation is disabled when the version of the generator cannot be established,
+// when generated files cannot be read back (e.g. with a [ZipFS]), or when every file must be explained.
+func (g *GenOpts) openCache() {
+	if g.Force || g.Explain {
+		return
+	}
+
+	if _, writeOnly := g.fs().(*ZipFS); writeOnly {
+		return
+	}
+
+	if cacheVersion() == "" {
+		debugLogf("generation cache disabled: the version of the generator is unknown")
+
+		return
+	}
+
+	g.cache = loadGenerationCache(g.fs(), g.Target)
+}
+
+// saveCache persists the generation cache, if any.
+func (g *GenOpts) saveCache() error {
+	if g.cache == nil {
+		return nil
+	}
+
+	return g.cache.Save()
+}
+
+// closeCache disables incremental generation.
+func (g *GenOpts) closeCache() {
+	g.cache = nil
+}
+
+func fileDigest(fsys WriteFS, file string) (string, error) {
+	buf, err := fsys.ReadFile(file)
+	if err != nil {
+		return "", err
+	}
+
+	sum := sha256.Sum256(buf)
+
+	return hex.EncodeToString(sum[:]), nil
+}
+
+// generatorVersion returns the version of the go-swagger module used to build the generator.
+func generatorVersion() string {
+	info, ok := debug.ReadBuildInfo()
+	if !ok {
+		return unknownVersion
+	}
+
+	if info.Main.Path == generatorModule {
+		return info.Main.Version
+	}
+
+	for _, dep := range info.Deps {
+		if dep.Path == generatorModule {
+			return dep.Version
+		}
+	}
+
+	return unknownVersion
+}
+
+// cacheVersion identifies the generator in the generation cache.
+//
+// Development builds have no released version: they are identified by a digest of the executable,
+// so that any change in the generator invalidates the cache. An empty version disables the cache.
+var cacheVersion = sync.OnceValue(func() string {
+	version := generatorVersion()
+	if version != develVersion && version != unknownVersion && version != "" {
+		return version
+	}
+
+	exe, err := os.Executable()
+	if err != nil {
+		return ""
+	}
+
+	sum, err := fileDigest(osFS{}, exe)
+	if err != nil {
+		return ""
+	}
+
+	return develVersion + "+" + sum
+})
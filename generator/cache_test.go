@@ -0,0 +1,218 @@
+// SPDX-FileCopyrightText: Copyright 2015-2025 go-swagger maintainers
+// SPDX-License-Identifier: Apache-2.0
+
+package generator
+
+import (
+	"archive/zip"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/go-openapi/testify/v2/assert"
+	"github.com/go-openapi/testify/v2/require"
+)
+
+func TestGenerate_Incremental(t *testing.T) {
+	defer discardOutput()()
+
+	target := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(target, "go.mod"), []byte("module incremental\n"), readableFile))
+
+	generate := func(force bool) {
+		opts := testGenOpts()
+		opts.Spec = "../fixtures/bugs/1042/fixture-1042.yaml"
+		opts.Target = target
+		opts.Force = force
+		require.NoError(t, GenerateModels(nil, opts))
+	}
+
+	modelFile := filepath.Join(target, defaultModelsTarget, "a.go")
+	ancient := time.Date(2000, 1, 1, 0, 0, 0, 0, time.UTC)
+	touched := func() bool {
+		info, err := os.Stat(modelFile)
+		require.NoError(t, err)
+
+		return !info.ModTime().Equal(ancient)
+	}
+
+	generate(false)
+	require.FileExists(t, filepath.Join(target, cacheDir, cacheFile))
+	require.FileExists(t, modelFile)
+
+	t.Run("should skip unchanged models", func(t *testing.T) {
+		require.NoError(t, os.Chtimes(modelFile, ancient, ancient))
+		generate(false)
+		assert.FalseT(t, touched())
+	})
+
+	t.Run("should regenerate a model altered on disk", func(t *testing.T) {
+		require.NoError(t, os.WriteFile(modelFile, []byte("package models\n"), readableFile))
+		require.NoError(t, os.Chtimes(modelFile, ancient, ancient))
+		generate(false)
+		assert.TrueT(t, touched())
+
+		content, err := os.ReadFile(modelFile)
+		require.NoError(t, err)
+		assert.StringContainsT(t, string(content), "type A struct")
+	})
+
+	t.Run("should regenerate everything when forced", func(t *testing.T) {
+		require.NoError(t, os.Chtimes(modelFile, ancient, ancient))
+		generate(true)
+		assert.TrueT(t, touched())
+	})
+}
+
+func TestGenerationCache(t *testing.T) {
+	target := t.TempDir()
+	file := filepath.Join(target, "generated.go")
+	require.NoError(t, os.WriteFile(file, []byte("package generated\n"), readableFile))
+
+	cache := loadGenerationCache(osFS{}, target)
+	assert.FalseT(t, cache.UpToDate("model:A", "abc", []string{file}))
+
+	cache.Record("model:A", "abc", []string{file})
+	assert.TrueT(t, cache.UpToDate("model:A", "abc", []string{file}))
+	assert.FalseT(t, cache.UpToDate("model:A", "def", []string{file}))
+
+	t.Run("should not record items with missing files", func(t *testing.T) {
+		cache.Record("model:B", "abc", []string{filepath.Join(target, "missing.go")})
+		assert.FalseT(t, cache.UpToDate("model:B", "abc", []string{filepath.Join(target, "missing.go")}))
+	})
+
+	t.Run("should reload a saved cache", func(t *testing.T) {
+		require.NoError(t, cache.Save())
+
+		reloaded := loadGenerationCache(osFS{}, target)
+		assert.TrueT(t, reloaded.UpToDate("model:A", "abc", []string{file}))
+	})
+
+	t.Run("should ignore a corrupted cache", func(t *testing.T) {
+		require.NoError(t, os.WriteFile(filepath.Join(target, cacheDir, cacheFile), []byte("{"), readableFile))
+
+		reloaded := loadGenerationCache(osFS{}, target)
+		assert.Empty(t, reloaded.Entries)
+	})
+}
+
+func TestGenerate_IncrementalSections(t *testing.T) {
+	defer discardOutput()()
+
+	opts := testGenOpts()
+	opts.Target = t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(opts.Target, "go.mod"), []byte("module incremental\n"), readableFile))
+	require.NoError(t, opts.templates.AddFile("generated", "package {{ .Name }}"))
+	opts.openCache()
+	require.NotNil(t, opts.cache)
+
+	section := []TemplateOpts{
+		{Name: "generated", Source: "asset:generated", Target: "{{ .Target }}", FileName: "{{ .Name }}.go"},
+		{Name: "conditional", Source: "asset:generated", Target: "{{ .Target }}", FileName: "{{ .Name }}_enum.go", When: ".Context.Enum"},
+		{Name: "once", Source: "asset:generated", Target: "{{ .Target }}", FileName: "{{ .Name }}_impl.go", SkipExists: true},
+	}
+	model := &GenDefinition{GenSchema: GenSchema{Name: "pet"}}
+
+	var renders int
+	render := func() error {
+		renders++
+		for i := range section {
+			if err := opts.write(&section[i], model); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	}
+
+	require.NoError(t, opts.cachedRender("model:pet", section, model, render))
+	assert.FileNotExists(t, filepath.Join(opts.Target, "pet_enum.go"))
+	assert.FileExists(t, filepath.Join(opts.Target, "pet_impl.go"))
+
+	t.Run("should cache items with files which are not written", func(t *testing.T) {
+		require.NoError(t, opts.cachedRender("model:pet", section, model, render))
+		assert.EqualT(t, 1, renders)
+	})
+
+	t.Run("should not track the edits of files generated once", func(t *testing.T) {
+		require.NoError(t, os.WriteFile(filepath.Join(opts.Target, "pet_impl.go"), []byte("package pet // edited\n"), readableFile))
+		require.NoError(t, opts.cachedRender("model:pet", section, model, render))
+		assert.EqualT(t, 1, renders)
+	})
+
+	t.Run("should render again a missing file generated once", func(t *testing.T) {
+		require.NoError(t, os.Remove(filepath.Join(opts.Target, "pet_impl.go")))
+		require.NoError(t, opts.cachedRender("model:pet", section, model, render))
+		assert.EqualT(t, 2, renders)
+		assert.FileExists(t, filepath.Join(opts.Target, "pet_impl.go"))
+	})
+}
+
+func TestGenerate_IncrementalTemplateFiles(t *testing.T) {
+	defer discardOutput()()
+
+	opts := testGenOpts()
+	opts.Target = t.TempDir()
+	opts.TemplateDir = t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(opts.Target, "go.mod"), []byte("module incremental\n"), readableFile))
+	templateFile := filepath.Join(opts.TemplateDir, "layout.gotmpl")
+	require.NoError(t, os.WriteFile(templateFile, []byte("package {{ .Name }}\n"), readableFile))
+	opts.openCache()
+	require.NotNil(t, opts.cache)
+
+	section := []TemplateOpts{{Name: "layout", Source: "layout.gotmpl", Target: "{{ .Target }}", FileName: "{{ .Name }}.go"}}
+	model := &GenDefinition{GenSchema: GenSchema{Name: "pet"}}
+
+	var renders int
+	render := func() error {
+		renders++
+
+		return opts.write(&section[0], model)
+	}
+
+	require.NoError(t, opts.cachedRender("model:pet", section, model, render))
+	require.NoError(t, opts.cachedRender("model:pet", section, model, render))
+	assert.EqualT(t, 1, renders)
+
+	// the template is read from disk, not from the repository of templates
+	require.NoError(t, os.WriteFile(templateFile, []byte("package {{ .Name }} // edited\n"), readableFile))
+	require.NoError(t, opts.cachedRender("model:pet", section, model, render))
+	assert.EqualT(t, 2, renders)
+
+	content, err := os.ReadFile(filepath.Join(opts.Target, "pet.go"))
+	require.NoError(t, err)
+	assert.StringContainsT(t, string(content), "// edited")
+}
+
+func TestCacheVersion(t *testing.T) {
+	version := cacheVersion()
+	require.NotEmpty(t, version)
+
+	if released := generatorVersion(); released == develVersion || released == unknownVersion {
+		// test binaries are development builds: they are identified by their content
+		assert.TrueT(t, strings.HasPrefix(version, develVersion+"+"))
+	}
+}
+
+func TestOpenCache(t *testing.T) {
+	for _, tc := range []struct {
+		name    string
+		opts    func(*GenOpts)
+		enabled bool
+	}{
+		{name: "by default", opts: func(*GenOpts) {}, enabled: true},
+		{name: "when forced", opts: func(o *GenOpts) { o.Force = true }},
+		{name: "when explaining files", opts: func(o *GenOpts) { o.Explain = true }},
+		{name: "with a zip archive", opts: func(o *GenOpts) { o.FS = NewZipFS(zip.NewWriter(io.Discard), o.Target) }},
+	} {
+		opts := testGenOpts()
+		opts.Target = t.TempDir()
+		tc.opts(opts)
+
+		opts.openCache()
+		assert.EqualT(t, tc.enabled, opts.cache != nil, tc.name)
+	}
+}
@@ -0,0 +1,833 @@
+// SPDX-FileCopyrightText: Copyright 2015-2025 go-swagger maintainers
+// SPDX-License-Identifier: Apache-2.0
+
+package generator
+
+import (
+	"cmp"
+	"errors"
+	"fmt"
+	"maps"
+	"os"
+	"path/filepath"
+	"reflect"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"text/template"
+	"text/template/parse"
+)
+
+// TemplateIssue is a problem found by checking templates statically.
+type TemplateIssue struct {
+	File     string // file which defines the template. Empty for the target, file name or condition of a template in the layout
+	Line     int
+	Column   int
+	Template string // name of the template, or of the entry in the layout
+	DataType string // go type of the data the template is checked with, if known
+	Message  string
+}
+
+func (i TemplateIssue) String() string {
+	location := i.File
+	if location == "" {
+		location = i.Template
+	}
+
+	var context string
+	switch {
+	case i.File != "" && i.DataType != "":
+		context = fmt.Sprintf(" (in template %q, with %s)", i.Template, i.DataType)
+	case i.File != "":
+		context = fmt.Sprintf(" (in template %q)", i.Template)
+	case i.DataType != "":
+		context = fmt.Sprintf(" (with %s)", i.DataType)
+	}
+
+	return fmt.Sprintf("%s:%d:%d: %s%s", location, i.Line, i.Column, i.Message, context)
+}
+
+// CheckTemplates checks statically the templates of the generator, before any spec is generated.
+//
+// Templates are walked from the entries of the layout, i.e. the built-in layouts for servers, clients,
+// CLIs, markdown, TypeScript and protocol buffers, as well as the layout configured in the options. Each template is checked
+// with the data type passed by its section (GenApp, GenOperation, GenDefinition...) or by the including template.
+//
+// The checks detect:
+//   - templates which fail to parse
+//   - references to fields or methods which do not exist in the data type
+//   - calls to functions with a wrong number of arguments, or arguments of a wrong type
+//   - templates which are included but not defined
+//
+// Only the templates loaded from the TemplateDir, or from a file named by the layout, are reported.
+// Fields of interface types, such as the values of a map[string]any, cannot be checked.
+func CheckTemplates(opts *GenOpts) ([]TemplateIssue, error) {
+	if opts == nil {
+		return nil, errors.New("gen opts are required")
+	}
+
+	if err := opts.EnsureDefaults(); err != nil {
+		return nil, err
+	}
+
+	c := newTemplateChecker(opts)
+	defer opts.beginGeneration()()
+	if err := opts.setTemplates(); err != nil {
+		if opts.TemplateDir == "" {
+			return nil, err
+		}
+
+		// templates which cannot be parsed cannot be checked any further
+		if issues := c.parseDir(opts.TemplateDir); len(issues) > 0 {
+			return issues, nil
+		}
+
+		return nil, err
+	}
+
+	layouts := append([]SectionOpts{opts.Sections}, builtinLayouts()...)
+	for _, layout := range layouts {
+		for _, section := range layoutSections(layout) {
+			for _, t := range section.templates {
+				c.checkLayoutEntry(section, &t)
+			}
+		}
+	}
+
+	c.checkUnused()
+
+	return c.sortedIssues(), nil
+}
+
+// builtinLayouts yields the layouts used by the generate commands when no layout is configured.
+func builtinLayouts() []SectionOpts {
+	server := &GenOpts{GenOptsCommon: GenOptsCommon{
+		IncludeModel: true, IncludeHandler: true, IncludeParameters: true, IncludeResponses: true,
+		IncludeURLBuilder: true, IncludeMain: true, IncludeSupport: true,
+	}}
+	DefaultSectionOpts(server)
+
+	autoConfigured := &GenOpts{GenOptsCommon: GenOptsCommon{IncludeSupport: true, ImplementationPackage: "implementation"}}
+	DefaultSectionOpts(autoConfigured)
+
+	client := &GenOpts{GenOptsCommon: GenOptsCommon{IsClient: true, IncludeCLi: true}}
+	DefaultSectionOpts(client)
+
+	markdown := &GenOpts{}
+	MarkdownSectionOpts(markdown, "markdown.md")
+
+	typescript := &GenOpts{}
+	TypeScriptSectionOpts(typescript)
+
+	proto := &GenOpts{}
+	ProtoSectionOpts(proto)
+
+	return []SectionOpts{server.Sections, autoConfigured.Sections, client.Sections, markdown.Sections, typescript.Sections, proto.Sections}
+}
+
+// layoutSection is a section of the layout, with the type of the data its templates are executed with.
+type layoutSection struct {
+	name      string
+	templates []TemplateOpts
+	dataType  reflect.Type
+}
+
+func layoutSections(sections SectionOpts) []layoutSection {
+	return []layoutSection{
+		{name: "application", templates: sections.Application, dataType: reflect.TypeFor[*GenApp]()},
+		{name: "operations", templates: sections.Operations, dataType: reflect.TypeFor[*GenOperation]()},
+		{name: "operation_groups", templates: sections.OperationGroups, dataType: reflect.TypeFor[*GenOperationGroup]()},
+		{name: "models", templates: sections.Models, dataType: reflect.TypeFor[*GenDefinition]()},
+		{name: "post_models", templates: sections.PostModels, dataType: reflect.TypeFor[GenDefinition]()},
+		{name: "security_schemes", templates: sections.SecuritySchemes, dataType: reflect.TypeFor[*GenSecurityScheme]()},
+		{name: "tags", templates: sections.Tags, dataType: reflect.TypeFor[*GenTag]()},
+		{name: "responses", templates: sections.Responses, dataType: reflect.TypeFor[*GenResponse]()},
+		{name: "parameters", templates: sections.Parameters, dataType: reflect.TypeFor[*GenParameter]()},
+	}
+}
+
+// templateChecker infers the type of the data flowing through templates, and reports the
+// references which cannot be resolved at execution time.
+//
+// A nil type stands for a type which is not known statically: nothing is checked against it.
+type templateChecker struct {
+	opts    *GenOpts
+	funcs   template.FuncMap
+	visited map[checkedTemplate]bool
+	used    map[*parse.Tree]bool
+	issues  map[TemplateIssue]bool
+	failed  map[checkedRef][]TemplateIssue
+	passed  map[checkedRef]bool
+}
+
+type checkedTemplate struct {
+	tree *parse.Tree
+	dot  reflect.Type
+}
+
+// checkedRef is a reference made by a node of a template, e.g. to a field or to a function.
+type checkedRef struct {
+	tree *parse.Tree
+	pos  parse.Pos
+	name string
+}
+
+// templateWalk is the state of the check of one template.
+type templateWalk struct {
+	tmpl     *template.Template
+	tree     *parse.Tree
+	source   TemplateSource
+	root     reflect.Type // the type of the data the template is executed with, or of the data of the section for a layout entry
+	context  reflect.Type // the type of .Context, in the target, file name and condition of a layout entry
+	offset   int          // offset of the columns in the source, for conditions which are wrapped in an if action
+	reported bool
+}
+
+// checkScope is the type of the dot and of the variables, at some point of a template.
+type checkScope struct {
+	dot  reflect.Type
+	vars map[string]reflect.Type
+}
+
+func (s checkScope) clone() checkScope {
+	return checkScope{dot: s.dot, vars: maps.Clone(s.vars)}
+}
+
+func newTemplateChecker(opts *GenOpts) *templateChecker {
+	return &templateChecker{
+		opts:    opts,
+		funcs:   opts.templates.Funcs(),
+		visited: make(map[checkedTemplate]bool),
+		used:    make(map[*parse.Tree]bool),
+		issues:  make(map[TemplateIssue]bool),
+		failed:  make(map[checkedRef][]TemplateIssue),
+		passed:  make(map[checkedRef]bool),
+	}
+}
+
+var rexParseError = regexp.MustCompile(`^template: [^:]*:(\d+):(?:\d+:)? (.*)$`)
+
+// parseDir reports the templates in a directory which fail to parse.
+func (c *templateChecker) parseDir(dir string) []TemplateIssue {
+	var issues []TemplateIssue
+
+	_ = filepath.Walk(dir, func(pth string, info os.FileInfo, err error) error {
+		if err != nil || info.IsDir() || !strings.HasSuffix(pth, ".gotmpl") {
+			return nil //nolint:nilerr // unreadable files are skipped, like when loading templates
+		}
+
+		data, err := os.ReadFile(pth)
+		if err != nil {
+			return nil //nolint:nilerr // unreadable files are skipped, like when loading templates
+		}
+
+		if _, err = template.New(filepath.Base(pth)).Funcs(c.funcs).Parse(string(data)); err != nil {
+			issues = append(issues, parseIssue(pth, filepath.Base(pth), err))
+		}
+
+		return nil
+	})
+
+	return issues
+}
+
+func parseIssue(file, name string, err error) TemplateIssue {
+	issue := TemplateIssue{File: file, Template: name, Message: err.Error()}
+	if matches := rexParseError.FindStringSubmatch(err.Error()); matches != nil {
+		issue.Line, _ = strconv.Atoi(matches[1])
+		issue.Message = matches[2]
+	}
+
+	return issue
+}
+
+// checkLayoutEntry checks the target, file name, condition and template of an entry in the layout.
+func (c *templateChecker) checkLayoutEntry(section layoutSection, t *TemplateOpts) {
+	entry := fmt.Sprintf("layout %s/%s", section.name, t.Name)
+	c.checkExpression(entry+" target", t.Target, 0, section.dataType)
+	c.checkExpression(entry+" file_name", t.FileName, 0, section.dataType)
+	if strings.TrimSpace(t.When) != "" {
+		const wrap = "{{ if "
+		c.checkExpression(entry+" when", wrap+t.When+" }}true{{ end }}", len(wrap), section.dataType)
+	}
+
+	tmpl, source, err := c.resolveLayoutTemplate(t)
+	if err != nil {
+		c.issues[TemplateIssue{Template: entry, DataType: typeName(section.dataType), Message: err.Error()}] = true
+
+		return
+	}
+
+	c.check(tmpl, source, section.dataType)
+}
+
+// resolveLayoutTemplate finds the template of an entry in the layout, like it is resolved at generation time.
+func (c *templateChecker) resolveLayoutTemplate(t *TemplateOpts) (*template.Template, TemplateSource, error) {
+	if strings.HasPrefix(strings.ToLower(t.Source), "asset:") {
+		name := strings.TrimPrefix(t.Source, "asset:")
+		tmpl, source, ok := c.opts.templates.Lookup(name)
+		if !ok {
+			return nil, TemplateSource{}, fmt.Errorf("no such template %q", name)
+		}
+
+		return tmpl, templateSource(source), nil
+	}
+
+	name := c.opts.LanguageOpts.Mangler.ToJSONName(strings.TrimSuffix(t.Source, ".gotmpl"))
+	if tmpl, source, ok := c.opts.templates.Lookup(name); ok {
+		return tmpl, templateSource(source), nil
+	}
+
+	templateFile := t.Source
+	if c.opts.TemplateDir != "" {
+		templateFile = filepath.Join(c.opts.TemplateDir, t.Source)
+	}
+
+	content, err := os.ReadFile(templateFile)
+	if err != nil {
+		return nil, TemplateSource{}, fmt.Errorf("error while opening %s template file: %w", templateFile, err)
+	}
+
+	tmpl, err := template.New(t.Source).Funcs(c.funcs).Parse(string(content))
+	if err != nil {
+		issue := parseIssue(templateFile, t.Source, err)
+		c.issues[issue] = true
+
+		return nil, TemplateSource{}, fmt.Errorf("template %s fails to parse", templateFile)
+	}
+
+	return tmpl, TemplateSource{Name: t.Source, File: templateFile, Origin: OriginDisk}, nil
+}
+
+// checkExpression checks the target, file name or condition of an entry in the layout.
+//
+// These are executed with a templateContext, which Context is the data of the section.
+func (c *templateChecker) checkExpression(name, expression string, offset int, dataType reflect.Type) {
+	tmpl, err := template.New(name).Funcs(c.funcs).Parse(expression)
+	if err != nil {
+		issue := parseIssue("", name, err)
+		issue.Template = name
+		c.issues[issue] = true
+
+		return
+	}
+
+	contextType := reflect.TypeFor[templateContext]()
+	w := &templateWalk{
+		tmpl:     tmpl,
+		tree:     tmpl.Tree,
+		source:   TemplateSource{Name: name},
+		root:     dataType,
+		context:  dataType,
+		offset:   offset,
+		reported: true,
+	}
+	c.walk(w, tmpl.Root, checkScope{dot: contextType, vars: map[string]reflect.Type{"$": contextType}})
+}
+
+// check checks a template, executed with some type of data.
+func (c *templateChecker) check(tmpl *template.Template, source TemplateSource, dot reflect.Type) {
+	if tmpl.Tree == nil || tmpl.Root == nil {
+		return
+	}
+
+	key := checkedTemplate{tree: tmpl.Tree, dot: dot}
+	if c.visited[key] {
+		return
+	}
+	c.visited[key] = true
+	c.used[tmpl.Tree] = true
+
+	w := &templateWalk{
+		tmpl:     tmpl,
+		tree:     tmpl.Tree,
+		source:   source,
+		root:     dot,
+		reported: source.Origin == OriginTemplateDir || source.Origin == OriginPack || source.Origin == OriginDisk,
+	}
+	c.walk(w, tmpl.Root, checkScope{dot: dot, vars: map[string]reflect.Type{"$": dot}})
+}
+
+// checkUnused checks the templates of the TemplateDir which are not included by any entry of the layouts.
+//
+// The type of their data is unknown, so only the calls to functions are checked.
+func (c *templateChecker) checkUnused() {
+	for _, name := range c.opts.templates.Names() {
+		tmpl, source, ok := c.opts.templates.Lookup(name)
+		if !ok || (source.Origin != OriginTemplateDir && source.Origin != OriginPack) || c.used[tmpl.Tree] {
+			continue
+		}
+
+		c.check(tmpl, templateSource(source), nil)
+	}
+}
+
+func (c *templateChecker) sortedIssues() []TemplateIssue {
+	for ref, issues := range c.failed {
+		if c.passed[ref] {
+			continue
+		}
+		for _, issue := range issues {
+			c.issues[issue] = true
+		}
+	}
+
+	return slices.SortedFunc(maps.Keys(c.issues), func(a, b TemplateIssue) int {
+		return cmp.Or(
+			cmp.Compare(a.File, b.File),
+			cmp.Compare(a.Template, b.Template),
+			cmp.Compare(a.Line, b.Line),
+			cmp.Compare(a.Column, b.Column),
+			cmp.Compare(a.Message, b.Message),
+			cmp.Compare(a.DataType, b.DataType),
+		)
+	})
+}
+
+// report a reference made by a node of a template which cannot be resolved.
+//
+// Templates may be executed with several types of data, e.g. recursive templates, and guard the references which
+// only exist for some of them. The issue is only reported if the reference cannot be resolved with any of these types.
+func (c *templateChecker) report(w *templateWalk, node parse.Node, name, format string, args ...any) {
+	if !w.reported {
+		return
+	}
+
+	issue := TemplateIssue{
+		File:     w.source.File,
+		Template: w.source.Name,
+		DataType: typeName(w.root),
+		Message:  fmt.Sprintf(format, args...),
+	}
+
+	location, _ := w.tree.ErrorContext(node)
+	parts := strings.Split(location, ":")
+	if len(parts) >= 3 { //nolint:mnd // location is "name:line:column"
+		issue.Line, _ = strconv.Atoi(parts[len(parts)-2])
+		column, _ := strconv.Atoi(parts[len(parts)-1])
+		issue.Column = column + 1 - w.offset
+	}
+
+	ref := checkedRef{tree: w.tree, pos: node.Position(), name: name}
+	c.failed[ref] = append(c.failed[ref], issue)
+}
+
+// pass records a reference made by a node of a template which is resolved.
+func (c *templateChecker) pass(w *templateWalk, node parse.Node, name string) {
+	c.passed[checkedRef{tree: w.tree, pos: node.Position(), name: name}] = true
+}
+
+func (c *templateChecker) walk(w *templateWalk, node parse.Node, s checkScope) {
+	switch n := node.(type) {
+	case *parse.ListNode:
+		if n == nil {
+			return
+		}
+		for _, item := range n.Nodes {
+			c.walk(w, item, s)
+		}
+
+	case *parse.ActionNode:
+		c.pipe(w, n.Pipe, s, true)
+
+	case *parse.IfNode:
+		inner := s.clone()
+		c.pipe(w, n.Pipe, inner, true)
+		c.walk(w, n.List, inner.clone())
+		c.walk(w, n.ElseList, inner.clone())
+
+	case *parse.WithNode:
+		inner := s.clone()
+		typ := c.pipe(w, n.Pipe, inner, true)
+		body := inner.clone()
+		body.dot = typ
+		c.walk(w, n.List, body)
+		c.walk(w, n.ElseList, inner.clone())
+
+	case *parse.RangeNode:
+		inner := s.clone()
+		typ := c.pipe(w, n.Pipe, inner, false)
+		key, elem := c.rangeTypes(w, n, typ)
+		switch len(n.Pipe.Decl) {
+		case 1:
+			inner.vars[n.Pipe.Decl[0].Ident[0]] = elem
+		case 2: //nolint:mnd // range $key, $elem := pipeline
+			inner.vars[n.Pipe.Decl[0].Ident[0]] = key
+			inner.vars[n.Pipe.Decl[1].Ident[0]] = elem
+		}
+		body := inner.clone()
+		body.dot = elem
+		c.walk(w, n.List, body)
+		c.walk(w, n.ElseList, inner.clone())
+
+	case *parse.TemplateNode:
+		var typ reflect.Type
+		if n.Pipe != nil {
+			typ = c.pipe(w, n.Pipe, s, true)
+		}
+		c.include(w, n, typ)
+	}
+}
+
+// include checks a template included by another one.
+//
+// Like at generation time, templates defined in the same file take precedence over the templates of the repository.
+func (c *templateChecker) include(w *templateWalk, n *parse.TemplateNode, typ reflect.Type) {
+	if tmpl := w.tmpl.Lookup(n.Name); tmpl != nil && tmpl.Tree != nil {
+		c.check(tmpl, TemplateSource{Name: n.Name, File: w.source.File, Origin: w.source.Origin}, typ)
+
+		return
+	}
+
+	if w.source.Origin != OriginDisk {
+		if tmpl, source, ok := c.opts.templates.Lookup(n.Name); ok {
+			c.check(tmpl, templateSource(source), typ)
+
+			return
+		}
+	}
+
+	c.report(w, n, "template "+n.Name, "no such template %q", n.Name)
+}
+
+func (c *templateChecker) rangeTypes(w *templateWalk, n *parse.RangeNode, typ reflect.Type) (key, elem reflect.Type) {
+	if typ == nil {
+		return nil, nil
+	}
+
+	for typ.Kind() == reflect.Pointer {
+		typ = typ.Elem()
+	}
+
+	switch typ.Kind() {
+	case reflect.Slice, reflect.Array:
+		return reflect.TypeFor[int](), typ.Elem()
+	case reflect.Map:
+		return typ.Key(), typ.Elem()
+	case reflect.Chan:
+		return typ.Elem(), typ.Elem()
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return typ, typ
+	case reflect.Struct, reflect.Bool, reflect.Float32, reflect.Float64:
+		c.report(w, n, "range", "range can't iterate over %s", typ)
+	}
+
+	return nil, nil
+}
+
+// pipe checks a pipeline and returns the type of its result.
+func (c *templateChecker) pipe(w *templateWalk, pipe *parse.PipeNode, s checkScope, declare bool) reflect.Type {
+	if pipe == nil {
+		return nil
+	}
+
+	var typ reflect.Type
+	for i, cmd := range pipe.Cmds {
+		typ = c.command(w, cmd, s, typ, i > 0)
+	}
+
+	if !declare {
+		return typ
+	}
+
+	for _, variable := range pipe.Decl {
+		name := variable.Ident[0]
+		if previous, isDeclared := s.vars[name]; pipe.IsAssign && isDeclared && previous != typ {
+			// a variable assigned values of different types is not checked any further
+			s.vars[name] = nil
+
+			continue
+		}
+		s.vars[name] = typ
+	}
+
+	return typ
+}
+
+// command checks a command of a pipeline. The result of the previous command, if any, is passed as the final argument.
+func (c *templateChecker) command(w *templateWalk, cmd *parse.CommandNode, s checkScope, final reflect.Type, hasFinal bool) reflect.Type {
+	args := cmd.Args[1:]
+
+	switch n := cmd.Args[0].(type) {
+	case *parse.IdentifierNode:
+		return c.call(w, cmd, n.Ident, c.argTypes(w, args, s, final, hasFinal))
+	case *parse.FieldNode:
+		return c.chain(w, n, s.dot, n.Ident, c.argTypes(w, args, s, final, hasFinal), len(args) > 0 || hasFinal)
+	case *parse.ChainNode:
+		return c.chain(w, n, c.arg(w, n.Node, s), n.Field, c.argTypes(w, args, s, final, hasFinal), len(args) > 0 || hasFinal)
+	case *parse.VariableNode:
+		return c.chain(w, n, s.vars[n.Ident[0]], n.Ident[1:], c.argTypes(w, args, s, final, hasFinal), len(args) > 0 || hasFinal)
+	default:
+		c.argTypes(w, args, s, final, hasFinal)
+
+		return c.arg(w, n, s)
+	}
+}
+
+// argTypes checks the arguments of a command and returns their types.
+//
+// The type of literals is not reported, since they are converted to the type of the parameter.
+func (c *templateChecker) argTypes(w *templateWalk, args []parse.Node, s checkScope, final reflect.Type, hasFinal bool) []reflect.Type {
+	types := make([]reflect.Type, 0, len(args)+1)
+	for _, arg := range args {
+		typ := c.arg(w, arg, s)
+		switch arg.(type) {
+		case *parse.StringNode, *parse.BoolNode, *parse.NumberNode, *parse.NilNode:
+			typ = nil
+		}
+		types = append(types, typ)
+	}
+
+	if hasFinal {
+		types = append(types, final)
+	}
+
+	return types
+}
+
+// arg checks an argument of a command and returns its type.
+func (c *templateChecker) arg(w *templateWalk, node parse.Node, s checkScope) reflect.Type {
+	switch n := node.(type) {
+	case *parse.DotNode:
+		return s.dot
+	case *parse.FieldNode:
+		return c.chain(w, n, s.dot, n.Ident, nil, false)
+	case *parse.VariableNode:
+		return c.chain(w, n, s.vars[n.Ident[0]], n.Ident[1:], nil, false)
+	case *parse.ChainNode:
+		return c.chain(w, n, c.arg(w, n.Node, s), n.Field, nil, false)
+	case *parse.PipeNode:
+		return c.pipe(w, n, s, true)
+	case *parse.IdentifierNode:
+		return c.call(w, n, n.Ident, nil)
+	case *parse.StringNode:
+		return reflect.TypeFor[string]()
+	case *parse.BoolNode:
+		return reflect.TypeFor[bool]()
+	default:
+		return nil
+	}
+}
+
+// chain resolves a chain of fields or methods, e.g. .Operation.Name. Arguments are passed to the last one.
+func (c *templateChecker) chain(w *templateWalk, node parse.Node, typ reflect.Type, idents []string, args []reflect.Type, isCall bool) reflect.Type {
+	for i, name := range idents {
+		if i < len(idents)-1 {
+			typ = c.member(w, node, typ, name, nil, false)
+
+			continue
+		}
+
+		typ = c.member(w, node, typ, name, args, isCall)
+	}
+
+	return typ
+}
+
+// member resolves a field or a method of a type, like text/template does at execution time.
+func (c *templateChecker) member(w *templateWalk, node parse.Node, typ reflect.Type, name string, args []reflect.Type, isCall bool) reflect.Type {
+	if typ == nil {
+		c.pass(w, node, name)
+
+		return nil
+	}
+
+	if typ.Kind() == reflect.Interface {
+		if method, ok := typ.MethodByName(name); ok {
+			return c.signature(w, node, name, method.Type, args, 0)
+		}
+		c.pass(w, node, name)
+
+		return nil
+	}
+
+	// methods are looked up on the pointer, since data is mostly passed by pointer to templates
+	ptr := typ
+	if typ.Kind() != reflect.Pointer {
+		ptr = reflect.PointerTo(typ)
+	}
+	if method, ok := ptr.MethodByName(name); ok {
+		return c.signature(w, node, name, method.Type, args, 1)
+	}
+
+	base := typ
+	for base.Kind() == reflect.Pointer {
+		base = base.Elem()
+	}
+
+	if base == reflect.TypeFor[templateContext]() && name == "Context" && w.context != nil {
+		c.pass(w, node, name)
+
+		return w.context
+	}
+
+	switch base.Kind() {
+	case reflect.Struct:
+		field, ok := base.FieldByName(name)
+		switch {
+		case !ok || !field.IsExported():
+			c.report(w, node, name, "can't evaluate field %s in type %s", name, typ)
+
+			return nil
+		case isCall:
+			c.report(w, node, name, "%s is not a method but has arguments", name)
+		default:
+			c.pass(w, node, name)
+		}
+
+		return field.Type
+	case reflect.Map:
+		c.pass(w, node, name)
+		if base.Key().Kind() == reflect.String {
+			return base.Elem()
+		}
+
+		return nil
+	case reflect.Interface:
+		c.pass(w, node, name)
+
+		return nil
+	default:
+		c.report(w, node, name, "can't evaluate field %s in type %s", name, typ)
+
+		return nil
+	}
+}
+
+// call checks a call to a function of the funcmap, or to a builtin function of text/template.
+func (c *templateChecker) call(w *templateWalk, node parse.Node, name string, args []reflect.Type) reflect.Type {
+	if fn, ok := c.funcs[name]; ok {
+		if fnType := reflect.TypeOf(fn); fnType != nil && fnType.Kind() == reflect.Func {
+			return c.signature(w, node, name, fnType, args, 0)
+		}
+
+		return nil
+	}
+
+	return builtinResult(name, args)
+}
+
+// signature checks the arguments passed to a function or a method, and returns the type of its result.
+//
+// The receiver of methods counts as the first parameter of their type.
+func (c *templateChecker) signature(w *templateWalk, node parse.Node, name string, fnType reflect.Type, args []reflect.Type, receiver int) reflect.Type {
+	numIn := fnType.NumIn() - receiver
+	valid := true
+
+	switch {
+	case fnType.IsVariadic() && len(args) < numIn-1:
+		c.report(w, node, name, "wrong number of args for %s: want at least %d got %d", name, numIn-1, len(args))
+		valid = false
+	case !fnType.IsVariadic() && len(args) != numIn:
+		c.report(w, node, name, "wrong number of args for %s: want %d got %d", name, numIn, len(args))
+		valid = false
+	default:
+		for i, arg := range args {
+			if arg == nil {
+				continue
+			}
+
+			var param reflect.Type
+			if fnType.IsVariadic() && i >= numIn-1 {
+				param = fnType.In(receiver + numIn - 1).Elem()
+			} else {
+				param = fnType.In(receiver + i)
+			}
+
+			if !assignableArg(arg, param) {
+				c.report(w, node, name, "wrong type for argument %d of %s: expected %s; got %s", i+1, name, param, arg)
+				valid = false
+			}
+		}
+	}
+
+	if valid {
+		c.pass(w, node, name)
+	}
+
+	if fnType.NumOut() == 0 {
+		return nil
+	}
+
+	return fnType.Out(0)
+}
+
+// assignableArg tells if a value may be passed as an argument, allowing for the indirections made by text/template.
+func assignableArg(arg, param reflect.Type) bool {
+	if arg.Kind() == reflect.Interface || param == reflect.TypeFor[reflect.Value]() {
+		return true
+	}
+
+	if param.Kind() == reflect.Interface {
+		return arg.Implements(param) || reflect.PointerTo(arg).Implements(param)
+	}
+
+	return arg.AssignableTo(param) ||
+		(arg.Kind() == reflect.Pointer && arg.Elem().AssignableTo(param)) ||
+		reflect.PointerTo(arg).AssignableTo(param)
+}
+
+// builtinResult returns the type of the result of a builtin function of text/template.
+func builtinResult(name string, args []reflect.Type) reflect.Type {
+	switch name {
+	case "not", "eq", "ne", "lt", "le", "gt", "ge":
+		return reflect.TypeFor[bool]()
+	case "len":
+		return reflect.TypeFor[int]()
+	case "print", "printf", "println", "html", "js", "urlquery":
+		return reflect.TypeFor[string]()
+	case "index":
+		if len(args) == 0 {
+			return nil
+		}
+		item := args[0]
+		for range args[1:] {
+			item = elemType(item)
+		}
+
+		return item
+	case "slice":
+		if len(args) == 0 {
+			return nil
+		}
+
+		return args[0]
+	case "call":
+		if len(args) > 0 && args[0] != nil && args[0].Kind() == reflect.Func && args[0].NumOut() > 0 {
+			return args[0].Out(0)
+		}
+
+		return nil
+	default:
+		return nil
+	}
+}
+
+func elemType(typ reflect.Type) reflect.Type {
+	if typ == nil {
+		return nil
+	}
+
+	for typ.Kind() == reflect.Pointer {
+		typ = typ.Elem()
+	}
+
+	switch typ.Kind() {
+	case reflect.Map, reflect.Slice, reflect.Array:
+		return typ.Elem()
+	case reflect.String:
+		return reflect.TypeFor[byte]()
+	default:
+		return nil
+	}
+}
+
+func typeName(typ reflect.Type) string {
+	if typ == nil {
+		return ""
+	}
+
+	return typ.String()
+}
@@ -0,0 +1,169 @@
+// SPDX-FileCopyrightText: Copyright 2015-2025 go-swagger maintainers
+// SPDX-License-Identifier: Apache-2.0
+
+package generator
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/go-openapi/testify/v2/assert"
+	"github.com/go-openapi/testify/v2/require"
+)
+
+func TestCheckTemplates(t *testing.T) {
+	defer discardOutput()()
+
+	writeTemplates := func(t *testing.T, files map[string]string) string {
+		t.Helper()
+
+		dir := t.TempDir()
+		for name, content := range files {
+			pth := filepath.Join(dir, name)
+			require.NoError(t, os.MkdirAll(filepath.Dir(pth), 0o700))
+			require.NoError(t, os.WriteFile(pth, []byte(content), readableFile))
+		}
+
+		return dir
+	}
+
+	t.Run("should not report the embedded templates", func(t *testing.T) {
+		issues, err := CheckTemplates(testGenOpts())
+		require.NoError(t, err)
+		assert.Empty(t, issues)
+	})
+
+	t.Run("should report unknown fields, functions misuse and undefined templates", func(t *testing.T) {
+		opts := testGenOpts()
+		opts.TemplateDir = writeTemplates(t, map[string]string{
+			"server/operation.gotmpl": "package {{ .Package }}\n\n" +
+				"// {{ .Nmae }}\n" +
+				"{{ range .Params }}{{ .GoType }} {{ .Recevier }}{{ end }}\n" +
+				"{{ pascalize .Name .Package }}\n" +
+				"{{ template \"undefinedTemplate\" . }}\n",
+		})
+
+		issues, err := CheckTemplates(opts)
+		require.NoError(t, err)
+
+		file := filepath.Join(opts.TemplateDir, "server", "operation.gotmpl")
+		expected := []TemplateIssue{
+			{Line: 3, Column: 7, Message: "can't evaluate field Nmae in type *generator.GenOperation"},
+			{Line: 4, Column: 37, Message: "can't evaluate field Recevier in type generator.GenParameter"},
+			{Line: 5, Column: 4, Message: "wrong number of args for pascalize: want 1 got 2"},
+			{Line: 6, Column: 13, Message: `no such template "undefinedTemplate"`},
+		}
+		require.Len(t, issues, len(expected))
+		for i, issue := range issues {
+			assert.EqualT(t, file, issue.File)
+			assert.EqualT(t, "serverOperation", issue.Template)
+			assert.EqualT(t, "*generator.GenOperation", issue.DataType)
+			assert.EqualT(t, expected[i].Line, issue.Line)
+			assert.EqualT(t, expected[i].Column, issue.Column)
+			assert.EqualT(t, expected[i].Message, issue.Message)
+		}
+
+		assert.EqualT(t,
+			file+`:3:7: can't evaluate field Nmae in type *generator.GenOperation (in template "serverOperation", with *generator.GenOperation)`,
+			issues[0].String(),
+		)
+	})
+
+	t.Run("should check templates included with the type of the including template", func(t *testing.T) {
+		opts := testGenOpts()
+		opts.AllowTemplateOverride = true
+		opts.TemplateDir = writeTemplates(t, map[string]string{
+			"schemavalidator.gotmpl": `{{ define "schemavalidator" }}{{ .Name }}{{ .IsNullable }}{{ .Nulable }}{{ end }}`,
+		})
+
+		issues, err := CheckTemplates(opts)
+		require.NoError(t, err)
+		require.NotEmpty(t, issues)
+		for _, issue := range issues {
+			assert.EqualT(t, "schemavalidator", issue.Template)
+			assert.StringContainsT(t, issue.Message, "can't evaluate field Nulable")
+		}
+	})
+
+	t.Run("should not report a reference which is resolved with another type", func(t *testing.T) {
+		opts := testGenOpts()
+		opts.TemplateDir = writeTemplates(t, map[string]string{
+			"server/operation.gotmpl": `{{ template "describe" . }}{{ range .Params }}{{ template "describe" . }}{{ end }}`,
+			"describe.gotmpl":         `{{ define "describe" }}{{ if .Params }}{{ .Summary }}{{ else }}{{ .Location }}{{ end }}{{ end }}`,
+		})
+
+		issues, err := CheckTemplates(opts)
+		require.NoError(t, err)
+		assert.Empty(t, issues)
+	})
+
+	t.Run("should report parse errors", func(t *testing.T) {
+		opts := testGenOpts()
+		opts.TemplateDir = writeTemplates(t, map[string]string{
+			"server/operation.gotmpl": "package {{ .Package }}\n\n{{ undefinedFunc .Name }}\n",
+		})
+
+		issues, err := CheckTemplates(opts)
+		require.NoError(t, err)
+		require.Len(t, issues, 1)
+		assert.EqualT(t, filepath.Join(opts.TemplateDir, "server", "operation.gotmpl"), issues[0].File)
+		assert.EqualT(t, 3, issues[0].Line)
+		assert.StringContainsT(t, issues[0].Message, `function "undefinedFunc" not defined`)
+	})
+
+	t.Run("should check the target, file name and condition of the layout", func(t *testing.T) {
+		opts := testGenOpts()
+		opts.TemplateDir = writeTemplates(t, map[string]string{
+			"stream.gotmpl": "package {{ .Package }}\n// {{ .Summry }}\n",
+		})
+		opts.Sections.Operations = append(opts.Sections.Operations, TemplateOpts{
+			Name:     "stream",
+			Source:   "stream.gotmpl",
+			Target:   "{{ .Target }}",
+			FileName: "{{ .Nmae }}_stream.go",
+			When:     `hasKey .Context.Extension "x-streaming"`,
+		})
+
+		issues, err := CheckTemplates(opts)
+		require.NoError(t, err)
+		require.Len(t, issues, 3)
+
+		assert.EqualT(t, "layout operations/stream file_name", issues[0].Template)
+		assert.EqualT(t, 1, issues[0].Line)
+		assert.EqualT(t, 4, issues[0].Column)
+		assert.EqualT(t, "can't evaluate field Nmae in type generator.templateContext", issues[0].Message)
+
+		assert.EqualT(t, "layout operations/stream when", issues[1].Template)
+		assert.EqualT(t, 16, issues[1].Column) // position of the last field of the chain
+		assert.EqualT(t, "can't evaluate field Extension in type *generator.GenOperation", issues[1].Message)
+
+		assert.EqualT(t, filepath.Join(opts.TemplateDir, "stream.gotmpl"), issues[2].File)
+		assert.EqualT(t, 2, issues[2].Line)
+		assert.EqualT(t, "can't evaluate field Summry in type *generator.GenOperation", issues[2].Message)
+	})
+
+	t.Run("should check function calls in templates which are not used by the layout", func(t *testing.T) {
+		opts := testGenOpts()
+		opts.TemplateDir = writeTemplates(t, map[string]string{
+			"unused.gotmpl": `{{ define "unused" }}{{ .Whatever }}{{ camelize }}{{ end }}`,
+		})
+
+		issues, err := CheckTemplates(opts)
+		require.NoError(t, err)
+		require.Len(t, issues, 1)
+		assert.EqualT(t, "unused", issues[0].Template)
+		assert.EqualT(t, "wrong number of args for camelize: want 1 got 0", issues[0].Message)
+	})
+
+	t.Run("should fail on protected templates", func(t *testing.T) {
+		opts := testGenOpts()
+		opts.TemplateDir = writeTemplates(t, map[string]string{
+			"schemavalidator.gotmpl": `{{ define "schemavalidator" }}{{ end }}`,
+		})
+
+		_, err := CheckTemplates(opts)
+		require.Error(t, err)
+		assert.StringContainsT(t, err.Error(), "cannot overwrite protected template")
+	})
+}
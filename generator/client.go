@@ -7,12 +7,13 @@ import (
 	"errors"
 	"os"
 	"path"
-
-	"github.com/go-openapi/swag/jsonutils"
+	"time"
 )
 
 // GenerateClient generates a client library for a swagger spec document.
 func GenerateClient(name string, modelNames, operationIDs []string, opts *GenOpts) error {
+	defer opts.beginGeneration()()
+
 	if err := opts.CheckOpts(); err != nil {
 		return err
 	}
@@ -105,38 +106,47 @@ func (c *clientGenerator) Generate() error {
 	}
 
 	if c.DumpData {
-		var dynamicApp any
-		if err := jsonutils.FromDynamicJSON(app, &dynamicApp); err != nil {
-			return err
-		}
-
-		return dumpData(os.Stdout, dynamicApp)
+		return c.GenOpts.dumpData(os.Stdout, app)
 	}
 
+	start := time.Now()
+	c.GenOpts.openCache()
+	defer c.GenOpts.closeCache()
+
 	if c.GenOpts.IncludeModel {
+		jobs := make([]renderJob, 0, len(app.Models))
 		for _, m := range app.Models {
 			if m.IsStream {
 				continue
 			}
 			mod := m
-			if err := c.GenOpts.renderDefinition(&mod); err != nil {
-				return err
-			}
+			jobs = append(jobs, func() error {
+				return c.GenOpts.renderDefinition(&mod)
+			})
+		}
+
+		if err := c.GenOpts.renderConcurrently(jobs); err != nil {
+			return err
 		}
 	}
 
 	if c.GenOpts.IncludeHandler {
+		jobs := make([]renderJob, 0, len(app.Operations)+len(app.OperationGroups))
 		for _, g := range app.OperationGroups {
 			opg := g
 			for _, o := range opg.Operations {
 				op := o
-				if err := c.GenOpts.renderOperation(&op); err != nil {
-					return err
-				}
-			}
-			if err := c.GenOpts.renderOperationGroup(&opg); err != nil {
-				return err
+				jobs = append(jobs, func() error {
+					return c.GenOpts.renderOperation(&op)
+				})
 			}
+			jobs = append(jobs, func() error {
+				return c.GenOpts.renderOperationGroup(&opg)
+			})
+		}
+
+		if err := c.GenOpts.renderConcurrently(jobs); err != nil {
+			return err
 		}
 	}
 
@@ -146,5 +156,10 @@ func (c *clientGenerator) Generate() error {
 		}
 	}
 
+	if err := c.GenOpts.saveCache(); err != nil {
+		return err
+	}
+	c.GenOpts.emitSince(start, Event{Kind: EventGenerationDone})
+
 	return nil
 }
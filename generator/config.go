@@ -4,49 +4,168 @@
 package generator
 
 import (
+	"bytes"
 	"errors"
 	"fmt"
+	"maps"
 	"os"
 	"path/filepath"
+	"reflect"
+	"regexp"
+	"slices"
+	"strings"
 
 	"github.com/spf13/viper"
 )
 
 // LanguageDefinition in the configuration file.
+//
+// Besides the layout of the generated files, the configuration file may carry generation options,
+// keyed like the long command line flags (e.g. "model-package" or "model_package"), and named profiles.
+//
+// A profile may extend another profile: its options and layout take precedence over the ones it extends,
+// which in turn take precedence over the top-level options and layout.
+//
+// When several specs are listed, they are generated in one run, with the models they share generated once.
+// See [GenerateMultiSpec].
 type LanguageDefinition struct {
-	Layout SectionOpts `mapstructure:"layout"`
+	Layout   SectionOpts                  `mapstructure:"layout"`
+	Options  map[string]any               `mapstructure:"options"`
+	Profiles map[string]ProfileDefinition `mapstructure:"profiles"`
+	Specs    []SpecTarget                 `mapstructure:"specs"`
+	Shared   SharedModelsOpts             `mapstructure:"shared"`
+
+	// ModelPackages routes definitions into sub-packages of the models package, by the prefix of their name.
+	ModelPackages []ModelPackageRule `mapstructure:"model_packages"`
+
+	// OperationPackages routes operations into packages by operationId, with the "rules" grouping of operations.
+	OperationPackages map[string]string `mapstructure:"operation_packages"`
+
+	// Hooks are commands run before and after the generation.
+	Hooks HooksOpts `mapstructure:"hooks"`
+
+	// Functions are external programs providing template functions, under a namespace.
+	Functions []FuncPluginOpts `mapstructure:"functions"`
+}
+
+// ProfileDefinition is a named set of generation options in the configuration file.
+type ProfileDefinition struct {
+	Extends   string           `mapstructure:"extends"`
+	Layout    *SectionOpts     `mapstructure:"layout"`
+	Options   map[string]any   `mapstructure:"options"`
+	Hooks     *HooksOpts       `mapstructure:"hooks"`
+	Functions []FuncPluginOpts `mapstructure:"functions"`
 }
 
 // ConfigureOpts for generation.
+//
+// The default layout is retained when the definition has no layout, e.g. with a configuration file
+// which only carries generation options.
 func (d *LanguageDefinition) ConfigureOpts(opts *GenOpts) error {
-	opts.Sections = d.Layout
+	if !reflect.ValueOf(d.Layout).IsZero() {
+		opts.Sections = d.Layout
+	}
+
+	if len(d.ModelPackages) > 0 {
+		opts.ModelPackageRules = d.ModelPackages
+	}
+
+	if len(d.OperationPackages) > 0 {
+		opts.OperationPackageRules = d.OperationPackages
+	}
+
+	if !d.Hooks.isEmpty() {
+		opts.Hooks = d.Hooks
+	}
+
+	if len(d.Functions) > 0 {
+		opts.FuncPlugins = d.Functions
+	}
 
 	return opts.EnsureDefaults()
 }
 
+// WithProfile resolves a named profile, with the profiles it extends.
+//
+// The returned definition carries the options and layout resolved for this profile.
+// With an empty name, the top-level options and layout are returned.
+func (d *LanguageDefinition) WithProfile(name string) (*LanguageDefinition, error) {
+	resolved := &LanguageDefinition{
+		Layout:  d.Layout,
+		Options: make(map[string]any, len(d.Options)),
+		Specs:   d.Specs,
+		Shared:  d.Shared,
+
+		ModelPackages:     d.ModelPackages,
+		OperationPackages: d.OperationPackages,
+		Hooks:             d.Hooks,
+		Functions:         d.Functions,
+	}
+	maps.Copy(resolved.Options, d.Options)
+
+	if name == "" {
+		return resolved, nil
+	}
+
+	// the chain of profiles, from the requested profile up to the root profile
+	var chain []ProfileDefinition
+	visited := make(map[string]bool)
+	for current := name; current != ""; {
+		if visited[current] {
+			return nil, fmt.Errorf("profile %q extends itself through %q", name, current)
+		}
+		visited[current] = true
+
+		profile, ok := d.Profiles[current]
+		if !ok {
+			if current == name {
+				return nil, fmt.Errorf("unknown profile %q in config file. Available profiles: %s",
+					name, strings.Join(slices.Sorted(maps.Keys(d.Profiles)), ", "))
+			}
+
+			return nil, fmt.Errorf("profile %q extends an unknown profile %q", name, current)
+		}
+
+		chain = append(chain, profile)
+		current = profile.Extends
+	}
+
+	for _, profile := range slices.Backward(chain) {
+		maps.Copy(resolved.Options, profile.Options)
+		if profile.Layout != nil {
+			resolved.Layout = *profile.Layout
+		}
+		if profile.Hooks != nil {
+			resolved.Hooks = *profile.Hooks
+		}
+		if len(profile.Functions) > 0 {
+			resolved.Functions = profile.Functions
+		}
+	}
+
+	return resolved, nil
+}
+
 // LanguageConfig structure that is obtained from parsing a config file.
 type LanguageConfig map[string]LanguageDefinition
 
 // ReadConfig at the specified path, when no path is specified it will look into
 // the current directory and load a .swagger.{yml,json,hcl,toml,properties} file
 // Returns a viper config or an error.
+//
+// Environment variables are interpolated in the content of the file, with the syntax ${VAR} or ${VAR:-default}.
 func ReadConfig(fpath string) (*viper.Viper, error) {
 	v := viper.New()
 	if fpath != "" {
 		if !fileExists(fpath, "") {
 			return nil, fmt.Errorf("can't find file for %q", fpath)
 		}
-		file, err := os.Open(fpath)
-		if err != nil {
-			return nil, err
-		}
-		defer func() { _ = file.Close() }()
 		ext := filepath.Ext(fpath)
 		if len(ext) > 0 {
 			ext = ext[1:]
 		}
 		v.SetConfigType(ext)
-		if err := v.ReadConfig(file); err != nil {
+		if err := readInterpolatedConfig(v, fpath); err != nil {
 			return nil, err
 		}
 		return v, nil
@@ -60,5 +179,59 @@ func ReadConfig(fpath string) (*viper.Viper, error) {
 			return nil, err
 		}
 	}
+
+	if found := v.ConfigFileUsed(); found != "" && fileExists(found, "") {
+		if err := readInterpolatedConfig(v, found); err != nil {
+			return nil, err
+		}
+	}
+
 	return v, nil
 }
+
+func readInterpolatedConfig(v *viper.Viper, fpath string) error {
+	content, err := os.ReadFile(fpath)
+	if err != nil {
+		return err
+	}
+
+	interpolated, err := interpolateEnv(content)
+	if err != nil {
+		return fmt.Errorf("in config file %q: %w", fpath, err)
+	}
+
+	return v.ReadConfig(bytes.NewReader(interpolated))
+}
+
+// rexEnvVar matches ${VAR} and ${VAR:-default}.
+var rexEnvVar = regexp.MustCompile(`\$\{([A-Za-z_][A-Za-z0-9_]*)(:-([^}]*))?\}`)
+
+// interpolateEnv replaces references to environment variables.
+//
+// An undefined variable without a default value is an error.
+func interpolateEnv(content []byte) ([]byte, error) {
+	var undefined []string
+
+	interpolated := rexEnvVar.ReplaceAllFunc(content, func(match []byte) []byte {
+		groups := rexEnvVar.FindSubmatch(match)
+		name := string(groups[1])
+
+		if value, ok := os.LookupEnv(name); ok {
+			return []byte(value)
+		}
+
+		if len(groups[2]) > 0 {
+			return groups[3]
+		}
+
+		undefined = append(undefined, name)
+
+		return match
+	})
+
+	if len(undefined) > 0 {
+		return nil, fmt.Errorf("undefined environment variables: %s", strings.Join(undefined, ", "))
+	}
+
+	return interpolated, nil
+}
@@ -0,0 +1,124 @@
+// SPDX-FileCopyrightText: Copyright 2015-2025 go-swagger maintainers
+// SPDX-License-Identifier: Apache-2.0
+
+package generator
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/go-openapi/testify/v2/assert"
+	"github.com/go-openapi/testify/v2/require"
+)
+
+func TestLanguageDefinition_WithProfile(t *testing.T) {
+	serverLayout := &SectionOpts{
+		Application: []TemplateOpts{{Name: "main", Source: "asset:serverMain"}},
+	}
+
+	def := LanguageDefinition{
+		Options: map[string]any{"model-package": "models", "principal": "models.User"},
+		Profiles: map[string]ProfileDefinition{
+			"base":   {Options: map[string]any{"model-package": "dto"}},
+			"server": {Extends: "base", Layout: serverLayout, Options: map[string]any{"server-package": "api"}},
+			"custom": {Extends: "server", Options: map[string]any{"server-package": "custom"}},
+			"loop":   {Extends: "cycle"},
+			"cycle":  {Extends: "loop"},
+			"orphan": {Extends: "missing"},
+		},
+	}
+
+	t.Run("should resolve top-level options without a profile", func(t *testing.T) {
+		resolved, err := def.WithProfile("")
+		require.NoError(t, err)
+		assert.Equal(t, def.Options, resolved.Options)
+		assert.Empty(t, resolved.Layout.Application)
+	})
+
+	t.Run("should resolve inherited profiles", func(t *testing.T) {
+		resolved, err := def.WithProfile("custom")
+		require.NoError(t, err)
+		assert.Equal(t, map[string]any{
+			"model-package":  "dto",
+			"principal":      "models.User",
+			"server-package": "custom",
+		}, resolved.Options)
+		assert.Equal(t, serverLayout.Application, resolved.Layout.Application)
+
+		t.Run("should not alter the definition", func(t *testing.T) {
+			assert.Len(t, def.Options, 2)
+		})
+	})
+
+	t.Run("should detect errors in profiles", func(t *testing.T) {
+		for _, profile := range []string{"unknown", "loop", "orphan"} {
+			_, err := def.WithProfile(profile)
+			require.Error(t, err, profile)
+		}
+	})
+}
+
+func TestLanguageDefinition_ConfigureOpts(t *testing.T) {
+	t.Run("should retain the default layout", func(t *testing.T) {
+		opts := testGenOpts()
+		def := LanguageDefinition{Options: map[string]any{"model-package": "dto"}}
+		require.NoError(t, def.ConfigureOpts(opts))
+		assert.NotEmpty(t, opts.Sections.Models)
+	})
+
+	t.Run("should set the layout", func(t *testing.T) {
+		opts := testGenOpts()
+		def := LanguageDefinition{Layout: SectionOpts{Models: []TemplateOpts{{Name: "custom"}}}}
+		require.NoError(t, def.ConfigureOpts(opts))
+		require.Len(t, opts.Sections.Models, 1)
+		assert.EqualT(t, "custom", opts.Sections.Models[0].Name)
+	})
+
+	t.Run("should set the rules for model packages", func(t *testing.T) {
+		opts := testGenOpts()
+		def := LanguageDefinition{ModelPackages: []ModelPackageRule{{Prefix: "Billing", Package: "billing"}}}
+		require.NoError(t, def.ConfigureOpts(opts))
+		assert.Equal(t, def.ModelPackages, opts.ModelPackageRules)
+	})
+
+	t.Run("should set the rules for operation packages", func(t *testing.T) {
+		opts := testGenOpts()
+		def := LanguageDefinition{OperationPackages: map[string]string{"listPets": "inventory"}}
+		require.NoError(t, def.ConfigureOpts(opts))
+		assert.Equal(t, def.OperationPackages, opts.OperationPackageRules)
+	})
+}
+
+func TestReadConfig_Interpolation(t *testing.T) {
+	config := filepath.Join(t.TempDir(), "config.yml")
+	require.NoError(t, os.WriteFile(config, []byte(`
+options:
+  model-package: ${TEST_GEN_PACKAGE}
+  principal: ${TEST_GEN_UNSET:-models.User}
+layout:
+  models:
+    - name: definition
+      target: "{{ $target := .Target }}{{ $target }}"
+`), readableFile))
+
+	t.Run("should interpolate environment variables", func(t *testing.T) {
+		t.Setenv("TEST_GEN_PACKAGE", "dto")
+		v, err := ReadConfig(config)
+		require.NoError(t, err)
+
+		assert.EqualT(t, "dto", v.GetString("options.model-package"))
+		assert.EqualT(t, "models.User", v.GetString("options.principal"))
+
+		var def LanguageDefinition
+		require.NoError(t, v.Unmarshal(&def))
+		require.Len(t, def.Layout.Models, 1)
+		assert.EqualT(t, "{{ $target := .Target }}{{ $target }}", def.Layout.Models[0].Target)
+	})
+
+	t.Run("should fail on undefined variables", func(t *testing.T) {
+		_, err := ReadConfig(config)
+		require.Error(t, err)
+		assert.StringContainsT(t, err.Error(), "TEST_GEN_PACKAGE")
+	})
+}
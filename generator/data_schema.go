@@ -0,0 +1,330 @@
+// SPDX-FileCopyrightText: Copyright 2015-2025 go-swagger maintainers
+// SPDX-License-Identifier: Apache-2.0
+
+package generator
+
+import (
+	"encoding"
+	"encoding/json"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"maps"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+
+	"github.com/go-openapi/spec"
+)
+
+// dataSchemaID is the location of the published schema of the data of templates.
+const dataSchemaID = "https://goswagger.io/go-swagger/reference/templates/data-model.schema.json"
+
+// dataSchemaRoots are the types of the data passed to templates.
+// The first ones are the data dumped by the generate commands: an application, a model or an operation.
+var dataSchemaRoots = []reflect.Type{
+	reflect.TypeFor[GenApp](),
+	reflect.TypeFor[GenDefinition](),
+	reflect.TypeFor[GenOperation](),
+	reflect.TypeFor[GenOperationGroup](),
+	reflect.TypeFor[GenTag](),
+	reflect.TypeFor[GenSecurityScheme](),
+	reflect.TypeFor[GenResponse](),
+	reflect.TypeFor[GenParameter](),
+	reflect.TypeFor[GenPackageDoc](),
+}
+
+// dumpedRoots is the number of roots which may be dumped, at the start of dataSchemaRoots.
+const dumpedRoots = 3
+
+// swaggerSchemaTypes are the types of the spec which are marshalled like in the spec, with their definition
+// in the JSON schema of swagger 2.0.
+var swaggerSchemaTypes = map[reflect.Type]string{
+	reflect.TypeFor[spec.Schema]():                "schema",
+	reflect.TypeFor[spec.Info]():                  "info",
+	reflect.TypeFor[spec.ExternalDocumentation](): "externalDocs",
+	reflect.TypeFor[spec.Tag]():                   "tag",
+}
+
+const swaggerSchemaID = "http://swagger.io/v2/schema.json"
+
+// generatorPkg is the package of the types documented by their doc comments.
+var generatorPkg = reflect.TypeFor[GenApp]().PkgPath()
+
+// dataSchema builds the JSON schema (draft 2020-12) of the data of templates, as marshalled by the dump-data option.
+//
+// The schema is reflected from the go types, and documented with the doc comments found in the go sources
+// of the package, from the source directory.
+func dataSchema(sourceDir string) (map[string]any, error) {
+	docs, err := parseTypeDocs(sourceDir)
+	if err != nil {
+		return nil, err
+	}
+
+	r := &schemaReflector{docs: docs, defs: make(map[string]any)}
+	dumped := make([]any, 0, dumpedRoots)
+	for i, root := range dataSchemaRoots {
+		ref := r.schema(root)
+		if i < dumpedRoots {
+			dumped = append(dumped, ref)
+		}
+	}
+
+	return map[string]any{
+		"$schema":     "https://json-schema.org/draft/2020-12/schema",
+		"$id":         dataSchemaID,
+		"title":       "go-swagger template data",
+		"description": "The data passed to the templates of go-swagger, as dumped by the --dump-data option of the generate commands. Fields are named after the go fields: they are available to templates as {{ .Name }}. Fields which are not set may be null.",
+		"anyOf":       dumped,
+		"$defs":       r.defs,
+	}, nil
+}
+
+// marshalDataSchema renders the schema of the data of templates as indented JSON.
+func marshalDataSchema(sourceDir string) ([]byte, error) {
+	schema, err := dataSchema(sourceDir)
+	if err != nil {
+		return nil, err
+	}
+
+	buf, err := json.MarshalIndent(schema, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+
+	return append(buf, '\n'), nil
+}
+
+type schemaReflector struct {
+	docs map[string]string // doc comments, by type name or by type and field names, e.g. "GenSchema.Name"
+	defs map[string]any
+}
+
+func (r *schemaReflector) schema(t reflect.Type) map[string]any {
+	if t.Kind() == reflect.Pointer {
+		return r.schema(t.Elem())
+	}
+
+	if ref, ok := swaggerSchemaTypes[t]; ok {
+		return map[string]any{"$ref": swaggerSchemaID + "#/definitions/" + ref}
+	}
+
+	if t == reflect.TypeFor[GenStatusCodeResponses]() {
+		// marshalled as an object, by status code
+		return map[string]any{
+			"type":                 "object",
+			"additionalProperties": r.schema(reflect.TypeFor[GenResponse]()),
+		}
+	}
+
+	if implements(t, reflect.TypeFor[json.Marshaler]()) {
+		return map[string]any{"description": "marshalled by " + t.String()}
+	}
+
+	if implements(t, reflect.TypeFor[encoding.TextMarshaler]()) {
+		return map[string]any{"type": "string"}
+	}
+
+	switch t.Kind() {
+	case reflect.Bool:
+		return map[string]any{"type": "boolean"}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return map[string]any{"type": "integer"}
+	case reflect.Float32, reflect.Float64:
+		return map[string]any{"type": "number"}
+	case reflect.String:
+		return map[string]any{"type": "string"}
+	case reflect.Slice, reflect.Array:
+		return map[string]any{"type": "array", "items": r.schema(t.Elem())}
+	case reflect.Map:
+		return map[string]any{"type": "object", "additionalProperties": r.schema(t.Elem())}
+	case reflect.Struct:
+		return r.structSchema(t)
+	default:
+		// interfaces hold any value
+		return map[string]any{}
+	}
+}
+
+func implements(t, iface reflect.Type) bool {
+	return t.Implements(iface) || reflect.PointerTo(t).Implements(iface)
+}
+
+// structSchema defines the schema of a struct type, and refers to it.
+func (r *schemaReflector) structSchema(t reflect.Type) map[string]any {
+	name := t.Name()
+	if t.PkgPath() != generatorPkg {
+		name = filepath.Base(t.PkgPath()) + "." + name
+	}
+	ref := map[string]any{"$ref": "#/$defs/" + name}
+
+	if _, defined := r.defs[name]; defined {
+		return ref
+	}
+
+	def := map[string]any{"type": "object"}
+	r.defs[name] = def // defined before its fields, for recursive types
+	if doc := r.docs[t.Name()]; doc != "" && t.PkgPath() == generatorPkg {
+		def["description"] = doc
+	}
+
+	properties := make(map[string]any)
+	r.fields(t, properties)
+	def["properties"] = properties
+
+	return ref
+}
+
+// fields collects the properties of the fields of a struct, and of the fields promoted from its embedded structs,
+// like encoding/json. The fields of an outer struct hide the promoted fields with the same name.
+func (r *schemaReflector) fields(t reflect.Type, properties map[string]any) {
+	var embedded []reflect.Type
+	for i := range t.NumField() {
+		field := t.Field(i)
+		name, skip := jsonFieldName(field)
+		if skip {
+			continue
+		}
+
+		fieldType := field.Type
+		if fieldType.Kind() == reflect.Pointer {
+			fieldType = fieldType.Elem()
+		}
+
+		if field.Anonymous && name == "" && fieldType.Kind() == reflect.Struct {
+			embedded = append(embedded, fieldType)
+
+			continue
+		}
+
+		if !field.IsExported() {
+			continue
+		}
+
+		if _, hidden := properties[name]; hidden {
+			continue
+		}
+
+		if fieldType.Kind() == reflect.Func || fieldType.Kind() == reflect.Chan {
+			continue
+		}
+
+		property := r.schema(field.Type)
+		if doc := r.docs[t.Name()+"."+field.Name]; doc != "" && t.PkgPath() == generatorPkg {
+			property = withDescription(property, doc)
+		}
+		properties[name] = property
+	}
+
+	for _, e := range embedded {
+		promoted := make(map[string]any)
+		r.fields(e, promoted)
+		for name, property := range promoted {
+			if _, hidden := properties[name]; !hidden {
+				properties[name] = property
+			}
+		}
+	}
+}
+
+// jsonFieldName is the name of a field given by its json tag, if any, and tells if the field is not marshalled.
+func jsonFieldName(field reflect.StructField) (string, bool) {
+	tag, ok := field.Tag.Lookup("json")
+	if !ok {
+		if field.Anonymous {
+			return "", false
+		}
+
+		return field.Name, false
+	}
+
+	name, _, _ := strings.Cut(tag, ",")
+	if name == "-" {
+		return "", true
+	}
+
+	if name == "" && !field.Anonymous {
+		name = field.Name
+	}
+
+	return name, false
+}
+
+// withDescription documents a property, with a copy of the schema of its type.
+func withDescription(property map[string]any, doc string) map[string]any {
+	described := maps.Clone(property)
+	described["description"] = doc
+
+	return described
+}
+
+// parseTypeDocs reads the doc comments of the types declared in the go files of a directory, and of their fields.
+func parseTypeDocs(dir string) (map[string]string, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	docs := make(map[string]string)
+	fset := token.NewFileSet()
+	for _, entry := range entries {
+		name := entry.Name()
+		if entry.IsDir() || filepath.Ext(name) != ".go" || strings.HasSuffix(name, "_test.go") {
+			continue
+		}
+
+		file, err := parser.ParseFile(fset, filepath.Join(dir, name), nil, parser.ParseComments)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, decl := range file.Decls {
+			gen, ok := decl.(*ast.GenDecl)
+			if !ok || gen.Tok != token.TYPE {
+				continue
+			}
+
+			for _, s := range gen.Specs {
+				typeSpec, ok := s.(*ast.TypeSpec)
+				if !ok {
+					continue
+				}
+
+				doc := typeSpec.Doc
+				if doc == nil && len(gen.Specs) == 1 {
+					doc = gen.Doc
+				}
+				if text := commentText(doc); text != "" {
+					docs[typeSpec.Name.Name] = text
+				}
+
+				structType, ok := typeSpec.Type.(*ast.StructType)
+				if !ok {
+					continue
+				}
+
+				for _, field := range structType.Fields.List {
+					text := commentText(field.Doc)
+					if text == "" {
+						text = commentText(field.Comment)
+					}
+
+					for _, fieldName := range field.Names {
+						if text != "" {
+							docs[typeSpec.Name.Name+"."+fieldName.Name] = text
+						}
+					}
+				}
+			}
+		}
+	}
+
+	return docs, nil
+}
+
+// commentText is the text of a comment, on a single line.
+func commentText(group *ast.CommentGroup) string {
+	return strings.Join(strings.Fields(group.Text()), " ")
+}
@@ -0,0 +1,133 @@
+// SPDX-FileCopyrightText: Copyright 2015-2025 go-swagger maintainers
+// SPDX-License-Identifier: Apache-2.0
+
+package generator
+
+import (
+	"bytes"
+	"encoding/json"
+	"flag"
+	"os"
+	"runtime"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/go-openapi/testify/v2/assert"
+	"github.com/go-openapi/testify/v2/require"
+)
+
+// dataSchemaFile is the published schema of the data of templates.
+const dataSchemaFile = "../docs/reference/templates/data-model.schema.json"
+
+var updateDataSchema bool
+
+func init() {
+	flag.BoolVar(&updateDataSchema, "update-data-schema", false, "write the schema of the data of templates to the docs")
+}
+
+func TestDataSchema(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("the options of the generator differ on windows")
+	}
+
+	schema, err := marshalDataSchema(".")
+	require.NoError(t, err)
+
+	if updateDataSchema {
+		require.NoError(t, os.WriteFile(dataSchemaFile, schema, readableFile))
+	}
+
+	t.Run("should be published", func(t *testing.T) {
+		published, err := os.ReadFile(dataSchemaFile)
+		require.NoError(t, err)
+		assert.EqualT(t, string(published), string(schema),
+			"the published schema is outdated: run go test ./generator -run TestDataSchema -update-data-schema",
+		)
+	})
+
+	t.Run("should document the data of templates", func(t *testing.T) {
+		var doc map[string]any
+		require.NoError(t, json.Unmarshal(schema, &doc))
+		defs, ok := doc["$defs"].(map[string]any)
+		require.TrueT(t, ok)
+
+		gen, ok := defs["GenSchema"].(map[string]any)
+		require.TrueT(t, ok)
+		assert.StringContainsT(t, gen["description"].(string), "GenSchema contains all the information")
+
+		properties, ok := gen["properties"].(map[string]any)
+		require.TrueT(t, ok)
+		for _, name := range []string{"Name", "GoType", "IsNullable", "Properties", "maximum", "Required"} {
+			assert.Contains(t, properties, name) // promoted from embedded structs, and named by json tags
+		}
+		assert.NotContains(t, properties, "GenOpts")
+		assert.Equal(t, map[string]any{"$ref": "#/$defs/GenSchema"}, properties["Items"])
+
+		op, ok := defs["GenOperation"].(map[string]any)
+		require.TrueT(t, ok)
+		responses := op["properties"].(map[string]any)["Responses"]
+		assert.Equal(t, map[string]any{"type": "object", "additionalProperties": map[string]any{"$ref": "#/$defs/GenResponse"}}, responses)
+	})
+
+	t.Run("should declare all the dumped data", func(t *testing.T) {
+		gen, err := testAppGenerator(t, "../fixtures/codegen/todolist.models.yml", "todo")
+		require.NoError(t, err)
+		app, err := gen.makeCodegenApp()
+		require.NoError(t, err)
+
+		var buf bytes.Buffer
+		require.NoError(t, gen.GenOpts.dumpData(&buf, app))
+
+		var data, doc map[string]any
+		require.NoError(t, json.Unmarshal(buf.Bytes(), &data))
+		require.NoError(t, json.Unmarshal(schema, &doc))
+
+		assert.Empty(t, undeclared(doc, map[string]any{"$ref": "#/$defs/GenApp"}, data, "$"))
+	})
+}
+
+// undeclared lists the members of the data which are not declared by the schema.
+func undeclared(doc, schema map[string]any, data any, path string) []string {
+	if ref, ok := schema["$ref"].(string); ok {
+		name, found := strings.CutPrefix(ref, "#/$defs/")
+		if !found {
+			return nil // defined by the swagger schema
+		}
+
+		return undeclared(doc, doc["$defs"].(map[string]any)[name].(map[string]any), data, path)
+	}
+
+	if _, typed := schema["type"]; !typed {
+		return nil // any value
+	}
+
+	var missing []string
+	switch value := data.(type) {
+	case map[string]any:
+		properties, _ := schema["properties"].(map[string]any)
+		additional, _ := schema["additionalProperties"].(map[string]any)
+		for key, member := range value {
+			property, ok := properties[key].(map[string]any)
+			if !ok {
+				property = additional
+			}
+
+			if property == nil {
+				missing = append(missing, path+"."+key)
+
+				continue
+			}
+			missing = append(missing, undeclared(doc, property, member, path+"."+key)...)
+		}
+	case []any:
+		items, _ := schema["items"].(map[string]any)
+		for i, item := range value {
+			if items != nil {
+				missing = append(missing, undeclared(doc, items, item, path+"["+strconv.Itoa(i)+"]")...)
+			}
+		}
+	}
+
+	return missing
+}
@@ -0,0 +1,122 @@
+// SPDX-FileCopyrightText: Copyright 2015-2025 go-swagger maintainers
+// SPDX-License-Identifier: Apache-2.0
+
+package generator
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/go-openapi/swag/jsonutils"
+	"go.yaml.in/yaml/v3"
+)
+
+// Formats of the data dumped with [GenOptsCommon.DumpData].
+const (
+	DumpFormatJSON = "json"
+	DumpFormatYAML = "yaml"
+)
+
+// dumpData writes the data of templates instead of generating files,
+// reduced to the part picked by the selector of the options, and in their format.
+//
+// The selector is either a path starting with "$", in a subset of JSONPath, or the name of a model or of an operation.
+// The data is described by the JSON schema published in docs/reference/templates/data-model.schema.json.
+func (g *GenOpts) dumpData(w io.Writer, data any) error {
+	var selector, format string
+	if g != nil {
+		selector, format = g.DumpSelector, g.DumpFormat
+	}
+
+	var dynamic any
+	if err := jsonutils.FromDynamicJSON(data, &dynamic); err != nil {
+		return err
+	}
+
+	selected, err := selectData(dynamic, selector)
+	if err != nil {
+		return err
+	}
+
+	switch format {
+	case "", DumpFormatJSON:
+		bb, err := json.MarshalIndent(selected, "", "  ")
+		if err != nil {
+			return err
+		}
+		_, _ = fmt.Fprintln(w, string(bb))
+
+		return nil
+	case DumpFormatYAML:
+		enc := yaml.NewEncoder(w)
+		enc.SetIndent(2) //nolint:mnd // like the json output
+		if err := enc.Encode(selected); err != nil {
+			return err
+		}
+
+		return enc.Close()
+	default:
+		return fmt.Errorf("unsupported format for dumped data: %q, expected %s or %s", format, DumpFormatJSON, DumpFormatYAML)
+	}
+}
+
+// selectData picks the part of the data designated by a selector. Several matches are returned as a list.
+func selectData(data any, selector string) (any, error) {
+	if selector == "" || selector == "$" {
+		return data, nil
+	}
+
+	var selected []any
+	if strings.HasPrefix(selector, "$") {
+		path, err := compileJSONPath(selector)
+		if err != nil {
+			return nil, err
+		}
+		selected = path.eval(data)
+	} else {
+		selected = selectNamed(data, selector)
+	}
+
+	switch len(selected) {
+	case 0:
+		return nil, fmt.Errorf("no data matches the selector %q", selector)
+	case 1:
+		return selected[0], nil
+	default:
+		return selected, nil
+	}
+}
+
+// selectNamed finds the models and the operations with some name in the data of a template:
+// the data itself, the models and operations of an application, or the extra schemas of a model.
+//
+// The name is matched against the go name and the name in the spec.
+func selectNamed(data any, name string) []any {
+	named := func(node any) bool {
+		obj, ok := node.(map[string]any)
+
+		return ok && (obj["Name"] == name || obj["OriginalName"] == name)
+	}
+
+	if named(data) {
+		return []any{data}
+	}
+
+	obj, ok := data.(map[string]any)
+	if !ok {
+		return nil
+	}
+
+	var selected []any
+	for _, member := range []string{"Models", "Operations", "ExtraSchemas"} {
+		for _, node := range children(obj[member]) {
+			if named(node) {
+				selected = append(selected, node)
+			}
+		}
+	}
+
+	return selected
+}
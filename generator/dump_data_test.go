@@ -0,0 +1,117 @@
+// SPDX-FileCopyrightText: Copyright 2015-2025 go-swagger maintainers
+// SPDX-License-Identifier: Apache-2.0
+
+package generator
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+
+	"github.com/go-openapi/testify/v2/assert"
+	"github.com/go-openapi/testify/v2/require"
+)
+
+func TestSelectData(t *testing.T) {
+	var data any
+	require.NoError(t, json.Unmarshal([]byte(`{
+		"Name": "todo",
+		"Models": [
+			{"Name": "Item", "OriginalName": "item", "Properties": [{"Name": "ID", "GoType": "int64"}, {"Name": "Description", "GoType": "string"}]},
+			{"Name": "Error", "OriginalName": "error", "Properties": [{"Name": "Code", "GoType": "int64"}]}
+		],
+		"Operations": [
+			{"Name": "addOne", "Method": "POST", "Path": "/"},
+			{"Name": "findTodos", "Method": "GET", "Path": "/"}
+		]
+	}`), &data))
+
+	for _, tc := range []struct {
+		selector string
+		expected string
+	}{
+		{"", `{"Name": "todo"}`},
+		{"$", `{"Name": "todo"}`},
+		{"Item", `{"Name": "Item"}`},
+		{"error", `{"Name": "Error"}`},
+		{"findTodos", `{"Name": "findTodos"}`},
+		{"$.Models[1].Name", `"Error"`},
+		{"$.Operations[0].Method", `"POST"`},
+		{"$.Models[*].Name", `["Item", "Error"]`},
+		{"$.Models[*].Properties[*].GoType", `["int64", "string", "int64"]`},
+		{"$.Models.*.OriginalName", `["item", "error"]`},
+		{"$.Models[0].*", `["Item", "item", [{"Name": "ID", "GoType": "int64"}, {"Name": "Description", "GoType": "string"}]]`},
+	} {
+		t.Run(tc.selector, func(t *testing.T) {
+			selected, err := selectData(data, tc.selector)
+			require.NoError(t, err)
+
+			var expected any
+			require.NoError(t, json.Unmarshal([]byte(tc.expected), &expected))
+			if obj, ok := expected.(map[string]any); ok {
+				// objects are compared by name
+				selectedObj, isObj := selected.(map[string]any)
+				require.TrueT(t, isObj)
+				assert.Equal(t, obj["Name"], selectedObj["Name"])
+
+				return
+			}
+			assert.Equal(t, expected, selected)
+		})
+	}
+
+	t.Run("should report a selector matching nothing", func(t *testing.T) {
+		_, err := selectData(data, "Pet")
+		require.ErrorContains(t, err, `no data matches the selector "Pet"`)
+
+		_, err = selectData(data, "$.Models[5]")
+		require.Error(t, err)
+	})
+
+	t.Run("should report an invalid JSONPath", func(t *testing.T) {
+		for _, selector := range []string{"$.", "$[1", "$.Models[-1]", "$.Models[?(@.Name == 'x')]", "$Models", "$..Name", "$['Models']"} {
+			_, err := selectData(data, selector)
+			require.ErrorIs(t, err, errInvalidPath, selector)
+		}
+	})
+}
+
+func TestDumpData(t *testing.T) {
+	data := struct {
+		Name   string
+		Models []GenDefinition
+	}{
+		Name: "todo",
+		Models: []GenDefinition{
+			{GenSchema: GenSchema{Name: "Item", OriginalName: "item"}},
+		},
+	}
+
+	t.Run("should dump the selected data as YAML", func(t *testing.T) {
+		opts := testGenOpts()
+		opts.DumpSelector = "item"
+		opts.DumpFormat = DumpFormatYAML
+
+		var buf bytes.Buffer
+		require.NoError(t, opts.dumpData(&buf, data))
+		assert.StringContainsT(t, buf.String(), "Name: Item\n")
+		assert.StringContainsT(t, buf.String(), "OriginalName: item\n")
+		assert.StringNotContainsT(t, buf.String(), "todo")
+	})
+
+	t.Run("should dump the data as JSON", func(t *testing.T) {
+		opts := testGenOpts()
+
+		var buf bytes.Buffer
+		require.NoError(t, opts.dumpData(&buf, data))
+		assert.StringContainsT(t, buf.String(), `"Name": "todo"`)
+	})
+
+	t.Run("should reject an unknown format", func(t *testing.T) {
+		opts := testGenOpts()
+		opts.DumpFormat = "toml"
+
+		var buf bytes.Buffer
+		require.ErrorContains(t, opts.dumpData(&buf, data), "unsupported format")
+	})
+}
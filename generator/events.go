@@ -0,0 +1,94 @@
+// SPDX-FileCopyrightText: Copyright 2015-2025 go-swagger maintainers
+// SPDX-License-Identifier: Apache-2.0
+
+package generator
+
+import (
+	"time"
+)
+
+// EventKind identifies a step in the progress of a generation.
+type EventKind string
+
+const (
+	// EventSpecLoaded is emitted when the spec document has been loaded.
+	EventSpecLoaded EventKind = "spec_loaded"
+	// EventSpecValidated is emitted when the spec document has been validated.
+	EventSpecValidated EventKind = "spec_validated"
+	// EventSpecFlattened is emitted when the spec document has been flattened or expanded.
+	EventSpecFlattened EventKind = "spec_flattened"
+	// EventItemsPlanned is emitted before rendering a batch of models, operations or operation groups.
+	// Total is the number of items in the batch.
+	EventItemsPlanned EventKind = "items_planned"
+	// EventModelRendered is emitted when all files for a model have been rendered.
+	EventModelRendered EventKind = "model_rendered"
+	// EventOperationRendered is emitted when all files for an operation have been rendered.
+	EventOperationRendered EventKind = "operation_rendered"
+	// EventOperationGroupRendered is emitted when all files for an operation group have been rendered.
+	EventOperationGroupRendered EventKind = "operation_group_rendered"
+	// EventFileWritten is emitted when a generated file has been written.
+	EventFileWritten EventKind = "file_written"
+	// EventFileSkipped is emitted when a generated file is not written. Reason tells why.
+	EventFileSkipped EventKind = "file_skipped"
+	// EventGenerationDone is emitted when the generation of a server, a client or models is complete.
+	EventGenerationDone EventKind = "generation_done"
+	// EventHookRun is emitted when a pre-generation or post-generation hook has run. Stage tells which.
+	EventHookRun EventKind = "hook_run"
+	// EventCodeVerified is emitted when the generated packages have been type-checked, with the Verify option.
+	// Total is the number of packages.
+	EventCodeVerified EventKind = "code_verified"
+)
+
+// Reasons for skipping a generated file.
+const (
+	SkipReasonExists    = "exists"     // the file exists and the template is configured with skip_exists
+	SkipReasonUpToDate  = "up_to_date" // the file is unchanged since the previous generation
+	SkipReasonCondition = "condition"  // the condition of the template is not met, see [TemplateOpts.When]
+	SkipReasonUnchanged = "unchanged"  // the file exists with the same content
+)
+
+// Event reports the progress of a generation.
+type Event struct {
+	Kind      EventKind     `json:"kind"`
+	Time      time.Time     `json:"time"`
+	Name      string        `json:"name,omitempty"`      // name of the model, operation, operation group or hook
+	Path      string        `json:"path,omitempty"`      // location of the spec or of the generated file
+	Template  string        `json:"template,omitempty"`  // name of the template used to generate a file
+	Element   string        `json:"element,omitempty"`   // element of the spec a file is generated from, e.g. "#/definitions/Pet"
+	Reason    string        `json:"reason,omitempty"`    // reason for skipping a file
+	Total     int           `json:"total,omitempty"`     // number of items planned in a batch
+	Duration  time.Duration `json:"duration,omitempty"`  // elapsed time for this step, in nanoseconds
+	Explain   *Explanation  `json:"explain,omitempty"`   // how a written file was produced, with the Explain option
+	Stage     string        `json:"stage,omitempty"`     // stage of a hook: pre or post
+	Merged    bool          `json:"merged,omitempty"`    // the edits of a written file have been merged with its new content
+	Conflicts int           `json:"conflicts,omitempty"` // number of conflicts when merging the edits of a written file
+}
+
+// EventHandler receives the progress events of a generation.
+//
+// Events are delivered sequentially, even when files are rendered concurrently.
+type EventHandler func(Event)
+
+// emit delivers a progress event to the handler configured in the options, if any.
+func (g *GenOpts) emit(e Event) {
+	if g.Events == nil {
+		return
+	}
+
+	if e.Time.IsZero() {
+		e.Time = time.Now()
+	}
+
+	if g.eventsMx != nil {
+		g.eventsMx.Lock()
+		defer g.eventsMx.Unlock()
+	}
+
+	g.Events(e)
+}
+
+// emitSince delivers a progress event for a step started at some time.
+func (g *GenOpts) emitSince(start time.Time, e Event) {
+	e.Duration = time.Since(start)
+	g.emit(e)
+}
@@ -0,0 +1,106 @@
+// SPDX-FileCopyrightText: Copyright 2015-2025 go-swagger maintainers
+// SPDX-License-Identifier: Apache-2.0
+
+package generator
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/go-openapi/testify/v2/assert"
+	"github.com/go-openapi/testify/v2/require"
+)
+
+func TestGenerate_Events(t *testing.T) {
+	defer discardOutput()()
+
+	target := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(target, "go.mod"), []byte("module events\n"), readableFile))
+
+	generate := func() []Event {
+		var events []Event
+		opts := testGenOpts()
+		opts.Spec = "../fixtures/bugs/1042/fixture-1042.yaml"
+		opts.Target = target
+		opts.ValidateSpec = true
+		opts.Events = func(e Event) {
+			events = append(events, e)
+		}
+		require.NoError(t, GenerateModels(nil, opts))
+
+		return events
+	}
+
+	kinds := func(events []Event, kind EventKind) []Event {
+		var selected []Event
+		for _, e := range events {
+			if e.Kind == kind {
+				selected = append(selected, e)
+			}
+		}
+
+		return selected
+	}
+
+	modelFile := filepath.Join(target, defaultModelsTarget, "a.go")
+
+	t.Run("should report the progress of a generation", func(t *testing.T) {
+		events := generate()
+		require.NotEmpty(t, events)
+
+		assert.EqualT(t, EventSpecLoaded, events[0].Kind)
+		assert.Len(t, kinds(events, EventSpecValidated), 1)
+		assert.Len(t, kinds(events, EventSpecFlattened), 1)
+		assert.EqualT(t, EventGenerationDone, events[len(events)-1].Kind)
+
+		planned := kinds(events, EventItemsPlanned)
+		require.Len(t, planned, 1)
+		rendered := kinds(events, EventModelRendered)
+		assert.Len(t, rendered, planned[0].Total)
+
+		var found bool
+		for _, e := range kinds(events, EventFileWritten) {
+			if e.Path == modelFile {
+				found = true
+				assert.EqualT(t, "definition", e.Template)
+			}
+		}
+		assert.TrueT(t, found)
+
+		for _, e := range events {
+			assert.False(t, e.Time.IsZero())
+		}
+	})
+
+	t.Run("should report files skipped when up to date", func(t *testing.T) {
+		events := generate()
+		assert.Empty(t, kinds(events, EventFileWritten))
+
+		var found bool
+		for _, e := range kinds(events, EventFileSkipped) {
+			if e.Path == modelFile {
+				found = true
+				assert.EqualT(t, SkipReasonUpToDate, e.Reason)
+				assert.EqualT(t, "definition", e.Template)
+			}
+		}
+		assert.TrueT(t, found)
+	})
+
+	t.Run("should report files skipped when unchanged", func(t *testing.T) {
+		require.NoError(t, os.Remove(filepath.Join(target, cacheDir, cacheFile)))
+
+		events := generate()
+		assert.Empty(t, kinds(events, EventFileWritten))
+
+		var found bool
+		for _, e := range kinds(events, EventFileSkipped) {
+			if e.Path == modelFile {
+				found = true
+				assert.EqualT(t, SkipReasonUnchanged, e.Reason)
+			}
+		}
+		assert.TrueT(t, found)
+	})
+}
@@ -0,0 +1,76 @@
+// SPDX-FileCopyrightText: Copyright 2015-2025 go-swagger maintainers
+// SPDX-License-Identifier: Apache-2.0
+
+package generator
+
+import (
+	"fmt"
+
+	templatesrepo "github.com/go-swagger/go-swagger/generator/internal/templates-repo"
+)
+
+// Origins of templates.
+const (
+	OriginEmbedded    = templatesrepo.OriginEmbedded    // default templates, embedded in the generator
+	OriginContrib     = templatesrepo.OriginContrib     // contributed templates, selected with the Template option
+	OriginTemplateDir = templatesrepo.OriginTemplateDir // templates loaded from the TemplateDir
+	OriginPack        = templatesrepo.OriginPack        // templates loaded from the directory of a template pack
+	OriginAdded       = templatesrepo.OriginAdded       // templates added programmatically
+	OriginDisk        = "disk"                          // templates loaded from a file named by the layout, outside of the repository
+)
+
+// TemplateSource tells where a template is defined.
+type TemplateSource struct {
+	Name      string `json:"name"`
+	File      string `json:"file,omitempty"`      // asset name or path on disk
+	Origin    string `json:"origin,omitempty"`    // one of the Origin* constants. Empty for a template which is not defined
+	Protected bool   `json:"protected,omitempty"` // the template may only be overridden with AllowTemplateOverride
+}
+
+// Explanation tells how a generated file was produced.
+//
+// Explanations are reported with the events for written files and for files skipped because they exist
+// or are unchanged, when the Explain option is set.
+type Explanation struct {
+	Template string           `json:"template"`           // name of the template in the layout
+	Source   string           `json:"source"`             // source of the template in the layout, e.g. "asset:serverOperation"
+	Resolved TemplateSource   `json:"resolved"`           // the template actually executed
+	Includes []TemplateSource `json:"includes,omitempty"` // templates included transitively, sorted by name
+	DataType string           `json:"data_type"`          // go type of the data the template is executed with
+}
+
+// explain tells how a file is produced by a template of the layout, resolved from some source.
+func (g *GenOpts) explain(t *TemplateOpts, resolved TemplateSource, data any) (*Explanation, error) {
+	explanation := &Explanation{
+		Template: t.Name,
+		Source:   t.Source,
+		Resolved: resolved,
+		DataType: fmt.Sprintf("%T", data),
+	}
+
+	if resolved.Origin == OriginDisk {
+		// templates loaded from disk do not include templates from the repository
+		return explanation, nil
+	}
+
+	source, includes, err := g.templates.Describe(resolved.Name)
+	if err != nil {
+		return nil, err
+	}
+
+	explanation.Resolved = templateSource(source)
+	for _, include := range includes {
+		explanation.Includes = append(explanation.Includes, templateSource(include))
+	}
+
+	return explanation, nil
+}
+
+func templateSource(source templatesrepo.Source) TemplateSource {
+	return TemplateSource{
+		Name:      source.Name,
+		File:      source.File,
+		Origin:    source.Origin,
+		Protected: source.Protected,
+	}
+}
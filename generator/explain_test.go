@@ -0,0 +1,121 @@
+// SPDX-FileCopyrightText: Copyright 2015-2025 go-swagger maintainers
+// SPDX-License-Identifier: Apache-2.0
+
+package generator
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/go-openapi/testify/v2/assert"
+	"github.com/go-openapi/testify/v2/require"
+)
+
+func TestExplain(t *testing.T) {
+	defer discardOutput()()
+
+	t.Run("should explain the templates used to write a file", func(t *testing.T) {
+		opts := testGenOpts()
+		opts.Target = t.TempDir()
+		opts.Explain = true
+		require.NoError(t, os.WriteFile(filepath.Join(opts.Target, "go.mod"), []byte("module explained\n"), readableFile))
+		require.NoError(t, opts.templates.AddFile("explainedInner", `{{ define "explainedInner" }}// {{ .Name }}{{ end }}`))
+		require.NoError(t, opts.templates.AddFile("explained", `package {{ .Name }}{{ template "explainedInner" . }}`))
+
+		var written []Event
+		opts.Events = func(e Event) {
+			if e.Kind == EventFileWritten {
+				written = append(written, e)
+			}
+		}
+
+		tplOpts := TemplateOpts{
+			Name:     "explained",
+			Source:   "asset:explained",
+			Target:   "{{ .Target }}",
+			FileName: "{{ .Name }}.go",
+		}
+		require.NoError(t, opts.write(&tplOpts, &GenOperation{Name: "get"}))
+
+		require.Len(t, written, 1)
+		explanation := written[0].Explain
+		require.NotNil(t, explanation)
+		assert.EqualT(t, "explained", explanation.Template)
+		assert.EqualT(t, "asset:explained", explanation.Source)
+		assert.EqualT(t, "explained", explanation.Resolved.Name)
+		assert.EqualT(t, OriginAdded, explanation.Resolved.Origin)
+		assert.EqualT(t, "*generator.GenOperation", explanation.DataType)
+		require.Len(t, explanation.Includes, 1)
+		assert.EqualT(t, "explainedInner", explanation.Includes[0].Name)
+		assert.EqualT(t, OriginAdded, explanation.Includes[0].Origin)
+	})
+
+	t.Run("should explain a template loaded from disk", func(t *testing.T) {
+		opts := testGenOpts()
+		dir := t.TempDir()
+		source := filepath.Join(dir, "explained.gotmpl")
+		require.NoError(t, os.WriteFile(source, []byte("package {{ .Name }}"), readableFile))
+
+		tplOpts := &TemplateOpts{Name: "explained", Source: source}
+		_, resolved, err := opts.resolveTemplate(tplOpts)
+		require.NoError(t, err)
+
+		explanation, err := opts.explain(tplOpts, resolved, &GenOperation{Name: "get"})
+		require.NoError(t, err)
+		assert.EqualT(t, OriginDisk, explanation.Resolved.Origin)
+		assert.EqualT(t, source, explanation.Resolved.Name)
+		assert.Empty(t, explanation.Includes)
+	})
+
+	t.Run("should explain skipped files", func(t *testing.T) {
+		opts := testGenOpts()
+		opts.Target = t.TempDir()
+		opts.Explain = true
+		require.NoError(t, os.WriteFile(filepath.Join(opts.Target, "go.mod"), []byte("module explained\n"), readableFile))
+		require.NoError(t, opts.templates.AddFile("explained", `package {{ .Name }}`))
+
+		var skipped []Event
+		opts.Events = func(e Event) {
+			if e.Kind == EventFileSkipped {
+				skipped = append(skipped, e)
+			}
+		}
+
+		generated := TemplateOpts{Name: "explained", Source: "asset:explained", Target: "{{ .Target }}", FileName: "{{ .Name }}.go"}
+		once := TemplateOpts{Name: "once", Source: "asset:explained", Target: "{{ .Target }}", FileName: "{{ .Name }}_once.go", SkipExists: true}
+		for range 2 {
+			require.NoError(t, opts.write(&generated, &GenOperation{Name: "get"}))
+			require.NoError(t, opts.write(&once, &GenOperation{Name: "get"}))
+		}
+
+		require.Len(t, skipped, 2)
+		assert.EqualT(t, SkipReasonUnchanged, skipped[0].Reason)
+		require.NotNil(t, skipped[0].Explain)
+		assert.EqualT(t, "explained", skipped[0].Explain.Template)
+		assert.EqualT(t, SkipReasonExists, skipped[1].Reason)
+		require.NotNil(t, skipped[1].Explain)
+		assert.EqualT(t, "once", skipped[1].Explain.Template)
+		assert.EqualT(t, "explained", skipped[1].Explain.Resolved.Name)
+	})
+
+	t.Run("should not explain files when the option is not set", func(t *testing.T) {
+		opts := testGenOpts()
+		opts.Target = t.TempDir()
+		require.NoError(t, os.WriteFile(filepath.Join(opts.Target, "go.mod"), []byte("module explained\n"), readableFile))
+		require.NoError(t, opts.templates.AddFile("explained", `package {{ .Name }}`))
+
+		var written []Event
+		opts.Events = func(e Event) {
+			if e.Kind == EventFileWritten {
+				written = append(written, e)
+			}
+		}
+
+		tplOpts := TemplateOpts{Name: "explained", Source: "asset:explained", Target: "{{ .Target }}", FileName: "{{ .Name }}.go"}
+		require.NoError(t, opts.write(&tplOpts, &GenOperation{Name: "get"}))
+
+		require.Len(t, written, 1)
+		assert.Nil(t, written[0].Explain)
+	})
+}
@@ -0,0 +1,228 @@
+// SPDX-FileCopyrightText: Copyright 2015-2025 go-swagger maintainers
+// SPDX-License-Identifier: Apache-2.0
+
+package generator
+
+import (
+	"archive/zip"
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// WriteFS is the file system where the generator writes the generated files.
+//
+// Names are file system paths, as resolved from the generation target and the layout of the templates.
+//
+// The default is the local file system. See [MemFS] and [ZipFS] for alternatives.
+type WriteFS interface {
+	Stat(name string) (fs.FileInfo, error)
+	ReadFile(name string) ([]byte, error)
+	MkdirAll(name string, perm fs.FileMode) error
+	WriteFile(name string, data []byte, perm fs.FileMode) error
+}
+
+// osFS writes generated files on the local file system.
+type osFS struct{}
+
+func (osFS) Stat(name string) (fs.FileInfo, error)        { return os.Stat(name) }
+func (osFS) ReadFile(name string) ([]byte, error)         { return os.ReadFile(name) } //nolint:gosec // generated files
+func (osFS) MkdirAll(name string, perm fs.FileMode) error { return os.MkdirAll(name, perm) }
+func (osFS) WriteFile(name string, data []byte, perm fs.FileMode) error {
+	return os.WriteFile(name, data, perm) //nolint:gosec // generated files
+}
+
+// MemFS is an in-memory [WriteFS], safe for concurrent use.
+//
+// It is useful to inspect generated files without writing them on disk, e.g. in tests.
+type MemFS struct {
+	mx    sync.RWMutex
+	files map[string]memFile
+	dirs  map[string]struct{}
+}
+
+type memFile struct {
+	data    []byte
+	mode    fs.FileMode
+	modTime time.Time
+}
+
+// NewMemFS builds an empty in-memory file system.
+func NewMemFS() *MemFS {
+	return &MemFS{
+		files: make(map[string]memFile),
+		dirs:  make(map[string]struct{}),
+	}
+}
+
+// Stat returns info about a file or directory.
+func (m *MemFS) Stat(name string) (fs.FileInfo, error) {
+	name = filepath.Clean(name)
+	m.mx.RLock()
+	defer m.mx.RUnlock()
+
+	if f, ok := m.files[name]; ok {
+		return memFileInfo{name: filepath.Base(name), size: int64(len(f.data)), mode: f.mode, modTime: f.modTime}, nil
+	}
+
+	if _, ok := m.dirs[name]; ok {
+		return memFileInfo{name: filepath.Base(name), mode: fs.ModeDir | readAllDir}, nil
+	}
+
+	return nil, &fs.PathError{Op: "stat", Path: name, Err: fs.ErrNotExist}
+}
+
+// ReadFile returns the content of a file.
+func (m *MemFS) ReadFile(name string) ([]byte, error) {
+	name = filepath.Clean(name)
+	m.mx.RLock()
+	defer m.mx.RUnlock()
+
+	f, ok := m.files[name]
+	if !ok {
+		return nil, &fs.PathError{Op: "read", Path: name, Err: fs.ErrNotExist}
+	}
+
+	return append([]byte(nil), f.data...), nil
+}
+
+// MkdirAll creates a directory and all its parents.
+func (m *MemFS) MkdirAll(name string, _ fs.FileMode) error {
+	m.mx.Lock()
+	defer m.mx.Unlock()
+
+	for dir := filepath.Clean(name); ; dir = filepath.Dir(dir) {
+		if _, isFile := m.files[dir]; isFile {
+			return &fs.PathError{Op: "mkdir", Path: dir, Err: errors.New("not a directory")}
+		}
+		m.dirs[dir] = struct{}{}
+
+		if dir == filepath.Dir(dir) {
+			return nil
+		}
+	}
+}
+
+// WriteFile creates or replaces a file.
+func (m *MemFS) WriteFile(name string, data []byte, perm fs.FileMode) error {
+	name = filepath.Clean(name)
+	m.mx.Lock()
+	defer m.mx.Unlock()
+
+	if _, isDir := m.dirs[name]; isDir {
+		return &fs.PathError{Op: "write", Path: name, Err: errors.New("is a directory")}
+	}
+
+	m.files[name] = memFile{
+		data:    append([]byte(nil), data...),
+		mode:    perm,
+		modTime: time.Now(),
+	}
+
+	return nil
+}
+
+// Files returns the sorted names of all the files written so far.
+func (m *MemFS) Files() []string {
+	m.mx.RLock()
+	defer m.mx.RUnlock()
+
+	names := make([]string, 0, len(m.files))
+	for name := range m.files {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	return names
+}
+
+type memFileInfo struct {
+	name    string
+	size    int64
+	mode    fs.FileMode
+	modTime time.Time
+}
+
+func (i memFileInfo) Name() string       { return i.name }
+func (i memFileInfo) Size() int64        { return i.size }
+func (i memFileInfo) Mode() fs.FileMode  { return i.mode }
+func (i memFileInfo) ModTime() time.Time { return i.modTime }
+func (i memFileInfo) IsDir() bool        { return i.mode.IsDir() }
+func (i memFileInfo) Sys() any           { return nil }
+
+// ZipFS is a write-only [WriteFS] that adds generated files to a zip archive.
+//
+// Files are stored in the archive with their path relative to some root directory,
+// usually the generation target.
+//
+// A zip archive cannot be read back: existing files are never found and incremental generation
+// does not apply.
+type ZipFS struct {
+	mx   sync.Mutex
+	w    *zip.Writer
+	root string
+}
+
+// NewZipFS builds a [ZipFS] writing to a zip archive, with files relative to the root directory.
+//
+// The caller is responsible for closing the zip writer when the generation is complete.
+func NewZipFS(w *zip.Writer, root string) *ZipFS {
+	abs, err := filepath.Abs(root)
+	if err != nil {
+		abs = root
+	}
+
+	return &ZipFS{w: w, root: abs}
+}
+
+// Stat never finds any file.
+func (z *ZipFS) Stat(name string) (fs.FileInfo, error) {
+	return nil, &fs.PathError{Op: "stat", Path: name, Err: fs.ErrNotExist}
+}
+
+// ReadFile never finds any file.
+func (z *ZipFS) ReadFile(name string) ([]byte, error) {
+	return nil, &fs.PathError{Op: "read", Path: name, Err: fs.ErrNotExist}
+}
+
+// MkdirAll is a no-op: directories are implied by the path of files in the archive.
+func (z *ZipFS) MkdirAll(string, fs.FileMode) error {
+	return nil
+}
+
+// WriteFile adds a file to the archive.
+func (z *ZipFS) WriteFile(name string, data []byte, perm fs.FileMode) error {
+	abs, err := filepath.Abs(name)
+	if err != nil {
+		return err
+	}
+
+	rel, err := filepath.Rel(z.root, abs)
+	if err != nil || strings.HasPrefix(rel, "..") {
+		return &fs.PathError{Op: "write", Path: name, Err: errors.New("file is outside of the root of the archive")}
+	}
+
+	header := &zip.FileHeader{
+		Name:     filepath.ToSlash(rel),
+		Method:   zip.Deflate,
+		Modified: time.Now(),
+	}
+	header.SetMode(perm)
+
+	z.mx.Lock()
+	defer z.mx.Unlock()
+
+	f, err := z.w.CreateHeader(header)
+	if err != nil {
+		return err
+	}
+
+	_, err = f.Write(data)
+
+	return err
+}
@@ -0,0 +1,353 @@
+// SPDX-FileCopyrightText: Copyright 2015-2025 go-swagger maintainers
+// SPDX-License-Identifier: Apache-2.0
+
+package generator
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"go/token"
+	"io"
+	"os"
+	"os/exec"
+	"slices"
+	"strings"
+	"sync"
+	"syscall"
+	"time"
+)
+
+// FuncPluginOpts is an external program providing template functions, declared in the configuration file.
+//
+// Unlike a go plugin (see TemplatePlugin), a function plugin is any executable: it works with static builds,
+// with any version of the go toolchain, and on every platform.
+//
+// The program is started in the current directory when the templates are loaded, and stopped once the generation
+// is complete, or killed when the context of the generation is canceled. It speaks JSON-RPC 2.0 on its standard input and output, with one message per line:
+//
+//   - the method "functions" lists the functions of the program, e.g. {"functions": ["slug"], "version": "1.0.0"}
+//   - the method "call" calls a function with its arguments, e.g. {"name": "slug", "args": ["Hello world"]},
+//     and returns its result, as any JSON value.
+//
+// The functions of the program are called in templates through the namespace of the plugin,
+// e.g. {{ acme "slug" .Name }}. The standard error of the program is reported on the standard error.
+type FuncPluginOpts struct {
+	Namespace string   `mapstructure:"namespace"` // name of the template function calling the functions of the program
+	Command   []string `mapstructure:"command"`   // the program to run and its arguments
+}
+
+// funcPluginStopTimeout is how long a function plugin may take to exit once its input is closed.
+const funcPluginStopTimeout = 5 * time.Second
+
+// JSON-RPC methods of function plugins.
+const (
+	funcPluginMethodFunctions = "functions"
+	funcPluginMethodCall      = "call"
+)
+
+// errFuncPluginExited reports a request to a function plugin which is not running anymore.
+var errFuncPluginExited = errors.New("the plugin exited")
+
+// funcPluginSet holds the running function plugins, shared by the copies of the options of a generation.
+type funcPluginSet struct {
+	mx          sync.Mutex
+	running     []*funcPlugin
+	generations int // generations in progress, nested like GenerateServer run by RunWithHooks
+}
+
+// funcPlugin is a running function plugin.
+type funcPlugin struct {
+	FuncPluginOpts
+
+	cmd       *exec.Cmd
+	stdin     io.WriteCloser
+	stdout    *bufio.Reader
+	mx        sync.Mutex // calls are sent one at a time, templates being rendered concurrently
+	lastID    int
+	functions []string
+	version   string
+}
+
+type rpcRequest struct {
+	JSONRPC string `json:"jsonrpc"`
+	ID      int    `json:"id"`
+	Method  string `json:"method"`
+	Params  any    `json:"params,omitempty"`
+}
+
+type rpcResponse struct {
+	JSONRPC string          `json:"jsonrpc"`
+	ID      int             `json:"id"`
+	Result  json.RawMessage `json:"result"`
+	Error   *rpcError       `json:"error"`
+}
+
+type rpcError struct {
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+}
+
+func (e *rpcError) Error() string {
+	return fmt.Sprintf("%s (code %d)", e.Message, e.Code)
+}
+
+// funcPluginManifest is the result of the "functions" method.
+type funcPluginManifest struct {
+	Functions []string `json:"functions"`
+	Version   string   `json:"version,omitempty"`
+}
+
+// funcPluginCall are the parameters of the "call" method.
+type funcPluginCall struct {
+	Name string `json:"name"`
+	Args []any  `json:"args"`
+}
+
+// startFuncPlugins starts the function plugins of the options, and registers their namespace in the functions
+// of templates. Plugins which are running already are kept.
+func (g *GenOptsCommon) startFuncPlugins() error {
+	if len(g.FuncPlugins) == 0 {
+		return nil
+	}
+
+	if g.funcPlugins == nil {
+		g.funcPlugins = &funcPluginSet{}
+	}
+
+	set := g.funcPlugins
+	set.mx.Lock()
+	defer set.mx.Unlock()
+
+	if len(set.running) > 0 {
+		return nil
+	}
+
+	for _, opts := range g.FuncPlugins {
+		if err := g.checkFuncPlugin(opts); err != nil {
+			_ = g.stopRunningFuncPlugins()
+
+			return err
+		}
+
+		plugin, err := startFuncPlugin(g.context(), opts)
+		if err != nil {
+			_ = g.stopRunningFuncPlugins()
+
+			return fmt.Errorf("could not start the function plugin %q: %w", opts.Namespace, err)
+		}
+
+		set.running = append(set.running, plugin)
+		g.funcMap[opts.Namespace] = plugin.call
+	}
+
+	return nil
+}
+
+func (g *GenOptsCommon) checkFuncPlugin(opts FuncPluginOpts) error {
+	if len(opts.Command) == 0 {
+		return fmt.Errorf("the function plugin %q has no command", opts.Namespace)
+	}
+
+	if !token.IsIdentifier(opts.Namespace) {
+		return fmt.Errorf("the namespace of a function plugin must be an identifier, but got %q", opts.Namespace)
+	}
+
+	if _, exists := g.funcMap[opts.Namespace]; exists {
+		return fmt.Errorf("the namespace of the function plugin %q is a template function already", opts.Namespace)
+	}
+
+	return nil
+}
+
+// beginGeneration marks the start of a generation, and returns the function marking its end:
+//
+//	defer opts.beginGeneration()()
+//
+// Function plugins started during a generation are stopped when it ends. Generations may be nested,
+// e.g. GenerateServer run by RunWithHooks: plugins are stopped when the outermost generation ends.
+func (g *GenOpts) beginGeneration() func() {
+	if g == nil {
+		return func() {}
+	}
+
+	if g.funcPlugins == nil {
+		g.funcPlugins = &funcPluginSet{}
+	}
+
+	set := g.funcPlugins
+	set.mx.Lock()
+	set.generations++
+	set.mx.Unlock()
+
+	return func() {
+		set.mx.Lock()
+		defer set.mx.Unlock()
+
+		set.generations--
+		if set.generations > 0 {
+			return
+		}
+
+		if err := g.stopRunningFuncPlugins(); err != nil {
+			g.warnf("%v", err)
+		}
+	}
+}
+
+func (g *GenOptsCommon) stopRunningFuncPlugins() error {
+	var errs []error
+	for _, plugin := range g.funcPlugins.running {
+		delete(g.funcMap, plugin.Namespace)
+
+		if err := plugin.stop(); err != nil {
+			errs = append(errs, fmt.Errorf("function plugin %q: %w", plugin.Namespace, err))
+		}
+	}
+	g.funcPlugins.running = nil
+
+	return errors.Join(errs...)
+}
+
+// funcPluginVersions identifies the function plugins in use, for the fingerprint of generated files.
+func (g *GenOptsCommon) funcPluginVersions() []string {
+	if g.funcPlugins == nil {
+		return nil
+	}
+
+	g.funcPlugins.mx.Lock()
+	defer g.funcPlugins.mx.Unlock()
+
+	versions := make([]string, 0, len(g.funcPlugins.running))
+	for _, plugin := range g.funcPlugins.running {
+		versions = append(versions, plugin.Namespace+" "+strings.Join(plugin.Command, " ")+" "+plugin.version)
+	}
+
+	return versions
+}
+
+func startFuncPlugin(ctx context.Context, opts FuncPluginOpts) (*funcPlugin, error) {
+	//nolint:gosec // the command is declared by the configuration file, like hooks
+	cmd := exec.CommandContext(ctx, opts.Command[0], opts.Command[1:]...)
+	cmd.Stderr = os.Stderr
+
+	stdin, err := cmd.StdinPipe()
+	if err != nil {
+		return nil, err
+	}
+
+	stdout, err := cmd.StdoutPipe()
+	if err != nil {
+		return nil, err
+	}
+
+	if err := cmd.Start(); err != nil {
+		return nil, err
+	}
+
+	plugin := &funcPlugin{
+		FuncPluginOpts: opts,
+		cmd:            cmd,
+		stdin:          stdin,
+		stdout:         bufio.NewReader(stdout),
+	}
+
+	var manifest funcPluginManifest
+	if err := plugin.request(funcPluginMethodFunctions, nil, &manifest); err != nil {
+		_ = plugin.stop()
+
+		return nil, fmt.Errorf("could not list the functions: %w", err)
+	}
+	plugin.functions, plugin.version = manifest.Functions, manifest.Version
+
+	return plugin, nil
+}
+
+// call calls a function of the plugin, from a template.
+func (p *funcPlugin) call(name string, args ...any) (any, error) {
+	if !slices.Contains(p.functions, name) {
+		return nil, fmt.Errorf("function plugin %q: unknown function %q, available functions: %s",
+			p.Namespace, name, strings.Join(p.functions, ", "))
+	}
+
+	if args == nil {
+		args = []any{}
+	}
+
+	var result any
+	if err := p.request(funcPluginMethodCall, funcPluginCall{Name: name, Args: args}, &result); err != nil {
+		return nil, fmt.Errorf("function plugin %q: %s: %w", p.Namespace, name, err)
+	}
+
+	return result, nil
+}
+
+// request sends a request to the plugin, and decodes the result of its response.
+func (p *funcPlugin) request(method string, params, result any) error {
+	p.mx.Lock()
+	defer p.mx.Unlock()
+
+	p.lastID++
+	request, err := json.Marshal(rpcRequest{JSONRPC: "2.0", ID: p.lastID, Method: method, Params: params})
+	if err != nil {
+		return err
+	}
+
+	if _, err := p.stdin.Write(append(request, '\n')); err != nil {
+		if errors.Is(err, syscall.EPIPE) || errors.Is(err, os.ErrClosed) {
+			// the plugin exited before reading the request
+			return errFuncPluginExited
+		}
+
+		return err
+	}
+
+	line, err := p.stdout.ReadBytes('\n')
+	if err != nil {
+		if errors.Is(err, io.EOF) {
+			return errFuncPluginExited
+		}
+
+		return err
+	}
+
+	var response rpcResponse
+	if err := json.Unmarshal(line, &response); err != nil {
+		return fmt.Errorf("invalid response %q: %w", strings.TrimSpace(string(line)), err)
+	}
+
+	if response.ID != p.lastID {
+		return fmt.Errorf("unexpected response to request %d, expected %d", response.ID, p.lastID)
+	}
+
+	if response.Error != nil {
+		return response.Error
+	}
+
+	if len(response.Result) == 0 {
+		return nil
+	}
+
+	return json.Unmarshal(response.Result, result)
+}
+
+// stop closes the input of the plugin, and waits for it to exit.
+func (p *funcPlugin) stop() error {
+	_ = p.stdin.Close()
+
+	done := make(chan error, 1)
+	go func() {
+		done <- p.cmd.Wait()
+	}()
+
+	select {
+	case err := <-done:
+		return err
+	case <-time.After(funcPluginStopTimeout):
+		_ = p.cmd.Process.Kill()
+
+		return fmt.Errorf("the plugin did not exit within %v, and was killed", funcPluginStopTimeout)
+	}
+}
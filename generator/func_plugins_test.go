@@ -0,0 +1,153 @@
+// SPDX-FileCopyrightText: Copyright 2015-2025 go-swagger maintainers
+// SPDX-License-Identifier: Apache-2.0
+
+package generator
+
+import (
+	"bufio"
+	"context"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+
+	"github.com/go-openapi/testify/v2/assert"
+	"github.com/go-openapi/testify/v2/require"
+)
+
+func TestFuncPlugins(t *testing.T) {
+	defer discardOutput()()
+
+	plugin := filepath.Join(t.TempDir(), "funcplugin")
+	build := exec.Command("go", "build", "-o", plugin, "../fixtures/templates/funcplugin")
+	build.Stderr = os.Stderr
+	require.NoError(t, build.Run())
+
+	options := func(t *testing.T, template string, plugins ...FuncPluginOpts) *GenOpts {
+		t.Helper()
+
+		templates := t.TempDir()
+		require.NoError(t, os.WriteFile(filepath.Join(templates, "plugins.gotmpl"), []byte(template), readableFile))
+
+		opts := testGenOpts()
+		opts.Spec = filepath.FromSlash("../fixtures/bugs/1042/fixture-1042.yaml")
+		opts.Target = t.TempDir()
+		require.NoError(t, os.WriteFile(filepath.Join(opts.Target, "go.mod"), []byte("module plugins\n"), readableFile))
+		opts.TemplateDir = templates
+		opts.FuncPlugins = plugins
+		opts.Sections = SectionOpts{
+			Models: []TemplateOpts{{Name: "model", Source: "plugins.gotmpl", Target: "{{ .Target }}", FileName: "{{ .Name }}.txt", SkipFormat: true}},
+		}
+
+		return opts
+	}
+
+	generate := func(t *testing.T, template string, plugins ...FuncPluginOpts) (*GenOpts, error) {
+		t.Helper()
+
+		opts := options(t, template, plugins...)
+
+		return opts, RunWithHooks(opts, func(opts *GenOpts) error {
+			return GenerateModels(nil, opts)
+		})
+	}
+
+	acme := FuncPluginOpts{Namespace: "acme", Command: []string{plugin}}
+
+	t.Run("should call the functions of a plugin from templates", func(t *testing.T) {
+		opts, err := generate(t, `{{ acme "slug" .Description }}:{{ range acme "words" .Name "x y" }} {{ . }}{{ end }}`, acme)
+		require.NoError(t, err)
+
+		content, err := os.ReadFile(filepath.Join(opts.Target, "a.txt"))
+		require.NoError(t, err)
+		assert.EqualT(t, "a-first-definition-a: A x y", string(content))
+
+		t.Run("should stop the plugin once the generation is complete", func(t *testing.T) {
+			assert.Empty(t, opts.funcPlugins.running)
+			assert.NotContains(t, opts.funcMap, "acme")
+		})
+	})
+
+	t.Run("should stop the plugin when a generation entry point returns", func(t *testing.T) {
+		opts := options(t, `{{ acme "slug" .Description }}`, acme)
+		require.NoError(t, GenerateModels(nil, opts))
+
+		assert.Empty(t, opts.funcPlugins.running)
+		assert.NotContains(t, opts.funcMap, "acme")
+	})
+
+	t.Run("should kill the plugin when the generation is canceled", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(t.Context())
+		opts := options(t, `{{ .Name }}`, acme)
+		opts.ctx = ctx
+		require.NoError(t, opts.EnsureDefaults())
+		require.NoError(t, opts.setTemplates())
+		require.Len(t, opts.funcPlugins.running, 1)
+
+		cancel()
+		plugin := opts.funcPlugins.running[0]
+		require.Error(t, plugin.cmd.Wait())
+		opts.funcPlugins.running = nil
+	})
+
+	t.Run("should report the errors of functions", func(t *testing.T) {
+		_, err := generate(t, `{{ acme "fail" }}`, acme)
+		require.ErrorContains(t, err, `function plugin "acme": fail: failed on purpose (code -32000)`)
+
+		_, err = generate(t, `{{ acme "slugify" .Name }}`, acme)
+		require.ErrorContains(t, err, `function plugin "acme": unknown function "slugify", available functions: fail, slug, words`)
+	})
+
+	t.Run("should not start invalid plugins", func(t *testing.T) {
+		_, err := generate(t, `{{ .Name }}`, FuncPluginOpts{Namespace: "pascalize", Command: []string{plugin}})
+		require.ErrorContains(t, err, `the namespace of the function plugin "pascalize" is a template function already`)
+
+		_, err = generate(t, `{{ .Name }}`, FuncPluginOpts{Namespace: "acme.funcs", Command: []string{plugin}})
+		require.ErrorContains(t, err, "must be an identifier")
+
+		_, err = generate(t, `{{ .Name }}`, FuncPluginOpts{Namespace: "acme", Command: []string{"true"}})
+		require.ErrorContains(t, err, `could not start the function plugin "acme": could not list the functions: the plugin exited`)
+	})
+}
+
+func TestFuncPlugin_Exited(t *testing.T) {
+	// the plugin exits at once: requests fail to be written, with a broken pipe
+	cmd := exec.CommandContext(t.Context(), "true")
+	stdin, err := cmd.StdinPipe()
+	require.NoError(t, err)
+	stdout, err := cmd.StdoutPipe()
+	require.NoError(t, err)
+	require.NoError(t, cmd.Start())
+	_, err = cmd.Process.Wait()
+	require.NoError(t, err)
+
+	plugin := &funcPlugin{cmd: cmd, stdin: stdin, stdout: bufio.NewReader(stdout)}
+	require.ErrorIs(t, plugin.request(funcPluginMethodFunctions, nil, nil), errFuncPluginExited)
+
+	// once stopped, its input is closed
+	_ = plugin.stop()
+	require.ErrorIs(t, plugin.request(funcPluginMethodFunctions, nil, nil), errFuncPluginExited)
+}
+
+func TestConfigureOpts_FuncPlugins(t *testing.T) {
+	def := &LanguageDefinition{
+		Functions: []FuncPluginOpts{{Namespace: "acme", Command: []string{"acme-funcs"}}},
+		Profiles: map[string]ProfileDefinition{
+			"ci": {Functions: []FuncPluginOpts{{Namespace: "ci", Command: []string{"ci-funcs"}}}},
+		},
+	}
+
+	resolved, err := def.WithProfile("")
+	require.NoError(t, err)
+	opts := new(GenOpts)
+	require.NoError(t, resolved.ConfigureOpts(opts))
+	require.Len(t, opts.FuncPlugins, 1)
+	assert.EqualT(t, "acme", opts.FuncPlugins[0].Namespace)
+
+	resolved, err = def.WithProfile("ci")
+	require.NoError(t, err)
+	opts = new(GenOpts)
+	require.NoError(t, resolved.ConfigureOpts(opts))
+	require.Len(t, opts.FuncPlugins, 1)
+	assert.EqualT(t, "ci", opts.FuncPlugins[0].Namespace)
+}
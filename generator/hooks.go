@@ -0,0 +1,224 @@
+// SPDX-FileCopyrightText: Copyright 2015-2025 go-swagger maintainers
+// SPDX-License-Identifier: Apache-2.0
+
+package generator
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"text/template"
+	"time"
+)
+
+// Stages of the generation where hooks are run.
+const (
+	HookStagePre  = "pre"
+	HookStagePost = "post"
+)
+
+// HooksOpts are the commands run before and after the generation.
+type HooksOpts struct {
+	Pre  []HookOpts `mapstructure:"pre"`
+	Post []HookOpts `mapstructure:"post"`
+}
+
+// HookOpts is a command run before or after the generation, with the target directory as working directory.
+//
+// The arguments of the command are templates, executed with the name of the application, the spec,
+// the target directory and the generation options (e.g. `{{ .Target }}` or `{{ .Opts.ModelPackage }}`).
+//
+// The command receives a [HookInput] as JSON on its standard input.
+type HookOpts struct {
+	Name    string   `mapstructure:"name"`
+	Command []string `mapstructure:"command"` // the program to run and its arguments
+}
+
+// HookInput is passed to hooks on their standard input, as JSON.
+type HookInput struct {
+	Stage   string   `json:"stage"`   // pre or post
+	Target  string   `json:"target"`  // absolute path to the target directory
+	Files   []string `json:"files"`   // files written by the generation, relative to the target. Empty for pre-generation hooks
+	Skipped []string `json:"skipped"` // files left untouched because they exist, are up to date or unchanged, relative to the target
+}
+
+// hookContext is the data available to the arguments of hooks.
+type hookContext struct {
+	Name, Spec, Target, Stage string
+	Opts                      *GenOpts
+}
+
+func (h HooksOpts) isEmpty() bool {
+	return len(h.Pre) == 0 && len(h.Post) == 0
+}
+
+// RunWithHooks runs a generation between the pre-generation and post-generation hooks of the options.
+//
+// Post-generation hooks receive the files produced by the generation. A failed hook fails the generation:
+// post-generation hooks are not run when the generation or a pre-generation hook fails.
+//
+// With the PackageDocs or GoGenerate options, doc.go and generate.go files are generated before post-generation hooks.
+// With the Verify option, the packages of the generated files are type-checked last, once post-generation hooks
+// have run (e.g. go mod tidy).
+//
+// The packages needed by the code generated with a template pack are added to the go module of the target
+// before post-generation hooks.
+//
+// Function plugins started for the generation are stopped once it is complete.
+func RunWithHooks(opts *GenOpts, generate func(*GenOpts) error) error {
+	defer opts.beginGeneration()()
+
+	if (opts.Hooks.isEmpty() && !opts.Verify && !opts.PackageDocs && opts.GoGenerate == "" && opts.TemplatePack == "") || opts.DumpData {
+		return generate(opts)
+	}
+
+	if err := opts.EnsureDefaults(); err != nil {
+		return err
+	}
+
+	target, err := filepath.Abs(opts.Target)
+	if err != nil {
+		return err
+	}
+
+	if len(opts.Hooks.Pre) > 0 {
+		if err := opts.fs().MkdirAll(target, readAllDir); err != nil {
+			return err
+		}
+	}
+
+	if err := opts.runHooks(opts.Hooks.Pre, HookInput{Stage: HookStagePre, Target: target}); err != nil {
+		return err
+	}
+
+	// the files of the generation are collected from the progress events
+	input := HookInput{Stage: HookStagePost, Target: target}
+	files := make(generatedFiles)
+	handler := opts.Events
+	opts.Events = func(e Event) {
+		if handler != nil {
+			handler(e)
+		}
+		input.collect(e)
+		files.collect(e)
+	}
+	defer func() {
+		opts.Events = handler
+	}()
+
+	if err := generate(opts); err != nil {
+		return err
+	}
+
+	if err := opts.writePackageDocs(files); err != nil {
+		return err
+	}
+
+	opts.noticePackImports(target)
+
+	if err := opts.runHooks(opts.Hooks.Post, input); err != nil {
+		return err
+	}
+
+	if !opts.Verify {
+		return nil
+	}
+
+	return opts.verify(target, files)
+}
+
+func (in *HookInput) collect(e Event) {
+	if e.Path == "" || (e.Kind != EventFileWritten && e.Kind != EventFileSkipped) || e.Reason == SkipReasonCondition {
+		return
+	}
+
+	file := e.Path
+	if abs, err := filepath.Abs(file); err == nil {
+		if rel, err := filepath.Rel(in.Target, abs); err == nil {
+			file = rel
+		}
+	}
+	file = filepath.ToSlash(file)
+
+	if e.Kind == EventFileWritten {
+		in.Files = append(in.Files, file)
+
+		return
+	}
+
+	in.Skipped = append(in.Skipped, file)
+}
+
+func (g *GenOpts) runHooks(hooks []HookOpts, input HookInput) error {
+	if input.Files == nil {
+		input.Files = []string{}
+	}
+	if input.Skipped == nil {
+		input.Skipped = []string{}
+	}
+
+	stdin, err := json.Marshal(input)
+	if err != nil {
+		return err
+	}
+
+	data := hookContext{Name: g.Name, Spec: g.Spec, Target: input.Target, Stage: input.Stage, Opts: g}
+	for i, hook := range hooks {
+		name := hook.Name
+		if name == "" {
+			name = fmt.Sprintf("#%d", i+1)
+		}
+
+		if err := g.runHook(name, hook, data, stdin); err != nil {
+			return fmt.Errorf("%s-generation hook %s failed: %w", input.Stage, name, err)
+		}
+	}
+
+	return nil
+}
+
+func (g *GenOpts) runHook(name string, hook HookOpts, data hookContext, stdin []byte) error {
+	if len(hook.Command) == 0 {
+		return errors.New("no command")
+	}
+
+	args := make([]string, 0, len(hook.Command))
+	for i, arg := range hook.Command {
+		tpl, err := template.New(fmt.Sprintf("%s-arg%d", name, i)).Funcs(g.funcMap).Parse(arg)
+		if err != nil {
+			return fmt.Errorf("invalid argument %q: %w", arg, err)
+		}
+
+		var buf bytes.Buffer
+		if err := tpl.Execute(&buf, data); err != nil {
+			return fmt.Errorf("invalid argument %q: %w", arg, err)
+		}
+		args = append(args, buf.String())
+	}
+
+	g.logf("running %s-generation hook %s: %s", data.Stage, name, strings.Join(args, " "))
+	start := time.Now()
+
+	cmd := exec.CommandContext(g.context(), args[0], args[1:]...) //nolint:gosec // hooks are configured by the user
+	cmd.Dir = data.Target
+	cmd.Stdin = bytes.NewReader(stdin)
+	output, err := cmd.CombinedOutput()
+	if err != nil {
+		if len(output) == 0 {
+			return err
+		}
+
+		return fmt.Errorf("%w, with output:\n%s", err, strings.TrimRight(string(output), "\n"))
+	}
+
+	if len(output) > 0 {
+		g.debugf("output of %s-generation hook %s:\n%s", data.Stage, name, output)
+	}
+	g.emitSince(start, Event{Kind: EventHookRun, Name: name, Stage: data.Stage})
+
+	return nil
+}
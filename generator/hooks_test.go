@@ -0,0 +1,133 @@
+// SPDX-FileCopyrightText: Copyright 2015-2025 go-swagger maintainers
+// SPDX-License-Identifier: Apache-2.0
+
+package generator
+
+import (
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+
+	"github.com/go-openapi/testify/v2/assert"
+	"github.com/go-openapi/testify/v2/require"
+)
+
+func TestRunWithHooks(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("hooks are tested with a shell")
+	}
+	defer discardOutput()()
+
+	fakeGeneration := func(opts *GenOpts) error {
+		opts.emit(Event{Kind: EventFileWritten, Path: filepath.Join(opts.Target, "models", "pet.go")})
+		opts.emit(Event{Kind: EventFileSkipped, Path: filepath.Join(opts.Target, "restapi", "configure_petstore.go"), Reason: SkipReasonExists})
+		opts.emit(Event{Kind: EventFileSkipped, Path: filepath.Join(opts.Target, "models", "stream.go"), Reason: SkipReasonCondition})
+
+		return nil
+	}
+
+	t.Run("should run hooks in the target, with the generated files", func(t *testing.T) {
+		opts := testGenOpts()
+		opts.Name = "petstore"
+		opts.Target = filepath.Join(t.TempDir(), "generated")
+		opts.Hooks = HooksOpts{
+			Pre:  []HookOpts{{Name: "input", Command: []string{"sh", "-c", "cat > {{ .Stage }}.json"}}},
+			Post: []HookOpts{{Name: "input", Command: []string{"sh", "-c", "cat > {{ .Stage }}-{{ snakize .Name }}.json"}}},
+		}
+
+		var hooksRun []string
+		opts.Events = func(e Event) {
+			if e.Kind == EventHookRun {
+				hooksRun = append(hooksRun, e.Stage+":"+e.Name)
+			}
+		}
+
+		require.NoError(t, RunWithHooks(opts, fakeGeneration))
+		assert.Equal(t, []string{"pre:input", "post:input"}, hooksRun)
+
+		var pre, post HookInput
+		content, err := os.ReadFile(filepath.Join(opts.Target, "pre.json"))
+		require.NoError(t, err)
+		require.NoError(t, json.Unmarshal(content, &pre))
+		assert.EqualT(t, HookStagePre, pre.Stage)
+		assert.Empty(t, pre.Files)
+
+		content, err = os.ReadFile(filepath.Join(opts.Target, "post-petstore.json"))
+		require.NoError(t, err)
+		require.NoError(t, json.Unmarshal(content, &post))
+		assert.Equal(t, HookInput{
+			Stage:   HookStagePost,
+			Target:  opts.Target,
+			Files:   []string{"models/pet.go"},
+			Skipped: []string{"restapi/configure_petstore.go"},
+		}, post)
+	})
+
+	t.Run("should fail with the output of a failed hook", func(t *testing.T) {
+		opts := testGenOpts()
+		opts.Target = t.TempDir()
+		opts.Hooks = HooksOpts{
+			Post: []HookOpts{
+				{Name: "lint", Command: []string{"sh", "-c", "echo missing license header in $(cat) >&2; exit 3"}},
+				{Name: "never", Command: []string{"sh", "-c", "touch never"}},
+			},
+		}
+
+		err := RunWithHooks(opts, fakeGeneration)
+		require.Error(t, err)
+		assert.StringContainsT(t, err.Error(), "post-generation hook lint failed: exit status 3, with output:\nmissing license header in {")
+		assert.FileNotExists(t, filepath.Join(opts.Target, "never"))
+	})
+
+	t.Run("should not generate when a pre-generation hook fails", func(t *testing.T) {
+		opts := testGenOpts()
+		opts.Target = t.TempDir()
+		opts.Hooks = HooksOpts{
+			Pre: []HookOpts{{Command: []string{"false"}}},
+		}
+
+		err := RunWithHooks(opts, func(*GenOpts) error {
+			return errors.New("should not generate")
+		})
+		require.Error(t, err)
+		assert.EqualT(t, "pre-generation hook #1 failed: exit status 1", err.Error())
+	})
+
+	t.Run("should reject an invalid argument", func(t *testing.T) {
+		opts := testGenOpts()
+		opts.Target = t.TempDir()
+		opts.Hooks = HooksOpts{
+			Post: []HookOpts{{Name: "tidy", Command: []string{"go", "{{ .Nmae }}"}}},
+		}
+
+		err := RunWithHooks(opts, fakeGeneration)
+		require.Error(t, err)
+		assert.StringContainsT(t, err.Error(), `post-generation hook tidy failed: invalid argument "{{ .Nmae }}"`)
+	})
+}
+
+func TestConfigureOpts_Hooks(t *testing.T) {
+	def := &LanguageDefinition{
+		Hooks: HooksOpts{Post: []HookOpts{{Name: "tidy", Command: []string{"go", "mod", "tidy"}}}},
+		Profiles: map[string]ProfileDefinition{
+			"ci": {Hooks: &HooksOpts{Post: []HookOpts{{Name: "lint", Command: []string{"golangci-lint", "run"}}}}},
+		},
+	}
+
+	resolved, err := def.WithProfile("")
+	require.NoError(t, err)
+	opts := new(GenOpts)
+	require.NoError(t, resolved.ConfigureOpts(opts))
+	require.Len(t, opts.Hooks.Post, 1)
+	assert.EqualT(t, "tidy", opts.Hooks.Post[0].Name)
+
+	resolved, err = def.WithProfile("ci")
+	require.NoError(t, err)
+	opts = new(GenOpts)
+	require.NoError(t, resolved.ConfigureOpts(opts))
+	require.Len(t, opts.Hooks.Post, 1)
+	assert.EqualT(t, "lint", opts.Hooks.Post[0].Name)
+}
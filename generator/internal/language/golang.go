@@ -15,8 +15,6 @@ import (
 	goruntime "runtime"
 	"sort"
 	"strings"
-
-	"golang.org/x/tools/imports"
 )
 
 var moduleRe = regexp.MustCompile(`module[ \t]+([^\s]+)`)
@@ -38,6 +36,7 @@ func GolangOpts(extraInitialisms ...string) *Options {
 	opts.ImportsFunc = defaultGoImportsFunc()
 	opts.ArrayInitializerFunc = defaultGoArrayInitializerFunc()
 	opts.BaseImportFunc = defaultGoBaseImportFunc()
+	opts.ImportDirFunc = DefaultGoImportDir
 
 	opts.Init()
 
@@ -46,9 +45,8 @@ func GolangOpts(extraInitialisms ...string) *Options {
 
 func defaultGoFormatFunc() FormatterFunc {
 	return func(ffn string, content []byte, fmtOpts ...FormatOption) ([]byte, error) {
-		o := FormatOptsWithDefault(fmtOpts)
-		imports.LocalPrefix = strings.Join(o.LocalPrefixes, ",") // regroup these packages
-		return imports.Process(ffn, content, &o.Options)
+		// local prefixes regroup these packages. The formatter may be called concurrently.
+		return formatByImports(ffn, content, FormatOptsWithDefault(fmtOpts))
 	}
 }
 
@@ -153,7 +151,7 @@ func defaultGoBaseImportErr(target string) (string, error) {
 	mod, goModuleAbsPath, err := tryResolveModule(targetAbsPath)
 	switch {
 	case err != nil:
-		return "", fmt.Errorf("failed to resolve module using go.mod file: %w", err)
+		return "", fmt.Errorf("failed to resolve module: %w", err)
 	case mod != "":
 		relTgt := relPathToRelGoPath(goModuleAbsPath, targetAbsPath)
 		if !strings.HasSuffix(mod, relTgt) {
@@ -232,7 +230,19 @@ func relPathToRelGoPath(modAbsPath, absPath string) string {
 	return strings.Join(pathItems, "/")
 }
 
+// tryResolveModule resolves the go module which holds some target directory.
+//
+// When a go.work file applies to the target, the modules used by this workspace take precedence.
+// Otherwise, the nearest go.mod file is retained.
 func tryResolveModule(baseTargetPath string) (string, string, error) {
+	module, err := resolveWorkspaceModule(baseTargetPath)
+	switch {
+	case err == nil:
+		return module.Path, module.Dir, nil
+	case !errors.Is(err, errNoWorkspaceModule):
+		return "", "", fmt.Errorf("failed to resolve module using go.work file: %w", err)
+	}
+
 	f, goModAbsPath, err := resolveGoModFile(baseTargetPath)
 	switch {
 	case os.IsNotExist(err):
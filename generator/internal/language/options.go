@@ -78,9 +78,10 @@ func FormatOptsWithDefault(opts []FormatOption) FormatOpts {
 // Options describes a target language to the code generator.
 type Options struct {
 	ReservedWords        []string
-	BaseImportFunc       MangleFunc                     `json:"-"`
-	ImportsFunc          func(map[string]string) string `json:"-"`
-	ArrayInitializerFunc func(any) (string, error)      `json:"-"`
+	BaseImportFunc       MangleFunc                           `json:"-"`
+	ImportDirFunc        func(string, string) (string, error) `json:"-"`
+	ImportsFunc          func(map[string]string) string       `json:"-"`
+	ArrayInitializerFunc func(any) (string, error)            `json:"-"`
 	FormatOnly           bool
 	ExtraInitialisms     []string
 	Mangler              mangling.NameMangler
@@ -208,6 +209,17 @@ func (l *Options) BaseImport(tgt string) string {
 	return ""
 }
 
+// ImportDir resolves the local directory of a package imported by the code generated in some target.
+//
+// An empty directory is returned when the package cannot be resolved locally.
+func (l *Options) ImportDir(tgt, importPath string) (string, error) {
+	if l.ImportDirFunc != nil {
+		return l.ImportDirFunc(tgt, importPath)
+	}
+
+	return "", nil
+}
+
 // importAlias extracts the last path component from a package import path.
 func importAlias(pkg string) string {
 	_, k := path.Split(pkg)
@@ -0,0 +1,224 @@
+// SPDX-FileCopyrightText: Copyright 2015-2025 go-swagger maintainers
+// SPDX-License-Identifier: Apache-2.0
+
+package language
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"golang.org/x/mod/modfile"
+)
+
+// goModule is a go module, located in some directory.
+type goModule struct {
+	Path string // the module path, e.g. github.com/example/api
+	Dir  string // the absolute path to the directory holding the go.mod file
+}
+
+// goWorkspace is a go workspace, as described by a go.work file.
+type goWorkspace struct {
+	File    string
+	Modules []goModule
+}
+
+// findGoWork locates the go.work file which applies to a directory.
+//
+// Like the go tool, it honors the GOWORK environment variable: GOWORK=off disables workspaces,
+// and an explicit path to a go.work file takes precedence over the lookup in parent directories.
+//
+// It returns an empty string when no workspace applies.
+func findGoWork(dir string) (string, error) {
+	switch gowork := os.Getenv("GOWORK"); gowork {
+	case "off":
+		return "", nil
+	case "":
+	default:
+		if !filepath.IsAbs(gowork) {
+			return "", fmt.Errorf("invalid GOWORK %q: the path must be absolute", gowork)
+		}
+
+		return gowork, nil
+	}
+
+	for {
+		pth := filepath.Join(dir, "go.work")
+		if _, err := os.Stat(pth); err == nil {
+			return pth, nil
+		} else if !os.IsNotExist(err) {
+			return "", err
+		}
+
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return "", nil
+		}
+		dir = parent
+	}
+}
+
+// loadGoWorkspace reads a go.work file and the modules it uses.
+func loadGoWorkspace(file string) (*goWorkspace, error) {
+	content, err := os.ReadFile(file)
+	if err != nil {
+		return nil, err
+	}
+
+	work, err := modfile.ParseWork(file, content, nil)
+	if err != nil {
+		return nil, fmt.Errorf("could not parse go workspace: %w", err)
+	}
+
+	workspace := &goWorkspace{File: file}
+	for _, use := range work.Use {
+		dir := filepath.FromSlash(use.Path)
+		if !filepath.IsAbs(dir) {
+			dir = filepath.Join(filepath.Dir(file), dir)
+		}
+		dir = filepath.Clean(dir)
+
+		modContent, err := os.ReadFile(filepath.Join(dir, "go.mod"))
+		if err != nil {
+			return nil, fmt.Errorf("could not read module %q used by go workspace %q: %w", use.Path, file, err)
+		}
+
+		modulePath := modfile.ModulePath(modContent)
+		if modulePath == "" {
+			return nil, fmt.Errorf("no module path in %q, used by go workspace %q", filepath.Join(dir, "go.mod"), file)
+		}
+
+		workspace.Modules = append(workspace.Modules, goModule{Path: modulePath, Dir: dir})
+	}
+
+	return workspace, nil
+}
+
+// resolveGoWorkspace loads the go workspace which applies to a directory, if any.
+func resolveGoWorkspace(dir string) (*goWorkspace, error) {
+	file, err := findGoWork(dir)
+	if err != nil || file == "" {
+		return nil, err
+	}
+
+	return loadGoWorkspace(file)
+}
+
+// moduleForDir returns the module of the workspace which holds some directory.
+//
+// With nested modules, the innermost module is retained.
+func (w *goWorkspace) moduleForDir(dir string) (goModule, bool) {
+	var found goModule
+	for _, module := range w.Modules {
+		if ok, _ := CheckPrefixAndFetchRelativePath(dir, module.Dir); !ok && dir != module.Dir {
+			continue
+		}
+
+		if len(module.Dir) > len(found.Dir) {
+			found = module
+		}
+	}
+
+	return found, found.Dir != ""
+}
+
+// moduleForImport returns the module of the workspace which provides some import path.
+func (w *goWorkspace) moduleForImport(importPath string) (goModule, bool) {
+	return moduleForImport(w.Modules, importPath)
+}
+
+func moduleForImport(modules []goModule, importPath string) (goModule, bool) {
+	var found goModule
+	for _, module := range modules {
+		if importPath != module.Path && !strings.HasPrefix(importPath, module.Path+"/") {
+			continue
+		}
+
+		if len(module.Path) > len(found.Path) {
+			found = module
+		}
+	}
+
+	return found, found.Path != ""
+}
+
+// importDir resolves the directory of a package, within a module.
+func (m goModule) importDir(importPath string) string {
+	rel := strings.TrimPrefix(strings.TrimPrefix(importPath, m.Path), "/")
+
+	return filepath.Join(m.Dir, filepath.FromSlash(rel))
+}
+
+// DefaultGoImportDir resolves the local directory of a go package imported by code generated in some target.
+//
+// Packages are resolved in the module of the target and, when a go.work file applies to the target,
+// in all the modules of this workspace.
+//
+// When the package is provided by a workspace module, the module of the target must be part of this workspace.
+//
+// It returns an empty string when the package is provided by some other module,
+// e.g. a dependency downloaded by the go tool.
+func DefaultGoImportDir(target, importPath string) (string, error) {
+	targetAbsPath, err := filepath.Abs(filepath.Clean(target))
+	if err != nil {
+		return "", fmt.Errorf("could not evaluate import directory with target %q: %w", target, err)
+	}
+
+	var modules []goModule
+	mod, modDir, err := tryResolveModule(targetAbsPath)
+	if err != nil {
+		return "", err
+	}
+	if mod != "" {
+		modules = append(modules, goModule{Path: mod, Dir: modDir})
+	}
+
+	workspace, err := resolveGoWorkspace(targetAbsPath)
+	if err != nil {
+		return "", err
+	}
+
+	if workspace != nil {
+		if module, ok := workspace.moduleForImport(importPath); ok {
+			if _, inWorkspace := workspace.moduleForDir(targetAbsPath); !inWorkspace {
+				return "", fmt.Errorf(
+					"package %q is provided by module %q of the go workspace %q, but target %q is not part of this workspace",
+					importPath, module.Path, workspace.File, target,
+				)
+			}
+
+			return module.importDir(importPath), nil
+		}
+	}
+
+	if module, ok := moduleForImport(modules, importPath); ok {
+		return module.importDir(importPath), nil
+	}
+
+	return "", nil
+}
+
+var errNoWorkspaceModule = errors.New("target is not located in a module of the go workspace")
+
+// resolveWorkspaceModule resolves the module of the go workspace which holds some directory.
+//
+// It returns errNoWorkspaceModule when no workspace applies, or when the directory is not part of the workspace.
+func resolveWorkspaceModule(dir string) (goModule, error) {
+	workspace, err := resolveGoWorkspace(dir)
+	if err != nil {
+		return goModule{}, err
+	}
+
+	if workspace == nil {
+		return goModule{}, errNoWorkspaceModule
+	}
+
+	module, ok := workspace.moduleForDir(dir)
+	if !ok {
+		return goModule{}, errNoWorkspaceModule
+	}
+
+	return module, nil
+}
@@ -0,0 +1,121 @@
+// SPDX-FileCopyrightText: Copyright 2015-2025 go-swagger maintainers
+// SPDX-License-Identifier: Apache-2.0
+
+package language
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/go-openapi/testify/v2/assert"
+	"github.com/go-openapi/testify/v2/require"
+)
+
+// makeWorkspace builds a go workspace with the modules "api" and "models", and a module "other" outside the workspace.
+func makeWorkspace(t *testing.T) string {
+	t.Helper()
+
+	root := t.TempDir()
+	files := map[string]string{
+		"go.work":          "go 1.25\n\nuse (\n\t./api\n\t./models\n)\n",
+		"api/go.mod":       "module example.com/api\n\ngo 1.25\n",
+		"models/go.mod":    "module example.com/models\n\ngo 1.25\n",
+		"models/dto/a.go":  "package dto\n",
+		"other/go.mod":     "module example.com/other\n\ngo 1.25\n",
+		"other/dto/a.go":   "package dto\n",
+		"api/gen/.gitkeep": "",
+	}
+	for name, content := range files {
+		pth := filepath.Join(root, filepath.FromSlash(name))
+		require.NoError(t, os.MkdirAll(filepath.Dir(pth), 0o755))
+		require.NoError(t, os.WriteFile(pth, []byte(content), 0o600))
+	}
+
+	return root
+}
+
+func TestGoWorkspace_BaseImport(t *testing.T) {
+	t.Setenv("GOWORK", "")
+	root := makeWorkspace(t)
+
+	t.Run("should resolve the module of the workspace", func(t *testing.T) {
+		pth, err := defaultGoBaseImportErr(filepath.Join(root, "api", "gen"))
+		require.NoError(t, err)
+		assert.EqualT(t, "example.com/api/gen", pth)
+	})
+
+	t.Run("should resolve a module outside the workspace", func(t *testing.T) {
+		pth, err := defaultGoBaseImportErr(filepath.Join(root, "other"))
+		require.NoError(t, err)
+		assert.EqualT(t, "example.com/other", pth)
+	})
+
+	t.Run("should honor an explicit GOWORK", func(t *testing.T) {
+		t.Setenv("GOWORK", "relative/go.work")
+		_, err := defaultGoBaseImportErr(filepath.Join(root, "api"))
+		require.Error(t, err)
+	})
+}
+
+func TestDefaultGoImportDir(t *testing.T) {
+	t.Setenv("GOWORK", "")
+	root := makeWorkspace(t)
+	target := filepath.Join(root, "api", "gen")
+
+	t.Run("should resolve a package in another module of the workspace", func(t *testing.T) {
+		dir, err := DefaultGoImportDir(target, "example.com/models/dto")
+		require.NoError(t, err)
+		assert.EqualT(t, filepath.Join(root, "models", "dto"), dir)
+	})
+
+	t.Run("should resolve a package in the module of the target", func(t *testing.T) {
+		dir, err := DefaultGoImportDir(target, "example.com/api/bespoke")
+		require.NoError(t, err)
+		assert.EqualT(t, filepath.Join(root, "api", "bespoke"), dir)
+	})
+
+	t.Run("should not resolve a package from a dependency", func(t *testing.T) {
+		dir, err := DefaultGoImportDir(target, "github.com/example/dto")
+		require.NoError(t, err)
+		assert.Empty(t, dir)
+	})
+
+	t.Run("should reject a workspace package imported from outside the workspace", func(t *testing.T) {
+		_, err := DefaultGoImportDir(filepath.Join(root, "other"), "example.com/models/dto")
+		require.Error(t, err)
+		assert.StringContainsT(t, err.Error(), "not part of this workspace")
+	})
+
+	t.Run("should ignore the workspace with GOWORK=off", func(t *testing.T) {
+		t.Setenv("GOWORK", "off")
+		dir, err := DefaultGoImportDir(filepath.Join(root, "other"), "example.com/models/dto")
+		require.NoError(t, err)
+		assert.Empty(t, dir)
+	})
+}
+
+func TestGoWorkspace_ModuleForDir(t *testing.T) {
+	workspace := &goWorkspace{Modules: []goModule{
+		{Path: "example.com/root", Dir: filepath.FromSlash("/work")},
+		{Path: "example.com/nested", Dir: filepath.FromSlash("/work/nested")},
+	}}
+
+	module, ok := workspace.moduleForDir(filepath.FromSlash("/work/nested/pkg"))
+	require.TrueT(t, ok)
+	assert.EqualT(t, "example.com/nested", module.Path)
+
+	module, ok = workspace.moduleForDir(filepath.FromSlash("/work/pkg"))
+	require.TrueT(t, ok)
+	assert.EqualT(t, "example.com/root", module.Path)
+
+	_, ok = workspace.moduleForDir(filepath.FromSlash("/elsewhere"))
+	assert.FalseT(t, ok)
+
+	module, ok = workspace.moduleForImport("example.com/nested/pkg")
+	require.TrueT(t, ok)
+	assert.EqualT(t, "example.com/nested", module.Path)
+
+	_, ok = workspace.moduleForImport("example.com/rootless")
+	assert.FalseT(t, ok)
+}
@@ -5,11 +5,15 @@ package repo
 
 import (
 	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
 	"fmt"
 	"log"
 	"maps"
 	"os"
 	"path/filepath"
+	"slices"
+	"sort"
 	"strings"
 	"sync"
 	"text/template"
@@ -18,6 +22,23 @@ import (
 	"github.com/go-openapi/swag/mangling"
 )
 
+// Origins of the templates loaded in a repository.
+const (
+	OriginEmbedded    = "embedded"     // default templates, embedded in the generator
+	OriginContrib     = "contrib"      // contributed templates, embedded in the generator
+	OriginTemplateDir = "template dir" // templates loaded from a directory on disk
+	OriginPack        = "pack"         // templates loaded from a template pack on disk
+	OriginAdded       = "added"        // templates added programmatically
+)
+
+// Source tells where a template is defined.
+type Source struct {
+	Name      string // name of the template
+	File      string // file which defines the template: an asset name, or a path on disk
+	Origin    string // one of the Origin* constants
+	Protected bool   // the template may only be overridden with allowOverride
+}
+
 // AssetProvider provides access to embedded template assets.
 type AssetProvider interface {
 	AssetNames() []string
@@ -25,23 +46,34 @@ type AssetProvider interface {
 }
 
 // Repository is the repository for the generator templates.
+//
+// Templates are loaded first, then retrieved with all their dependencies resolved.
+// Retrieving and executing templates is safe for concurrent use.
 type Repository struct {
 	files              map[string]string
+	sources            map[string]Source
+	sums               map[string][sha256.Size]byte
 	templates          map[string]*template.Template
+	resolved           map[string]*template.Template
 	funcs              template.FuncMap
 	protectedTemplates map[string]bool
 	allowOverride      bool
 	mux                sync.Mutex
 	mangler            mangling.NameMangler
+	logf               func(string, ...any)
 }
 
 // NewRepository creates a new template repository with the provided functions defined.
 func NewRepository(funcs template.FuncMap) *Repository {
 	repo := Repository{
 		files:     make(map[string]string),
+		sources:   make(map[string]Source),
+		sums:      make(map[string][sha256.Size]byte),
 		templates: make(map[string]*template.Template),
+		resolved:  make(map[string]*template.Template),
 		funcs:     funcs,
 		mangler:   mangling.NewNameMangler(), // default is good enough for template management
+		logf:      log.Printf,
 	}
 
 	if repo.funcs == nil {
@@ -57,6 +89,11 @@ func (t *Repository) SetProtectedTemplates(m map[string]bool) {
 	t.protectedTemplates = m
 }
 
+// SetLogger sets the function reporting how templates are loaded. Messages go to the standard logger by default.
+func (t *Repository) SetLogger(logf func(string, ...any)) {
+	t.logf = logf
+}
+
 // ShallowClone a repository.
 //
 // Clones the maps of files and templates, so as to be able to use
@@ -64,17 +101,23 @@ func (t *Repository) SetProtectedTemplates(m map[string]bool) {
 func (t *Repository) ShallowClone() *Repository {
 	clone := &Repository{
 		files:              make(map[string]string, len(t.files)),
+		sources:            make(map[string]Source, len(t.sources)),
+		sums:               make(map[string][sha256.Size]byte, len(t.sums)),
 		templates:          make(map[string]*template.Template, len(t.templates)),
+		resolved:           make(map[string]*template.Template),
 		funcs:              t.funcs,
 		protectedTemplates: t.protectedTemplates,
 		allowOverride:      t.allowOverride,
 		mangler:            t.mangler,
+		logf:               t.logf,
 	}
 
 	t.mux.Lock()
 	defer t.mux.Unlock()
 
 	maps.Copy(clone.files, t.files)
+	maps.Copy(clone.sources, t.sources)
+	maps.Copy(clone.sums, t.sums)
 	maps.Copy(clone.templates, t.templates)
 
 	return clone
@@ -83,7 +126,7 @@ func (t *Repository) ShallowClone() *Repository {
 // LoadDefaults loads templates from the given asset map.
 func (t *Repository) LoadDefaults(assets map[string][]byte) error {
 	for name, asset := range assets {
-		if err := t.addFile(name, string(asset), true); err != nil {
+		if err := t.addFile(name, string(asset), true, Source{File: name, Origin: OriginEmbedded}); err != nil {
 			return err
 		}
 	}
@@ -93,11 +136,22 @@ func (t *Repository) LoadDefaults(assets map[string][]byte) error {
 
 // LoadDir will walk the specified path and add each .gotmpl file it finds to the repository.
 func (t *Repository) LoadDir(templatePath string) error {
+	return t.loadDir(templatePath, false, OriginTemplateDir)
+}
+
+// LoadPack will walk the directory of a template pack and add each .gotmpl file it finds to the repository.
+//
+// Like contributed templates, the templates of a pack may override protected templates.
+func (t *Repository) LoadPack(packPath string) error {
+	return t.loadDir(packPath, true, OriginPack)
+}
+
+func (t *Repository) loadDir(templatePath string, allowOverride bool, origin string) error {
 	err := filepath.Walk(templatePath, func(path string, _ os.FileInfo, err error) error {
 		if strings.HasSuffix(path, ".gotmpl") {
 			if assetName, e := filepath.Rel(templatePath, path); e == nil {
 				if data, e := os.ReadFile(path); e == nil { //nolint:gosec // pre-existing: template loading from user-specified directory
-					if ee := t.AddFile(assetName, string(data)); ee != nil {
+					if ee := t.addFile(assetName, string(data), allowOverride, Source{File: path, Origin: origin}); ee != nil {
 						return fmt.Errorf("could not add template: %w", ee)
 					}
 				}
@@ -120,7 +174,7 @@ func (t *Repository) LoadDir(templatePath string) error {
 
 // LoadContrib loads template from contrib directory using the given asset provider.
 func (t *Repository) LoadContrib(name string, provider AssetProvider) error {
-	log.Printf("loading contrib %s", name)
+	t.logf("loading contrib %s", name)
 	const pathPrefix = "templates/contrib/"
 	basePath := pathPrefix + name
 	filesAdded := 0
@@ -130,11 +184,11 @@ func (t *Repository) LoadContrib(name string, provider AssetProvider) error {
 		}
 		if strings.HasPrefix(aname, basePath) {
 			target := aname[len(basePath)+1:]
-			err := t.addFile(target, string(provider.MustAsset(aname)), true)
+			err := t.addFile(target, string(provider.MustAsset(aname)), true, Source{File: aname, Origin: OriginContrib})
 			if err != nil {
 				return err
 			}
-			log.Printf("added contributed template %s from %s", target, aname)
+			t.logf("added contributed template %s from %s", target, aname)
 			filesAdded++
 		}
 	}
@@ -160,7 +214,7 @@ func (t *Repository) MustGet(name string) *template.Template {
 //
 // If the file contains a definition for a template that is protected the whole file will not be added.
 func (t *Repository) AddFile(name, data string) error {
-	return t.addFile(name, data, false)
+	return t.addFile(name, data, false, Source{File: name, Origin: OriginAdded})
 }
 
 // SetAllowOverride allows setting allowOverride after the Repository was initialized.
@@ -170,14 +224,29 @@ func (t *Repository) SetAllowOverride(value bool) {
 
 // Get will return the named template from the repository, ensuring that all dependent templates are loaded.
 // It will return an error if a dependent template is not defined in the repository.
+//
+// Resolved templates are cached until a new file is added to the repository.
 func (t *Repository) Get(name string) (*template.Template, error) {
-	templ, found := t.templates[name]
+	t.mux.Lock()
+	defer t.mux.Unlock()
+
+	if resolved, ok := t.resolved[name]; ok {
+		return resolved, nil
+	}
 
+	templ, found := t.templates[name]
 	if !found {
 		return templ, fmt.Errorf("template doesn't exist %s", name)
 	}
 
-	return t.addDependencies(templ)
+	resolved, err := t.addDependencies(templ)
+	if err != nil {
+		return resolved, err
+	}
+
+	t.resolved[name] = resolved
+
+	return resolved, nil
 }
 
 // DumpTemplates prints out a dump of all the defined templates, where they are defined and what their dependencies are.
@@ -193,7 +262,88 @@ func (t *Repository) DumpTemplates() {
 		}
 		fmt.Fprintln(buf, "\n---")
 	}
-	log.Println(buf.String())
+	t.logf("%s", buf.String())
+}
+
+// Describe tells where a template is defined, and where the templates it includes transitively are defined.
+//
+// Included templates are sorted by name. Templates which are included but not defined in the repository
+// are reported without a file nor an origin.
+func (t *Repository) Describe(name string) (Source, []Source, error) {
+	t.mux.Lock()
+	defer t.mux.Unlock()
+
+	templ, found := t.templates[name]
+	if !found {
+		return Source{}, nil, fmt.Errorf("template doesn't exist %s", name)
+	}
+
+	deps := t.flattenDependencies(templ, nil)
+	includes := make([]Source, 0, len(deps))
+	for _, dep := range slices.Sorted(maps.Keys(deps)) {
+		if dep == "" || dep == name {
+			continue
+		}
+		includes = append(includes, t.source(dep))
+	}
+
+	return t.source(name), includes, nil
+}
+
+// Lookup returns a template loaded in the repository, and tells where it is defined.
+//
+// Unlike Get, the dependencies of the template are not resolved.
+func (t *Repository) Lookup(name string) (*template.Template, Source, bool) {
+	t.mux.Lock()
+	defer t.mux.Unlock()
+
+	templ, found := t.templates[name]
+	if !found {
+		return nil, Source{}, false
+	}
+
+	return templ, t.source(name), true
+}
+
+// Names returns the names of all the templates loaded in the repository, in alphabetical order.
+func (t *Repository) Names() []string {
+	t.mux.Lock()
+	defer t.mux.Unlock()
+
+	return slices.Sorted(maps.Keys(t.templates))
+}
+
+func (t *Repository) source(name string) Source {
+	source, ok := t.sources[name]
+	if !ok {
+		return Source{Name: name}
+	}
+	source.Protected = t.protectedTemplates[name]
+
+	return source
+}
+
+// Fingerprint returns a stable digest of the source of all template files loaded in the repository.
+//
+// Two repositories loaded with the same files yield the same fingerprint, regardless of the loading order.
+func (t *Repository) Fingerprint() string {
+	t.mux.Lock()
+	defer t.mux.Unlock()
+
+	names := make([]string, 0, len(t.sums))
+	for name := range t.sums {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	h := sha256.New()
+	for _, name := range names {
+		sum := t.sums[name]
+		h.Write([]byte(name))
+		h.Write(sum[:])
+	}
+
+	return hex.EncodeToString(h.Sum(nil))
 }
 
 // Funcs returns the template function map, allowing callers to add or modify functions.
@@ -201,7 +351,7 @@ func (t *Repository) Funcs() template.FuncMap {
 	return t.funcs
 }
 
-func (t *Repository) addFile(name, data string, allowOverride bool) error {
+func (t *Repository) addFile(name, data string, allowOverride bool, source Source) error {
 	fileName := name
 	name = t.mangler.ToJSONName(strings.TrimSuffix(name, ".gotmpl"))
 
@@ -219,10 +369,19 @@ func (t *Repository) addFile(name, data string, allowOverride bool) error {
 		}
 	}
 
+	t.mux.Lock()
+	defer t.mux.Unlock()
+
+	t.sums[fileName] = sha256.Sum256([]byte(data))
+	clear(t.resolved)
+
 	// Add each defined template into the cache
 	for _, template := range templ.Templates() {
 		t.files[template.Name()] = fileName
 		t.templates[template.Name()] = template.Lookup(template.Name())
+
+		source.Name = template.Name()
+		t.sources[template.Name()] = source
 	}
 
 	return nil
@@ -7,7 +7,6 @@ package repo
 
 import (
 	"fmt"
-	"log"
 	"plugin"
 	"text/template"
 )
@@ -19,7 +18,7 @@ import (
 // which can add any number of functions to the template repository funcMap.
 // Any existing sprig or go-swagger templates with the same name will be overridden.
 func (t *Repository) LoadPlugin(pluginPath string) error {
-	log.Printf("Attempting to load template plugin: %s", pluginPath)
+	t.logf("Attempting to load template plugin: %s", pluginPath)
 
 	p, err := plugin.Open(pluginPath)
 	if err != nil {
@@ -5,6 +5,7 @@ package repo
 
 import (
 	"bytes"
+	"fmt"
 	"os"
 	"path/filepath"
 	"testing"
@@ -61,6 +62,27 @@ func TestLoadDir_Success(t *testing.T) {
 	require.NotNil(t, tmpl)
 }
 
+func TestLoadPack(t *testing.T) {
+	repo := NewRepository(nil)
+	repo.SetProtectedTemplates(map[string]bool{
+		"myProtected": true,
+	})
+
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(
+		filepath.Join(dir, "test.gotmpl"),
+		[]byte(`{{ define "myProtected" }}hello{{ end }}`),
+		0o600,
+	))
+
+	require.NoError(t, repo.LoadPack(dir))
+
+	_, source, ok := repo.Lookup("myProtected")
+	require.True(t, ok)
+	assert.EqualT(t, OriginPack, source.Origin)
+	assert.EqualT(t, filepath.Join(dir, "test.gotmpl"), source.File)
+}
+
 func TestSetAllowOverride(t *testing.T) {
 	repo := NewRepository(nil)
 	repo.SetProtectedTemplates(map[string]bool{
@@ -68,7 +90,7 @@ func TestSetAllowOverride(t *testing.T) {
 	})
 
 	// Seed the repo with the protected template
-	err := repo.addFile("secret.gotmpl", "original", true)
+	err := repo.addFile("secret.gotmpl", "original", true, Source{File: "secret.gotmpl", Origin: OriginEmbedded})
 	require.NoError(t, err)
 
 	// Without allowOverride, adding a file that redefines "secret" fails
@@ -160,8 +182,12 @@ func TestLoadContrib(t *testing.T) {
 		},
 	}
 
+	var logs []string
+	repo.SetLogger(func(format string, args ...any) { logs = append(logs, fmt.Sprintf(format, args...)) })
+
 	err := repo.LoadContrib("mycontrib", provider)
 	require.NoError(t, err)
+	assert.Contains(t, logs, "loading contrib mycontrib")
 
 	_, err = repo.Get("model")
 	require.NoError(t, err)
@@ -513,3 +539,126 @@ func TestTemplates_RepoRecursiveTemplates(t *testing.T) {
 		assert.EqualT(t, expected, b.String())
 	})
 }
+
+func TestFingerprint(t *testing.T) {
+	repo := NewRepository(nil)
+	require.NoError(t, repo.AddFile("a.gotmpl", "first"))
+	require.NoError(t, repo.AddFile("b.gotmpl", "second"))
+
+	other := NewRepository(nil)
+	require.NoError(t, other.AddFile("b.gotmpl", "second"))
+	require.NoError(t, other.AddFile("a.gotmpl", "first"))
+
+	t.Run("should not depend on loading order", func(t *testing.T) {
+		assert.Equal(t, repo.Fingerprint(), other.Fingerprint())
+	})
+
+	t.Run("should be preserved by a clone", func(t *testing.T) {
+		assert.Equal(t, repo.Fingerprint(), repo.ShallowClone().Fingerprint())
+	})
+
+	t.Run("should change when a template source changes", func(t *testing.T) {
+		require.NoError(t, other.AddFile("a.gotmpl", "changed"))
+		assert.NotEqual(t, repo.Fingerprint(), other.Fingerprint())
+	})
+}
+
+func TestGet_Concurrent(t *testing.T) {
+	repo := NewRepository(nil)
+	require.NoError(t, repo.AddFile("base", `{{ template "sub" . }}`))
+	require.NoError(t, repo.AddFile("sub", `{{ define "sub" }}hello {{ . }}{{ end }}`))
+
+	const workers = 8
+	errs := make(chan error, workers)
+	for i := range workers {
+		go func() {
+			tmpl, err := repo.Get("base")
+			if err != nil {
+				errs <- err
+
+				return
+			}
+
+			var buf bytes.Buffer
+			errs <- tmpl.Execute(&buf, i)
+		}()
+	}
+
+	for range workers {
+		require.NoError(t, <-errs)
+	}
+}
+
+func TestGet_InvalidatedByAddFile(t *testing.T) {
+	repo := NewRepository(nil)
+	require.NoError(t, repo.AddFile("base", `{{ template "sub" }}`))
+	require.NoError(t, repo.AddFile("sub", `first`))
+
+	tmpl, err := repo.Get("base")
+	require.NoError(t, err)
+	var buf bytes.Buffer
+	require.NoError(t, tmpl.Execute(&buf, nil))
+	assert.EqualT(t, "first", buf.String())
+
+	require.NoError(t, repo.AddFile("sub", `second`))
+	tmpl, err = repo.Get("sub")
+	require.NoError(t, err)
+	buf.Reset()
+	require.NoError(t, tmpl.Execute(&buf, nil))
+	assert.EqualT(t, "second", buf.String())
+}
+
+func TestDescribe(t *testing.T) {
+	repo := NewRepository(nil)
+	repo.SetProtectedTemplates(map[string]bool{"header": true})
+	require.NoError(t, repo.LoadDefaults(map[string][]byte{
+		"server/operation.gotmpl": []byte(`{{ template "header" }}{{ template "validator" }}`),
+		"header.gotmpl":           []byte(`header`),
+		"validator.gotmpl":        []byte(`{{ template "primitive" }}`),
+		"primitive.gotmpl":        []byte(`primitive`),
+	}))
+
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "validator.gotmpl"), []byte(`{{ template "primitive" }}{{ template "undefined" }}`), 0o600))
+	require.NoError(t, repo.LoadDir(dir))
+
+	source, includes, err := repo.Describe("serverOperation")
+	require.NoError(t, err)
+	assert.Equal(t, Source{Name: "serverOperation", File: "server/operation.gotmpl", Origin: OriginEmbedded}, source)
+	assert.Equal(t, []Source{
+		{Name: "header", File: "header.gotmpl", Origin: OriginEmbedded, Protected: true},
+		{Name: "primitive", File: "primitive.gotmpl", Origin: OriginEmbedded},
+		{Name: "undefined"},
+		{Name: "validator", File: filepath.Join(dir, "validator.gotmpl"), Origin: OriginTemplateDir},
+	}, includes)
+
+	t.Run("should fail on an unknown template", func(t *testing.T) {
+		_, _, err := repo.Describe("missing")
+		require.Error(t, err)
+	})
+}
+
+func TestLookup(t *testing.T) {
+	repo := NewRepository(nil)
+	repo.SetProtectedTemplates(map[string]bool{"header": true})
+	require.NoError(t, repo.LoadDefaults(map[string][]byte{
+		"server/operation.gotmpl": []byte(`{{ template "header" }}{{ define "inner" }}inner{{ end }}`),
+		"header.gotmpl":           []byte(`header`),
+	}))
+
+	assert.Equal(t, []string{"header", "inner", "serverOperation"}, repo.Names())
+
+	templ, source, ok := repo.Lookup("inner")
+	require.True(t, ok)
+	assert.EqualT(t, "inner", templ.Name())
+	assert.Equal(t, Source{Name: "inner", File: "server/operation.gotmpl", Origin: OriginEmbedded}, source)
+
+	_, source, ok = repo.Lookup("header")
+	require.True(t, ok)
+	assert.True(t, source.Protected)
+
+	t.Run("should not find an unknown template", func(t *testing.T) {
+		_, _, ok := repo.Lookup("missing")
+		assert.False(t, ok)
+	})
+}
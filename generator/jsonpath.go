@@ -0,0 +1,138 @@
+// SPDX-FileCopyrightText: Copyright 2015-2025 go-swagger maintainers
+// SPDX-License-Identifier: Apache-2.0
+
+package generator
+
+import (
+	"errors"
+	"fmt"
+	"maps"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// jsonPath is a compiled path into dynamic JSON data (maps, slices and scalars).
+//
+// It supports the small subset of JSONPath needed to explore the data of templates:
+//
+//	$          the root
+//	.name      a member
+//	[0]        an element
+//	.*, [*]    all members, ordered by name, or all elements
+type jsonPath []pathSegment
+
+// pathSegment selects a member or an element, or all of them when neither is set.
+type pathSegment struct {
+	name  *string
+	index *int
+}
+
+var errInvalidPath = errors.New("invalid JSONPath")
+
+// compileJSONPath parses a path, starting with "$".
+func compileJSONPath(expr string) (jsonPath, error) {
+	invalid := func(reason string) error {
+		return fmt.Errorf("%w %q: %s", errInvalidPath, expr, reason)
+	}
+
+	rest, ok := strings.CutPrefix(expr, "$")
+	if !ok {
+		return nil, invalid("it must start with $")
+	}
+
+	var path jsonPath
+	for rest != "" {
+		var segment pathSegment
+
+		switch rest[0] {
+		case '.':
+			end := strings.IndexAny(rest[1:], ".[") + 1
+			if end == 0 {
+				end = len(rest)
+			}
+			name := rest[1:end]
+			rest = rest[end:]
+
+			if name == "" {
+				return nil, invalid("a member name is expected after .")
+			}
+			if name != "*" {
+				segment.name = &name
+			}
+		case '[':
+			end := strings.IndexByte(rest, ']')
+			if end < 0 {
+				return nil, invalid("] is expected")
+			}
+			inner := rest[1:end]
+			rest = rest[end+1:]
+
+			if inner != "*" {
+				index, err := strconv.Atoi(inner)
+				if err != nil || index < 0 {
+					return nil, invalid(fmt.Sprintf("an index or * is expected in [%s]", inner))
+				}
+				segment.index = &index
+			}
+		default:
+			return nil, invalid(fmt.Sprintf("unexpected %q", rest))
+		}
+
+		path = append(path, segment)
+	}
+
+	return path, nil
+}
+
+// eval returns the nodes of the data selected by the path.
+func (path jsonPath) eval(data any) []any {
+	nodes := []any{data}
+	for _, segment := range path {
+		var selected []any
+		for _, node := range nodes {
+			selected = append(selected, segment.apply(node)...)
+		}
+		nodes = selected
+	}
+
+	return nodes
+}
+
+func (s pathSegment) apply(node any) []any {
+	switch {
+	case s.name != nil:
+		if obj, ok := node.(map[string]any); ok {
+			if value, found := obj[*s.name]; found {
+				return []any{value}
+			}
+		}
+
+		return nil
+	case s.index != nil:
+		if arr, ok := node.([]any); ok && *s.index < len(arr) {
+			return []any{arr[*s.index]}
+		}
+
+		return nil
+	default:
+		return children(node)
+	}
+}
+
+// children lists the members of an object, ordered by name, or the elements of an array.
+func children(node any) []any {
+	switch value := node.(type) {
+	case map[string]any:
+		nodes := make([]any, 0, len(value))
+		for _, key := range slices.Sorted(maps.Keys(value)) {
+			nodes = append(nodes, value[key])
+		}
+
+		return nodes
+	case []any:
+		return value
+	default:
+		return nil
+	}
+}
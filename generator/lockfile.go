@@ -0,0 +1,178 @@
+// SPDX-FileCopyrightText: Copyright 2015-2025 go-swagger maintainers
+// SPDX-License-Identifier: Apache-2.0
+
+package generator
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// lockFile is the file name of the record of the last generation, at the root of the target.
+//
+// Unlike the state kept in the hidden directory of the target, it is not specific to a machine.
+const lockFile = ".swagger-lock.json"
+
+// Lockfile records how a target directory was generated, so the generation can be replayed.
+//
+// It is written into the target at every generation, and is meant to be committed with the generated code.
+type Lockfile struct {
+	Generator    string              `json:"generator"`               // version of go-swagger
+	Command      string              `json:"command"`                 // generate command, e.g. "server"
+	Options      map[string][]string `json:"options"`                 // options of the command, by long name. Files are relative to the target
+	Spec         LockedSpec          `json:"spec"`                    // the generated spec
+	TemplatePack *LockedTemplatePack `json:"template_pack,omitempty"` // the template pack, if any
+}
+
+// LockedSpec identifies the spec of a generation.
+type LockedSpec struct {
+	Path   string `json:"path,omitempty"`   // relative to the target, or a URL
+	SHA256 string `json:"sha256,omitempty"` // digest of the spec document, for a local spec
+}
+
+// LockedTemplatePack identifies the template pack of a generation.
+type LockedTemplatePack struct {
+	Ref     string `json:"ref"` // the template pack, as given in the options
+	Name    string `json:"name"`
+	Version string `json:"version,omitempty"`
+}
+
+// NewLockfile records a generation run by some command, with its options.
+func (g *GenOpts) NewLockfile(command string, options map[string][]string) (*Lockfile, error) {
+	lock := &Lockfile{
+		Generator: generatorVersion(),
+		Command:   command,
+		Options:   options,
+	}
+
+	spec, err := g.lockedSpec()
+	if err != nil {
+		return nil, err
+	}
+	lock.Spec = spec
+
+	if pack := g.LoadedTemplatePack(); pack != nil {
+		lock.TemplatePack = &LockedTemplatePack{Ref: g.TemplatePack, Name: pack.Name, Version: pack.Version}
+	}
+
+	return lock, nil
+}
+
+// lockedSpec identifies the spec of the options. Without a spec, the default spec is searched like for a generation:
+// there may be none, when several specs are generated from a configuration file.
+func (g *GenOpts) lockedSpec() (LockedSpec, error) {
+	if strings.HasPrefix(g.Spec, "http://") || strings.HasPrefix(g.Spec, "https://") {
+		return LockedSpec{Path: g.Spec}, nil
+	}
+
+	specPath, err := findSwaggerSpec(g.Spec)
+	if err != nil {
+		if g.Spec == "" {
+			return LockedSpec{}, nil
+		}
+
+		return LockedSpec{}, err
+	}
+
+	digest, err := specDigest(specPath)
+	if err != nil {
+		return LockedSpec{}, err
+	}
+
+	spec, err := relativeTo(g.Target, specPath)
+	if err != nil {
+		return LockedSpec{}, err
+	}
+
+	return LockedSpec{Path: spec, SHA256: digest}, nil
+}
+
+// WriteLockfile writes the record of a generation into the target.
+func (g *GenOpts) WriteLockfile(lock *Lockfile) error {
+	buf, err := json.MarshalIndent(lock, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	if err := g.fs().MkdirAll(g.Target, readAllDir); err != nil {
+		return err
+	}
+
+	return g.fs().WriteFile(filepath.Join(g.Target, lockFile), append(buf, '\n'), readAllFile)
+}
+
+// ReadLockfile reads the record of the last generation of a target directory.
+func ReadLockfile(target string) (*Lockfile, error) {
+	pth := filepath.Join(target, lockFile)
+	buf, err := os.ReadFile(pth)
+	if err != nil {
+		return nil, fmt.Errorf("no generation is recorded in %s: %w", target, err)
+	}
+
+	var lock Lockfile
+	if err := json.Unmarshal(buf, &lock); err != nil {
+		return nil, fmt.Errorf("invalid lockfile %s: %w", pth, err)
+	}
+
+	if lock.Command == "" {
+		return nil, fmt.Errorf("invalid lockfile %s: the command is missing", pth)
+	}
+
+	return &lock, nil
+}
+
+// CheckGenerator tells if the generation was recorded with another version of the generator.
+func (l *Lockfile) CheckGenerator() error {
+	if installed := generatorVersion(); installed != l.Generator {
+		return fmt.Errorf("the generation was recorded with go-swagger %s, but go-swagger %s is installed", l.Generator, installed)
+	}
+
+	return nil
+}
+
+// SpecChanged tells if the spec of a recorded generation has changed since.
+func (l *Lockfile) SpecChanged(target string) bool {
+	if l.Spec.SHA256 == "" {
+		return false
+	}
+
+	digest, err := specDigest(filepath.Join(target, filepath.FromSlash(l.Spec.Path)))
+
+	return err != nil || digest != l.Spec.SHA256
+}
+
+func specDigest(specPath string) (string, error) {
+	buf, err := os.ReadFile(specPath)
+	if err != nil {
+		return "", err
+	}
+
+	sum := sha256.Sum256(buf)
+
+	return hex.EncodeToString(sum[:]), nil
+}
+
+// relativeTo expresses a file name relative to the target, with forward slashes.
+func relativeTo(target, file string) (string, error) {
+	absTarget, err := filepath.Abs(target)
+	if err != nil {
+		return "", err
+	}
+
+	absFile, err := filepath.Abs(file)
+	if err != nil {
+		return "", err
+	}
+
+	rel, err := filepath.Rel(absTarget, absFile)
+	if err != nil {
+		return "", err
+	}
+
+	return filepath.ToSlash(rel), nil
+}
@@ -0,0 +1,70 @@
+// SPDX-FileCopyrightText: Copyright 2015-2025 go-swagger maintainers
+// SPDX-License-Identifier: Apache-2.0
+
+package generator
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/go-openapi/testify/v2/assert"
+	"github.com/go-openapi/testify/v2/require"
+)
+
+func TestLockfile(t *testing.T) {
+	t.Run("should record a generation in the target", func(t *testing.T) {
+		opts := testGenOpts()
+		opts.Spec = "../fixtures/codegen/todolist.models.yml"
+		opts.Target = t.TempDir()
+
+		lock, err := opts.NewLockfile("model", map[string][]string{"name": {"Pet"}})
+		require.NoError(t, err)
+		require.NoError(t, opts.WriteLockfile(lock))
+		require.FileExists(t, filepath.Join(opts.Target, ".swagger-lock.json"))
+
+		read, err := ReadLockfile(opts.Target)
+		require.NoError(t, err)
+		assert.Equal(t, lock, read)
+		assert.EqualT(t, generatorVersion(), read.Generator)
+		assert.Len(t, read.Spec.SHA256, 64)
+		require.NoError(t, read.CheckGenerator())
+
+		spec, err := filepath.Abs(opts.Spec)
+		require.NoError(t, err)
+		assert.EqualT(t, spec, filepath.Join(opts.Target, filepath.FromSlash(read.Spec.Path)))
+	})
+
+	t.Run("should warn about another version of the generator", func(t *testing.T) {
+		lock := &Lockfile{Generator: "v0.1.0", Command: "server"}
+		err := lock.CheckGenerator()
+		require.Error(t, err)
+		assert.StringContainsT(t, err.Error(), "the generation was recorded with go-swagger v0.1.0")
+	})
+
+	t.Run("should not record the digest of a remote spec", func(t *testing.T) {
+		opts := testGenOpts()
+		opts.Spec = "https://example.com/swagger.json"
+		opts.Target = t.TempDir()
+
+		lock, err := opts.NewLockfile("client", nil)
+		require.NoError(t, err)
+		assert.Equal(t, LockedSpec{Path: opts.Spec}, lock.Spec)
+		assert.FalseT(t, lock.SpecChanged(opts.Target))
+	})
+
+	t.Run("should fail without a recorded generation", func(t *testing.T) {
+		_, err := ReadLockfile(t.TempDir())
+		require.Error(t, err)
+		assert.StringContainsT(t, err.Error(), "no generation is recorded in")
+	})
+
+	t.Run("should fail with an invalid lockfile", func(t *testing.T) {
+		target := t.TempDir()
+		require.NoError(t, os.WriteFile(filepath.Join(target, lockFile), []byte(`{"options":{}}`), readableFile))
+
+		_, err := ReadLockfile(target)
+		require.Error(t, err)
+		assert.StringContainsT(t, err.Error(), "the command is missing")
+	})
+}
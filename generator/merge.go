@@ -0,0 +1,216 @@
+// SPDX-FileCopyrightText: Copyright 2015-2025 go-swagger maintainers
+// SPDX-License-Identifier: Apache-2.0
+
+package generator
+
+import (
+	"bytes"
+	"errors"
+	"io/fs"
+	"path/filepath"
+	"slices"
+	"strings"
+)
+
+const (
+	// pristineDir is the directory in the cache directory where the last generated content of files edited by users is kept.
+	pristineDir = "pristine"
+
+	conflictEdited    = "<<<<<<< edited\n"
+	conflictSeparator = "=======\n"
+	conflictGenerated = ">>>>>>> generated\n"
+)
+
+// mergeEdits merges the edits of a generated file with its new generated content.
+//
+// This is a three-way merge, line by line, from the pristine content generated previously:
+// changes on one side only are applied, and conflict markers are inserted where both sides changed
+// the same lines differently. It returns the merged content and the number of conflicts.
+func mergeEdits(pristine, edited, generated []byte) ([]byte, int) {
+	base, ours, theirs := splitLines(pristine), splitLines(edited), splitLines(generated)
+	matchOurs, matchTheirs := matchLines(base, ours), matchLines(base, theirs)
+
+	var (
+		merged    bytes.Buffer
+		conflicts int
+		o, a, b   int // positions in base, ours and theirs
+	)
+
+	for {
+		// stable lines, unchanged on both sides
+		for o < len(base) && matchOurs[o] == a && matchTheirs[o] == b {
+			merged.WriteString(base[o])
+			o++
+			a++
+			b++
+		}
+
+		if o == len(base) && a == len(ours) && b == len(theirs) {
+			break
+		}
+
+		// the chunk up to the next line unchanged on both sides
+		next, nextA, nextB := o, len(ours), len(theirs)
+		for next < len(base) && (matchOurs[next] < 0 || matchTheirs[next] < 0) {
+			next++
+		}
+		if next < len(base) {
+			nextA, nextB = matchOurs[next], matchTheirs[next]
+		}
+
+		chunkBase, chunkOurs, chunkTheirs := base[o:next], ours[a:nextA], theirs[b:nextB]
+		switch {
+		case slices.Equal(chunkOurs, chunkBase):
+			writeLines(&merged, chunkTheirs, false)
+		case slices.Equal(chunkTheirs, chunkBase), slices.Equal(chunkOurs, chunkTheirs):
+			writeLines(&merged, chunkOurs, false)
+		default:
+			conflicts++
+			merged.WriteString(conflictEdited)
+			writeLines(&merged, chunkOurs, true)
+			merged.WriteString(conflictSeparator)
+			writeLines(&merged, chunkTheirs, true)
+			merged.WriteString(conflictGenerated)
+		}
+
+		o, a, b = next, nextA, nextB
+	}
+
+	return merged.Bytes(), conflicts
+}
+
+// splitLines splits some content in lines, with their line ending.
+func splitLines(content []byte) []string {
+	lines := strings.SplitAfter(string(content), "\n")
+	if lines[len(lines)-1] == "" {
+		lines = lines[:len(lines)-1]
+	}
+
+	return lines
+}
+
+// writeLines writes some lines. Only the last line of a file may have no line ending:
+// with terminated, a line ending is added, for lines followed by a conflict marker.
+func writeLines(w *bytes.Buffer, lines []string, terminated bool) {
+	for _, line := range lines {
+		w.WriteString(line)
+	}
+
+	if terminated && len(lines) > 0 && !strings.HasSuffix(lines[len(lines)-1], "\n") {
+		w.WriteByte('\n')
+	}
+}
+
+// matchLines matches the lines of a with the lines of b, along a longest common subsequence.
+//
+// It returns, for each line of a, the index of the matching line in b, or -1.
+func matchLines(a, b []string) []int {
+	matches := make([]int, len(a))
+	for i := range matches {
+		matches[i] = -1
+	}
+
+	// common prefix and suffix are matched upfront, to keep the table small
+	prefix := 0
+	for prefix < len(a) && prefix < len(b) && a[prefix] == b[prefix] {
+		matches[prefix] = prefix
+		prefix++
+	}
+
+	suffix := 0
+	for suffix < len(a)-prefix && suffix < len(b)-prefix && a[len(a)-1-suffix] == b[len(b)-1-suffix] {
+		matches[len(a)-1-suffix] = len(b) - 1 - suffix
+		suffix++
+	}
+
+	midA, midB := a[prefix:len(a)-suffix], b[prefix:len(b)-suffix]
+	if len(midA) == 0 || len(midB) == 0 {
+		return matches
+	}
+
+	// lengths[i][j] is the length of the longest common subsequence of midA[i:] and midB[j:]
+	width := len(midB) + 1
+	lengths := make([]int32, (len(midA)+1)*width)
+	for i := len(midA) - 1; i >= 0; i-- {
+		for j := len(midB) - 1; j >= 0; j-- {
+			switch {
+			case midA[i] == midB[j]:
+				lengths[i*width+j] = lengths[(i+1)*width+j+1] + 1
+			case lengths[(i+1)*width+j] >= lengths[i*width+j+1]:
+				lengths[i*width+j] = lengths[(i+1)*width+j]
+			default:
+				lengths[i*width+j] = lengths[i*width+j+1]
+			}
+		}
+	}
+
+	for i, j := 0, 0; i < len(midA) && j < len(midB); {
+		switch {
+		case midA[i] == midB[j]:
+			matches[prefix+i] = prefix + j
+			i++
+			j++
+		case lengths[(i+1)*width+j] >= lengths[i*width+j+1]:
+			i++
+		default:
+			j++
+		}
+	}
+
+	return matches
+}
+
+// pristinePath is the location of the last generated content of a file, in the hidden directory of the target.
+//
+// Files outside of the target have no pristine copy.
+func (g *GenOpts) pristinePath(file string) (string, bool) {
+	target, err := filepath.Abs(g.Target)
+	if err != nil {
+		return "", false
+	}
+
+	abs, err := filepath.Abs(file)
+	if err != nil {
+		return "", false
+	}
+
+	rel, err := filepath.Rel(target, abs)
+	if err != nil || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+		return "", false
+	}
+
+	return filepath.Join(target, cacheDir, pristineDir, rel), true
+}
+
+// readPristine reads the last generated content of a file.
+func (g *GenOpts) readPristine(file string) ([]byte, bool) {
+	pth, ok := g.pristinePath(file)
+	if !ok {
+		return nil, false
+	}
+
+	content, err := g.fs().ReadFile(pth)
+	if err != nil {
+		if !errors.Is(err, fs.ErrNotExist) {
+			g.warnf("could not read the pristine copy of %s: %v", file, err)
+		}
+
+		return nil, false
+	}
+
+	return content, true
+}
+
+// writePristine keeps the generated content of a file, to merge the edits of users at the next generation.
+func (g *GenOpts) writePristine(file string, content []byte) error {
+	pth, ok := g.pristinePath(file)
+	if !ok {
+		return nil
+	}
+
+	if err := g.fs().MkdirAll(filepath.Dir(pth), readAllDir); err != nil {
+		return err
+	}
+
+	return g.fs().WriteFile(pth, content, readAllFile)
+}
@@ -0,0 +1,142 @@
+// SPDX-FileCopyrightText: Copyright 2015-2025 go-swagger maintainers
+// SPDX-License-Identifier: Apache-2.0
+
+package generator
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/go-openapi/testify/v2/assert"
+	"github.com/go-openapi/testify/v2/require"
+)
+
+func TestMergeEdits(t *testing.T) {
+	const pristine = "package a\n\nfunc A() {}\n\nfunc B() {}\n\nfunc C() {}\n"
+
+	for _, tc := range []struct {
+		name      string
+		edited    string
+		generated string
+		expected  string
+		conflicts int
+	}{
+		{
+			name:      "should keep the edits when the generated content is unchanged",
+			edited:    "package a\n\nfunc A() { edited() }\n\nfunc B() {}\n\nfunc C() {}\n",
+			generated: pristine,
+			expected:  "package a\n\nfunc A() { edited() }\n\nfunc B() {}\n\nfunc C() {}\n",
+		},
+		{
+			name:      "should take the new content when not edited",
+			edited:    pristine,
+			generated: "package a\n\nfunc A() {}\n\nfunc C() {}\n",
+			expected:  "package a\n\nfunc A() {}\n\nfunc C() {}\n",
+		},
+		{
+			name:      "should merge changes to different lines",
+			edited:    "package a\n\nfunc A() { edited() }\n\nfunc B() {}\n\nfunc C() {}\n",
+			generated: "package a\n\nfunc A() {}\n\nfunc B() {}\n\nfunc C() {}\n\nfunc D() {}\n",
+			expected:  "package a\n\nfunc A() { edited() }\n\nfunc B() {}\n\nfunc C() {}\n\nfunc D() {}\n",
+		},
+		{
+			name:      "should merge identical changes",
+			edited:    "package a\n\nfunc A() {}\n\nfunc B() { same() }\n\nfunc C() {}\n",
+			generated: "package a\n\nfunc A() {}\n\nfunc B() { same() }\n\nfunc C() {}\n",
+			expected:  "package a\n\nfunc A() {}\n\nfunc B() { same() }\n\nfunc C() {}\n",
+		},
+		{
+			name:      "should mark conflicting changes only",
+			edited:    "package a\n\nfunc A() { edited() }\n\nfunc B() { edited() }\n\nfunc C() {}\n",
+			generated: "package a\n\nfunc A() {}\n\nfunc B() { generated() }\n\nfunc C() {}\n",
+			expected: "package a\n\nfunc A() { edited() }\n\n" +
+				conflictEdited + "func B() { edited() }\n" + conflictSeparator + "func B() { generated() }\n" + conflictGenerated +
+				"\nfunc C() {}\n",
+			conflicts: 1,
+		},
+		{
+			name:      "should merge content without a final line ending",
+			edited:    "package a\n\nfunc A() {}\n\nfunc B() {}\n\nfunc C() {}",
+			generated: "package a\n\nfunc A() { generated() }\n\nfunc B() {}\n\nfunc C() {}\n",
+			expected:  "package a\n\nfunc A() { generated() }\n\nfunc B() {}\n\nfunc C() {}",
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			merged, conflicts := mergeEdits([]byte(pristine), []byte(tc.edited), []byte(tc.generated))
+			assert.EqualT(t, tc.expected, string(merged))
+			assert.EqualT(t, tc.conflicts, conflicts)
+		})
+	}
+}
+
+func TestGenerateServer_MergeEdits(t *testing.T) {
+	defer discardOutput()()
+
+	const spec = `swagger: "2.0"
+info:
+  title: merge
+  version: "1.0"
+paths:
+  /pets:
+    get:
+      operationId: listPets
+      responses:
+        200:
+          description: ok
+`
+
+	target := t.TempDir()
+	specFile := filepath.Join(target, "swagger.yml")
+	require.NoError(t, os.WriteFile(specFile, []byte(spec), readableFile))
+	require.NoError(t, os.WriteFile(filepath.Join(target, "go.mod"), []byte("module merge\n"), readableFile))
+
+	generate := func() []Event {
+		var events []Event
+		opts := testGenOpts()
+		opts.Spec = specFile
+		opts.Target = target
+		opts.MergeEdits = true
+		opts.Events = func(e Event) {
+			events = append(events, e)
+		}
+		require.NoError(t, GenerateServer("", nil, nil, opts))
+
+		return events
+	}
+
+	generate()
+	configure := filepath.Join(target, "restapi", "configure_merge.go")
+	content, err := os.ReadFile(configure)
+	require.NoError(t, err)
+	require.StringContainsT(t, string(content), "api.UseSwaggerUI()")
+
+	// the user edits the configuration, then adds an operation to the spec
+	edited := strings.Replace(string(content), "api.UseSwaggerUI()", "api.UseRedoc()", 1)
+	require.NoError(t, os.WriteFile(configure, []byte(edited), readableFile))
+	require.NoError(t, os.WriteFile(specFile, []byte(spec+`    post:
+      operationId: addPet
+      responses:
+        201:
+          description: created
+`), readableFile))
+
+	events := generate()
+
+	var merged bool
+	for _, e := range events {
+		if e.Kind == EventFileWritten && e.Path == configure {
+			merged = e.Merged
+			assert.EqualT(t, 0, e.Conflicts)
+		}
+	}
+	assert.TrueT(t, merged)
+
+	content, err = os.ReadFile(configure)
+	require.NoError(t, err)
+	assert.StringContainsT(t, string(content), "api.UseRedoc()")
+	assert.StringNotContainsT(t, string(content), "api.UseSwaggerUI()")
+	assert.StringContainsT(t, string(content), "api.AddPetHandler")
+	assert.StringNotContainsT(t, string(content), conflictEdited)
+}
@@ -6,7 +6,6 @@ package generator
 import (
 	"errors"
 	"fmt"
-	"log"
 	"maps"
 	"os"
 	"path"
@@ -19,7 +18,6 @@ import (
 	"github.com/go-openapi/analysis"
 	"github.com/go-openapi/loads"
 	"github.com/go-openapi/spec"
-	"github.com/go-openapi/swag/jsonutils"
 	"github.com/go-openapi/swag/mangling"
 )
 
@@ -42,6 +40,8 @@ Every action that happens tracks the path which is a linked list of refs
 
 // GenerateModels generates all model files for some schema definitions.
 func GenerateModels(modelNames []string, opts *GenOpts) error {
+	defer opts.beginGeneration()()
+
 	// overide any default or incompatible options setting
 	opts.IncludeModel = true
 	opts.IgnoreOperations = true
@@ -59,6 +59,8 @@ func GenerateModels(modelNames []string, opts *GenOpts) error {
 
 // GenerateDefinition generates a single model file for some schema definitions.
 func GenerateDefinition(modelNames []string, opts *GenOpts) error {
+	defer opts.beginGeneration()()
+
 	if err := opts.CheckOpts(); err != nil {
 		return err
 	}
@@ -67,11 +69,16 @@ func GenerateDefinition(modelNames []string, opts *GenOpts) error {
 		return err
 	}
 
+	specPath := opts.Spec
 	specDoc, _, err := opts.analyzeSpec()
 	if err != nil {
 		return err
 	}
 
+	if opts.packages, err = opts.planModelPackages(specPath, specDoc); err != nil {
+		return err
+	}
+
 	modelNames = pruneEmpty(modelNames)
 	if len(modelNames) == 0 {
 		for k := range specDoc.Spec().Definitions {
@@ -120,21 +127,16 @@ func (m *definitionGenerator) Generate() error {
 	}
 
 	if m.opts.DumpData {
-		var dynamicMod any
-		if err := jsonutils.FromDynamicJSON(mod, &dynamicMod); err != nil {
-			return err
-		}
-
-		return dumpData(os.Stdout, dynamicMod)
+		return m.opts.dumpData(os.Stdout, mod)
 	}
 
 	if m.opts.IncludeModel {
-		log.Println("including additional model")
+		m.opts.logf("including additional model")
 		if err := m.generateModel(mod); err != nil {
 			return fmt.Errorf("could not generate model: %w", err)
 		}
 	}
-	log.Println("generated model", m.Name)
+	m.opts.logf("generated model %s", m.Name)
 
 	return nil
 }
@@ -157,13 +159,13 @@ func makeGenDefinition(name, pkg string, schema spec.Schema, specDoc *loads.Docu
 		//
 		// We do this at the top level because of the possibility of aliased types which always bubble up validation to types which
 		// are referring to them. This results in correct but inelegant code with empty validations.
-		gd.HasValidations = shallowValidationLookup(gd.GenSchema)
+		gd.HasValidations = shallowValidationLookup(gd.GenSchema, opts.warnf)
 	}
 	return gd, err
 }
 
 //nolint:gocognit,gocyclo,cyclop // TODO(fredbi): refactor
-func shallowValidationLookup(sch GenSchema) bool {
+func shallowValidationLookup(sch GenSchema, warnf func(string, ...any)) bool {
 	// scan top level need for validations
 	//
 	// NOTE: this supersedes the previous NeedsValidation flag
@@ -173,7 +175,7 @@ func shallowValidationLookup(sch GenSchema) bool {
 	// The latter was almost not used anyhow.
 
 	if sch.HasAdditionalProperties && sch.AdditionalProperties == nil {
-		log.Printf("warning: schema for additional properties in schema %q is empty. skipped", sch.Name)
+		warnf("schema for additional properties in schema %q is empty. skipped", sch.Name)
 	}
 
 	if sch.IsArray && sch.HasValidations {
@@ -220,8 +222,8 @@ func shallowValidationLookup(sch GenSchema) bool {
 	return false
 }
 
-func isExternal(schema spec.Schema) bool {
-	extType, ok := hasExternalType(schema.Extensions)
+func isExternal(schema spec.Schema, warnf func(string, ...any)) bool {
+	extType, ok := hasExternalType(schema.Extensions, warnf)
 	return ok && !extType.Embedded
 }
 
@@ -229,9 +231,17 @@ func isExternal(schema spec.Schema) bool {
 func makeGenDefinitionHierarchy(name, pkg, container string, schema spec.Schema, specDoc *loads.Document, opts *GenOpts) (*GenDefinition, error) {
 	// Check if model is imported from external package using x-go-type
 	receiver := "m"
+	// models may be split into sub-packages of the models package
+	subPkg, _ := opts.packages.forDefinition(name)
+	var modelPackage string
+	if subPkg != "" {
+		pkg = path.Join(filepath.ToSlash(pkg), subPkg)
+		modelPackage = path.Join(filepath.ToSlash(opts.ModelPackage), subPkg)
+	}
+
 	// models are resolved in the current package
 	modelPkg := opts.LanguageOpts.ManglePackageName(path.Base(filepath.ToSlash(pkg)), "definitions")
-	resolver := newTypeResolver("", specDoc, opts).withDefinitionPackage(modelPkg)
+	resolver := newTypeResolver("", specDoc, opts).withDefinitionPackage(modelPkg).withModelSubPackage(subPkg)
 	resolver.ModelName = name
 	analyzed := analysis.New(specDoc.Spec())
 
@@ -313,7 +323,7 @@ func makeGenDefinitionHierarchy(name, pkg, container string, schema spec.Schema,
 		swsp := specDoc.Spec()
 		for i, ss := range schema.AllOf {
 			if pg.GenSchema.AllOf == nil {
-				log.Printf("warning: resolved schema for subtype %q.AllOf[%d] is empty. skipped", name, i)
+				opts.warnf("resolved schema for subtype %q.AllOf[%d] is empty. skipped", name, i)
 				continue
 			}
 			ref := ss.Ref
@@ -380,19 +390,23 @@ func makeGenDefinitionHierarchy(name, pkg, container string, schema spec.Schema,
 		"validate":    "github.com/go-openapi/validate",
 	}
 
+	imports := findImports(&pg.GenSchema)
+	maps.Copy(imports, opts.packages.imports(subPkg, true))
+
 	return &GenDefinition{
 		GenCommon: GenCommon{
 			Copyright:        opts.Copyright,
 			TargetImportPath: opts.LanguageOpts.BaseImport(opts.Target),
 		},
 		Package:        modelPkg,
+		ModelPackage:   modelPackage,
 		CliPackage:     opts.CliPackage,
 		GenSchema:      pg.GenSchema,
 		DependsOn:      pg.Dependencies,
 		DefaultImports: defaultImports,
-		ExtraSchemas:   gatherExtraSchemas(pg.ExtraSchemas),
-		Imports:        findImports(&pg.GenSchema),
-		External:       isExternal(schema),
+		ExtraSchemas:   gatherExtraSchemas(pg.ExtraSchemas, opts.warnf),
+		Imports:        imports,
+		External:       isExternal(schema, opts.warnf),
 	}, nil
 }
 
@@ -686,7 +700,7 @@ func (sg *schemaGenContext) schemaValidations() sharedValidations {
 		// when readOnly or default is specified, this disables Required validation (Swagger-specific)
 		isRequired = false
 		if sg.Required {
-			log.Printf("warning: properties with a default value or readOnly should not be required [%s]", sg.Name)
+			sg.TypeResolver.warnf("properties with a default value or readOnly should not be required [%s]", sg.Name)
 		}
 	}
 
@@ -869,7 +883,7 @@ func (sg *schemaGenContext) buildProperties() error {
 			if ok {
 				emprop.GenSchema.CustomTag = tagAsStr
 			} else {
-				log.Printf("warning: expect %s extension to be a string, got: %v. Skipped", xGoCustomTag, customTag)
+				sg.TypeResolver.warnf("expect %s extension to be a string, got: %v. Skipped", xGoCustomTag, customTag)
 			}
 		}
 		sg.GenSchema.Properties = append(sg.GenSchema.Properties, emprop.GenSchema)
@@ -970,7 +984,7 @@ func (sg *schemaGenContext) buildAllOf() error {
 	}
 
 	if hasArray > 1 || (hasArray > 0 && hasNonArray > 0) {
-		log.Printf("warning: cannot generate serializable allOf with conflicting array definitions in %s", sg.Container)
+		sg.TypeResolver.warnf("cannot generate serializable allOf with conflicting array definitions in %s", sg.Container)
 	}
 
 	// AllOf types are always considered nullable, except when an extension says otherwise
@@ -1819,7 +1833,7 @@ func (sg *schemaGenContext) makeGenSchema() error {
 			sg.GenSchema.HasValidations = !tpe.IsInterface && !tpe.IsStream && !tpe.SkipExternalValidation
 			sg.GenSchema.IsAliased = sg.GenSchema.HasValidations
 
-			log.Printf("INFO: type %s is external, with inferred spec type %s, referred to as %s", sg.GenSchema.Name, sg.GenSchema.GoType, extType)
+			sg.TypeResolver.logf("type %s is external, with inferred spec type %s, referred to as %s", sg.GenSchema.Name, sg.GenSchema.GoType, extType)
 			sg.GenSchema.GoType = extType
 			sg.GenSchema.AliasedType = extType
 
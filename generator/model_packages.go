@@ -0,0 +1,425 @@
+// SPDX-FileCopyrightText: Copyright 2015-2025 go-swagger maintainers
+// SPDX-License-Identifier: Apache-2.0
+
+package generator
+
+import (
+	"fmt"
+	"path"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/go-openapi/loads"
+	"github.com/go-openapi/spec"
+)
+
+// ModelPackageRule routes definitions into a sub-package of the models package, by the prefix of their name.
+//
+// The longest matching prefix wins. Definitions which match no rule remain in the models package.
+type ModelPackageRule struct {
+	Prefix  string `mapstructure:"prefix"`
+	Package string `mapstructure:"package"` // the sub-package, relative to the models package, e.g. "billing" or "billing/invoices"
+}
+
+// modelPackages knows in which sub-package of the models package each definition is generated.
+//
+// Sub-packages are relative to the models package: the models package itself is "".
+type modelPackages struct {
+	rootAlias    string            // alias of the models package
+	rootImport   string            // import path of the models package
+	byDefinition map[string]string // definition name (or x-go-name) -> sub-package
+	aliases      map[string]string // sub-package -> import alias
+}
+
+// planModelPackages assigns definitions to sub-packages of the models package.
+//
+// A definition is routed according to, by order of precedence:
+//   - its x-go-model-package extension
+//   - the rules for model packages
+//   - the file where it is defined, with SplitModelsByFile
+//
+// It returns nil when all definitions remain in the models package.
+func (g *GenOpts) planModelPackages(specPath string, specDoc *loads.Document) (*modelPackages, error) {
+	var sourceFiles map[string]string
+	if g.SplitModelsByFile {
+		var err error
+		files, err := definitionSourceFiles(specPath)
+		if err != nil {
+			return nil, fmt.Errorf("could not resolve the source files of definitions: %w", err)
+		}
+
+		// definitions imported from other documents are renamed when flattening the spec, e.g. "Money" as "money"
+		sourceFiles = make(map[string]string, len(files))
+		for name, file := range files {
+			sourceFiles[strings.ToLower(name)] = file
+		}
+	}
+
+	definitions := specDoc.Spec().Definitions
+	assigned := make(map[string]string, len(definitions))
+	var split bool
+
+	for _, name := range sortedKeys(definitions) {
+		schema := definitions[name]
+		if isExternal(schema, g.warnf) {
+			continue
+		}
+
+		pkg := g.modelSubPackage(name, schema, sourceFiles)
+		assigned[name] = pkg
+		split = split || pkg != ""
+	}
+
+	if !split {
+		return nil, nil
+	}
+
+	if err := checkModelPackageCycles(definitions, assigned, g.LanguageOpts.ManglePackagePath(g.ModelPackage, defaultModelsTarget)); err != nil {
+		return nil, err
+	}
+
+	packages := &modelPackages{
+		rootAlias:    g.LanguageOpts.ManglePackageName(g.ModelPackage, defaultModelsTarget),
+		byDefinition: make(map[string]string, len(assigned)),
+		aliases:      make(map[string]string),
+	}
+
+	if g.ExistingModels != "" {
+		packages.rootImport = g.LanguageOpts.ManglePackagePath(g.ExistingModels, "")
+	} else {
+		packages.rootImport = path.Join(
+			g.LanguageOpts.BaseImport(g.Target),
+			g.LanguageOpts.ManglePackagePath(g.ModelPackage, defaultModelsTarget),
+		)
+	}
+
+	seenAliases := make(map[string]bool)
+	for _, name := range sortedKeys(assigned) {
+		pkg := assigned[name]
+		packages.byDefinition[name] = pkg
+		if goName, ok := definitions[name].Extensions.GetString(xGoName); ok {
+			packages.byDefinition[goName] = pkg
+		}
+
+		if _, known := packages.aliases[pkg]; pkg == "" || known {
+			continue
+		}
+
+		// sub-packages are imported with an alias such as "billingmodels", which does not conflict
+		// with packages generated for operations
+		alias := g.LanguageOpts.ManglePackageName(path.Base(pkg), "") + packages.rootAlias
+		if seenAliases[alias] {
+			alias = g.LanguageOpts.ManglePackageName(strings.ReplaceAll(pkg, "/", ""), "") + packages.rootAlias
+		}
+		seenAliases[alias] = true
+		packages.aliases[pkg] = alias
+	}
+
+	return packages, nil
+}
+
+// modelSubPackage determines the sub-package of a definition.
+func (g *GenOpts) modelSubPackage(name string, schema spec.Schema, sourceFiles map[string]string) string {
+	if pkg, ok := schema.Extensions.GetString(xGoModelPackage); ok {
+		return cleanSubPackage(pkg)
+	}
+
+	var rule ModelPackageRule
+	for _, candidate := range g.ModelPackageRules {
+		if strings.HasPrefix(name, candidate.Prefix) && len(candidate.Prefix) >= len(rule.Prefix) {
+			rule = candidate
+		}
+	}
+	if rule.Package != "" {
+		return cleanSubPackage(rule.Package)
+	}
+
+	if file, ok := sourceFiles[strings.ToLower(name)]; ok {
+		base := strings.TrimSuffix(filepath.Base(file), filepath.Ext(file))
+
+		return g.LanguageOpts.ManglePackageName(base, "")
+	}
+
+	return ""
+}
+
+func cleanSubPackage(pkg string) string {
+	pkg = path.Clean(strings.Trim(filepath.ToSlash(pkg), "/"))
+	if pkg == "." {
+		return ""
+	}
+
+	return pkg
+}
+
+// forDefinition returns the sub-package where a definition is generated.
+func (p *modelPackages) forDefinition(name string) (string, bool) {
+	if p == nil {
+		return "", false
+	}
+
+	pkg, ok := p.byDefinition[name]
+
+	return pkg, ok
+}
+
+// alias returns the import alias of a sub-package.
+func (p *modelPackages) alias(pkg string) string {
+	if pkg == "" {
+		return p.rootAlias
+	}
+
+	return p.aliases[pkg]
+}
+
+// imports lists the model packages which may be imported by code generated in a sub-package.
+//
+// Unused imports are pruned when formatting the generated code.
+func (p *modelPackages) imports(current string, inModels bool) map[string]string {
+	if p == nil {
+		return nil
+	}
+
+	imports := make(map[string]string, len(p.aliases)+1)
+	if inModels && current != "" {
+		imports[p.rootAlias] = p.rootImport
+	}
+
+	for pkg, alias := range p.aliases {
+		if inModels && pkg == current {
+			continue
+		}
+		imports[alias] = path.Join(p.rootImport, pkg)
+	}
+
+	return imports
+}
+
+// packageDependency is a reference from a definition to a definition in another package.
+type packageDependency struct {
+	from, to string // definitions
+	reason   string
+}
+
+// checkModelPackageCycles verifies that model packages may import each other.
+//
+// Besides $ref's, a base type with a discriminator depends on its subtypes.
+func checkModelPackageCycles(definitions spec.Definitions, assigned map[string]string, rootPackage string) error {
+	graph := make(map[string]map[string]packageDependency)
+	addEdge := func(from, to, reason string) {
+		fromPkg, toPkg := assigned[from], assigned[to]
+		if _, ok := assigned[to]; !ok || fromPkg == toPkg {
+			return
+		}
+
+		if graph[fromPkg] == nil {
+			graph[fromPkg] = make(map[string]packageDependency)
+		}
+		if _, exists := graph[fromPkg][toPkg]; !exists {
+			graph[fromPkg][toPkg] = packageDependency{from: from, to: to, reason: reason}
+		}
+	}
+
+	for _, name := range sortedKeys(definitions) {
+		if _, ok := assigned[name]; !ok {
+			continue
+		}
+		schema := definitions[name]
+
+		for _, ref := range schemaRefs(&schema) {
+			if target, ok := definitionName(ref.GetURL().Fragment); ok {
+				addEdge(name, target, "refers to")
+			}
+		}
+
+		for _, parent := range schema.AllOf {
+			if parent.Ref.String() == "" {
+				continue
+			}
+
+			target, ok := definitionName(parent.Ref.GetURL().Fragment)
+			if !ok {
+				continue
+			}
+
+			if base, isDefined := definitions[target]; isDefined && base.Discriminator != "" {
+				addEdge(target, name, "has the subtype")
+			}
+		}
+	}
+
+	// depth-first search of a cycle, in a predictable order
+	const (
+		unvisited = iota
+		visiting
+		visited
+	)
+	state := make(map[string]int)
+	var stack []string
+
+	var visit func(string) []string
+	visit = func(pkg string) []string {
+		state[pkg] = visiting
+		stack = append(stack, pkg)
+
+		for _, next := range sortedKeys(graph[pkg]) {
+			switch state[next] {
+			case visiting:
+				return append(stack[slices.Index(stack, next):], next)
+			case unvisited:
+				if cycle := visit(next); cycle != nil {
+					return cycle
+				}
+			}
+		}
+
+		stack = stack[:len(stack)-1]
+		state[pkg] = visited
+
+		return nil
+	}
+
+	for _, pkg := range sortedKeys(graph) {
+		if state[pkg] != unvisited {
+			continue
+		}
+
+		cycle := visit(pkg)
+		if cycle == nil {
+			continue
+		}
+
+		steps := make([]string, 0, len(cycle))
+		for i, pkg := range cycle[:len(cycle)-1] {
+			dep := graph[pkg][cycle[i+1]]
+			steps = append(steps, fmt.Sprintf("%q (%s %s %s)", path.Join(rootPackage, pkg), dep.from, dep.reason, dep.to))
+		}
+		steps = append(steps, fmt.Sprintf("%q", path.Join(rootPackage, cycle[len(cycle)-1])))
+
+		return fmt.Errorf("model packages would import each other in a cycle: %s", strings.Join(steps, " -> "))
+	}
+
+	return nil
+}
+
+// schemaRefs collects all the $ref's in a schema.
+func schemaRefs(schema *spec.Schema) []spec.Ref {
+	if schema == nil {
+		return nil
+	}
+
+	var refs []spec.Ref
+	if schema.Ref.String() != "" {
+		refs = append(refs, schema.Ref)
+	}
+
+	if schema.Items != nil {
+		refs = append(refs, schemaRefs(schema.Items.Schema)...)
+		for i := range schema.Items.Schemas {
+			refs = append(refs, schemaRefs(&schema.Items.Schemas[i])...)
+		}
+	}
+
+	for _, schemas := range [][]spec.Schema{schema.AllOf, schema.AnyOf, schema.OneOf} {
+		for i := range schemas {
+			refs = append(refs, schemaRefs(&schemas[i])...)
+		}
+	}
+
+	for _, name := range sortedKeys(schema.Properties) {
+		property := schema.Properties[name]
+		refs = append(refs, schemaRefs(&property)...)
+	}
+
+	if schema.AdditionalProperties != nil {
+		refs = append(refs, schemaRefs(schema.AdditionalProperties.Schema)...)
+	}
+	if schema.AdditionalItems != nil {
+		refs = append(refs, schemaRefs(schema.AdditionalItems.Schema)...)
+	}
+	refs = append(refs, schemaRefs(schema.Not)...)
+
+	return refs
+}
+
+// definitionSourceFiles finds the file where definitions imported from other documents are located.
+//
+// Definitions located in the root document are not listed. So are definitions of the root document
+// which are mere $ref's to another document: they follow the document they refer to.
+func definitionSourceFiles(specPath string) (map[string]string, error) {
+	if strings.HasPrefix(specPath, "http://") || strings.HasPrefix(specPath, "https://") {
+		return nil, nil
+	}
+
+	specPath, err := filepath.Abs(specPath)
+	if err != nil {
+		return nil, err
+	}
+
+	root, err := loadRawDocument(specPath)
+	if err != nil {
+		return nil, err
+	}
+
+	rootDefinitions, _ := root["definitions"].(map[string]any)
+	sources := make(map[string]string)
+	docs := map[string]map[string]any{specPath: root}
+
+	type pending struct {
+		file string
+		node any
+	}
+	queue := []pending{{file: specPath, node: root}}
+
+	for len(queue) > 0 {
+		current := queue[0]
+		queue = queue[1:]
+
+		var refs []string
+		walkRefs(current.node, func(_ map[string]any, ref string) {
+			refs = append(refs, ref)
+		})
+
+		for _, ref := range refs {
+			file, fragment, remote := resolveRef(current.file, ref)
+			if !remote && file == "" && !strings.Contains(ref, "://") {
+				file = current.file // local $ref within another document
+			}
+			if file == "" || file == specPath {
+				continue
+			}
+
+			name, ok := definitionName(fragment)
+			if !ok {
+				continue
+			}
+
+			if _, seen := sources[name]; seen {
+				continue
+			}
+
+			if local, isLocal := rootDefinitions[name].(map[string]any); isLocal {
+				localRef, _ := local["$ref"].(string)
+				localFile, localFragment, _ := resolveRef(specPath, localRef)
+				if localFile != file || localFragment != fragment {
+					continue // defined in the root document
+				}
+			}
+			sources[name] = file
+
+			doc, loaded := docs[file]
+			if !loaded {
+				if doc, err = loadRawDocument(file); err != nil {
+					return nil, err
+				}
+				docs[file] = doc
+			}
+
+			definitions, _ := doc["definitions"].(map[string]any)
+			queue = append(queue, pending{file: file, node: definitions[name]})
+		}
+	}
+
+	return sources, nil
+}
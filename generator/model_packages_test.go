@@ -0,0 +1,133 @@
+// SPDX-FileCopyrightText: Copyright 2015-2025 go-swagger maintainers
+// SPDX-License-Identifier: Apache-2.0
+
+package generator
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/go-openapi/loads"
+	"github.com/go-openapi/spec"
+	"github.com/go-openapi/testify/v2/assert"
+	"github.com/go-openapi/testify/v2/require"
+)
+
+func TestGenerateModels_SplitPackages(t *testing.T) {
+	defer discardOutput()()
+
+	target := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(target, "go.mod"), []byte("module split\n"), readableFile))
+
+	opts := testGenOpts()
+	opts.Spec = "../fixtures/codegen/split-models/split.yml"
+	opts.Target = target
+	opts.SplitModelsByFile = true
+	opts.ModelPackageRules = []ModelPackageRule{{Prefix: "Err", Package: "problems"}}
+	require.NoError(t, GenerateModels(nil, opts))
+
+	models := filepath.Join(target, defaultModelsTarget)
+	for _, file := range []string{
+		"pet.go",
+		"dog.go",
+		filepath.Join("orders", "order.go"),
+		filepath.Join("orders", "order_line.go"),
+		filepath.Join("crm", "customers", "customer.go"),
+		filepath.Join("money", "money.go"),
+		filepath.Join("money", "currency.go"),
+		filepath.Join("problems", "error.go"),
+	} {
+		_, err := os.Stat(filepath.Join(models, file))
+		require.NoErrorf(t, err, "expected model file %s", file)
+	}
+
+	content, err := os.ReadFile(filepath.Join(models, "orders", "order.go"))
+	require.NoError(t, err)
+	order := string(content)
+
+	assert.StringContainsT(t, order, "package orders")
+	assert.StringContainsT(t, order, `customersmodels "split/models/crm/customers"`)
+	assert.StringContainsT(t, order, `moneymodels "split/models/money"`)
+	assert.StringContainsT(t, order, `problemsmodels "split/models/problems"`)
+	assert.StringContainsT(t, order, "Customer *customersmodels.Customer")
+	assert.StringContainsT(t, order, "Total *moneymodels.Money")
+	assert.StringContainsT(t, order, "Errors []*problemsmodels.Error")
+	assert.StringContainsT(t, order, "Lines []*OrderLine")
+
+	content, err = os.ReadFile(filepath.Join(models, "money", "money.go"))
+	require.NoError(t, err)
+	assert.StringContainsT(t, string(content), "Currency *Currency")
+}
+
+func TestGenerateModels_SplitPackagesCycles(t *testing.T) {
+	defer discardOutput()()
+
+	for _, tc := range []struct {
+		spec     string
+		expected string
+	}{
+		{
+			spec:     "cycle.yml",
+			expected: `"models/billing" (Invoice refers to Customer) -> "models/crm" (Customer refers to Invoice) -> "models/billing"`,
+		},
+		{
+			spec:     "discriminator-cycle.yml",
+			expected: `"models" (Pet has the subtype Cat) -> "models/cats" (Cat refers to Pet) -> "models"`,
+		},
+	} {
+		t.Run(tc.spec, func(t *testing.T) {
+			opts := testGenOpts()
+			opts.Spec = filepath.Join("..", "fixtures", "codegen", "split-models", tc.spec)
+			opts.Target = t.TempDir()
+
+			err := GenerateModels(nil, opts)
+			require.Error(t, err)
+			assert.StringContainsT(t, err.Error(), tc.expected)
+		})
+	}
+}
+
+func TestPlanModelPackages_ScannedSpec(t *testing.T) {
+	// specs generated by scanning go code annotate every definition with the x-go-package of its go type
+	specPath := filepath.Join("..", "fixtures", "bugs", "1614", "gitea.json")
+	specDoc, err := loads.Spec(specPath)
+	require.NoError(t, err)
+
+	user := specDoc.Spec().Definitions["User"]
+	pkg, ok := user.Extensions.GetString("x-go-package")
+	require.TrueT(t, ok)
+	require.EqualT(t, "code.gitea.io/gitea/vendor/code.gitea.io/sdk/gitea", pkg)
+
+	opts := testGenOpts()
+	packages, err := opts.planModelPackages(specPath, specDoc)
+	require.NoError(t, err)
+	assert.Nil(t, packages)
+}
+
+func TestModelSubPackage(t *testing.T) {
+	opts := testGenOpts()
+	opts.ModelPackageRules = []ModelPackageRule{
+		{Prefix: "Billing", Package: "billing"},
+		{Prefix: "BillingInvoice", Package: "/billing/invoices/"},
+	}
+	sourceFiles := map[string]string{"money": filepath.Join("common", "money-types.yml")}
+
+	withPackage := func(pkg string) spec.Schema {
+		var schema spec.Schema
+		schema.AddExtension(xGoModelPackage, pkg)
+
+		return schema
+	}
+
+	assert.EqualT(t, "", opts.modelSubPackage("Order", spec.Schema{}, sourceFiles))
+	assert.EqualT(t, "billing", opts.modelSubPackage("BillingAccount", spec.Schema{}, sourceFiles))
+	assert.EqualT(t, "billing/invoices", opts.modelSubPackage("BillingInvoiceLine", spec.Schema{}, sourceFiles))
+	assert.EqualT(t, "money_types", opts.modelSubPackage("Money", spec.Schema{}, sourceFiles))
+	assert.EqualT(t, "orders", opts.modelSubPackage("BillingAccount", withPackage("orders"), sourceFiles))
+	assert.EqualT(t, "", opts.modelSubPackage("BillingAccount", withPackage("."), sourceFiles))
+
+	var scanned spec.Schema
+	scanned.AddExtension("x-go-package", "github.com/example/billing")
+	assert.EqualT(t, "", opts.modelSubPackage("Order", scanned, sourceFiles))
+}
@@ -0,0 +1,490 @@
+// SPDX-FileCopyrightText: Copyright 2015-2025 go-swagger maintainers
+// SPDX-License-Identifier: Apache-2.0
+
+package generator
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"path"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/go-openapi/jsonpointer"
+	"github.com/go-openapi/swag/loading"
+)
+
+const (
+	defaultSharedTarget  = "shared"
+	defaultSharedPackage = "models"
+	definitionsPointer   = "/definitions/"
+	sharedSpecFile       = "shared.json"
+)
+
+// SpecTarget is one of the specs generated by a multi-spec run.
+type SpecTarget struct {
+	Spec   string `mapstructure:"spec"`
+	Target string `mapstructure:"target"`
+}
+
+// SharedModelsOpts tells where to generate the models shared by several specs.
+type SharedModelsOpts struct {
+	Target  string `mapstructure:"target"`  // base directory of the shared package. Defaults to "shared"
+	Package string `mapstructure:"package"` // the shared package, relative to the target. Defaults to "models"
+}
+
+// sharedDefinition is a definition $ref'ed by several specs, from the same file and JSON pointer.
+type sharedDefinition struct {
+	File   string // absolute path to the file holding the definition
+	Name   string // name of the definition in this file
+	Schema map[string]any
+}
+
+func (d *sharedDefinition) key() string {
+	return sharedKey(d.File, d.Name)
+}
+
+func sharedKey(file, name string) string {
+	return file + "#" + definitionsPointer + jsonpointer.Escape(name)
+}
+
+// GenerateMultiSpec generates code for several specs in one run.
+//
+// Definitions that several specs $ref from the same file and JSON pointer (e.g. "common.yml#/definitions/Address")
+// are generated once, in a shared models package. The code generated for every spec imports this package
+// instead of duplicating these models.
+//
+// Only $ref's found in the spec documents are considered: shared definitions reached through some other
+// remote document are still generated with every spec.
+//
+// The generate function runs the generation of each spec (e.g. a call to [GenerateServer]),
+// with a copy of opts targeting this spec.
+func GenerateMultiSpec(specs []SpecTarget, shared SharedModelsOpts, opts *GenOpts, generate func(*GenOpts) error) error {
+	defer opts.beginGeneration()()
+
+	if len(specs) == 0 {
+		return errors.New("no spec to generate")
+	}
+
+	if err := opts.EnsureDefaults(); err != nil {
+		return err
+	}
+
+	if shared.Target == "" {
+		shared.Target = defaultSharedTarget
+	}
+	if shared.Package == "" {
+		shared.Package = defaultSharedPackage
+	}
+
+	specs = slices.Clone(specs)
+	docs := make([]map[string]any, len(specs))
+	refs := make(map[string][]int) // shared key -> indices of the specs referring to it
+	for i, target := range specs {
+		specPath, err := filepath.Abs(target.Spec)
+		if err != nil {
+			return err
+		}
+		specs[i].Spec = specPath
+
+		doc, err := loadRawDocument(specPath)
+		if err != nil {
+			return fmt.Errorf("could not load spec %q: %w", target.Spec, err)
+		}
+		docs[i] = doc
+
+		for _, key := range remoteDefinitions(doc, specPath) {
+			refs[key] = append(refs[key], i)
+		}
+	}
+
+	definitions, err := gatherSharedDefinitions(refs)
+	if err != nil {
+		return err
+	}
+
+	var external map[string]map[string]any
+	if len(definitions) > 0 {
+		if external, err = generateSharedModels(definitions, shared, opts); err != nil {
+			return fmt.Errorf("could not generate shared models: %w", err)
+		}
+	}
+
+	for i, target := range specs {
+		if err := opts.fs().MkdirAll(target.Target, readAllDir); err != nil {
+			return err
+		}
+
+		specOpts := *opts
+		specOpts.Target = target.Target
+		specOpts.Spec = target.Spec
+
+		if rewriteSharedRefs(docs[i], target.Spec, external) {
+			// the rewritten spec is known by the path of the spec, so its relative $ref's are still resolved
+			if specOpts.specContent, err = json.Marshal(docs[i]); err != nil {
+				return err
+			}
+		}
+
+		opts.logf("generating spec %q into %q", target.Spec, target.Target)
+		if err := generate(&specOpts); err != nil {
+			return fmt.Errorf("could not generate spec %q: %w", target.Spec, err)
+		}
+	}
+
+	return nil
+}
+
+// gatherSharedDefinitions retains the definitions referred to by several specs.
+func gatherSharedDefinitions(refs map[string][]int) ([]*sharedDefinition, error) {
+	files := make(map[string]map[string]any)
+	names := make(map[string]string)
+	var definitions []*sharedDefinition
+
+	for _, key := range sortedKeys(refs) {
+		if len(refs[key]) < 2 {
+			continue
+		}
+
+		file, fragment, _ := strings.Cut(key, "#")
+		name, _ := definitionName(fragment)
+
+		if other, conflict := names[name]; conflict {
+			return nil, fmt.Errorf("shared definition %q is defined in several files: %q and %q", name, other, file)
+		}
+		names[name] = file
+
+		doc, ok := files[file]
+		if !ok {
+			var err error
+			doc, err = loadRawDocument(file)
+			if err != nil {
+				return nil, fmt.Errorf("could not load shared definitions from %q: %w", file, err)
+			}
+			files[file] = doc
+		}
+
+		defs, _ := doc["definitions"].(map[string]any)
+		schema, ok := defs[name].(map[string]any)
+		if !ok {
+			return nil, fmt.Errorf("shared definition %q not found in %q", name, file)
+		}
+
+		definitions = append(definitions, &sharedDefinition{File: file, Name: name, Schema: schema})
+	}
+
+	return definitions, nil
+}
+
+// generateSharedModels generates the shared definitions as a models package.
+//
+// It returns, for every shared definition, the schema of an external type that refers to the shared package.
+func generateSharedModels(definitions []*sharedDefinition, shared SharedModelsOpts, opts *GenOpts) (map[string]map[string]any, error) {
+	isShared := make(map[string]string, len(definitions))
+	for _, def := range definitions {
+		isShared[def.key()] = def.Name
+	}
+
+	defs := make(map[string]any, len(definitions))
+	for _, def := range definitions {
+		schema := deepCopyJSON(def.Schema)
+
+		// refs within the shared definitions are relative to their own file
+		walkRefs(schema, func(obj map[string]any, ref string) {
+			file, fragment, remote := resolveRef(def.File, ref)
+			if !remote && file == "" {
+				file = def.File
+			}
+			if file == "" {
+				return
+			}
+
+			if name, ok := isShared[file+"#"+fragment]; ok {
+				obj["$ref"] = "#" + definitionsPointer + jsonpointer.Escape(name)
+
+				return
+			}
+
+			obj["$ref"] = file + "#" + fragment
+		})
+
+		defs[def.Name] = schema
+	}
+
+	sharedSpec, err := json.Marshal(map[string]any{
+		"swagger":     "2.0",
+		"info":        map[string]any{"title": "shared models", "version": "0.0.0"},
+		"paths":       map[string]any{},
+		"definitions": defs,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	sharedTarget, err := filepath.Abs(shared.Target)
+	if err != nil {
+		return nil, err
+	}
+
+	// the base import of the shared package is resolved from an existing directory
+	if err := opts.fs().MkdirAll(sharedTarget, readAllDir); err != nil {
+		return nil, err
+	}
+
+	sharedOpts := *opts
+	// the spec of the shared models only exists in memory: its $ref's are absolute
+	sharedOpts.Spec = filepath.Join(sharedTarget, sharedSpecFile)
+	sharedOpts.specContent = sharedSpec
+	sharedOpts.Target = sharedTarget
+	sharedOpts.ModelPackage = shared.Package
+
+	opts.logf("generating %d shared models into %q", len(definitions), filepath.Join(shared.Target, shared.Package))
+	if err := GenerateModels(nil, &sharedOpts); err != nil {
+		return nil, err
+	}
+
+	importPath := path.Join(opts.LanguageOpts.BaseImport(sharedTarget), filepath.ToSlash(shared.Package))
+	alias := opts.LanguageOpts.ManglePackageName(path.Base(importPath), defaultSharedPackage)
+	if !strings.HasPrefix(alias, "shared") {
+		alias = "shared" + alias
+	}
+
+	external := make(map[string]map[string]any, len(definitions))
+	for _, def := range definitions {
+		external[def.key()] = externalSchema(def, importPath, alias, opts)
+	}
+
+	return external, nil
+}
+
+// externalSchema builds the schema of a definition as an external type, imported from the shared package.
+func externalSchema(def *sharedDefinition, importPath, alias string, opts *GenOpts) map[string]any {
+	typ, _ := def.Schema["type"].(string)
+	if typ == "" {
+		typ = object
+	}
+
+	hints := map[string]any{}
+	switch typ {
+	case object, array:
+		hints["kind"] = typ
+	}
+
+	schema := map[string]any{
+		"type": typ,
+		xGoType: map[string]any{
+			"type":   opts.LanguageOpts.MangleName(def.Name, ""),
+			"import": map[string]any{"package": importPath, "alias": alias},
+			"hints":  hints,
+		},
+	}
+
+	if description, ok := def.Schema["description"]; ok {
+		schema["description"] = description
+	}
+
+	return schema
+}
+
+// rewriteSharedRefs replaces the $ref's to shared definitions in a spec document by definitions of external types.
+//
+// Other relative $ref's to remote documents are made absolute, so the rewritten document may be located anywhere.
+//
+// It returns false when the document does not refer to any shared definition.
+func rewriteSharedRefs(doc map[string]any, specPath string, external map[string]map[string]any) bool {
+	if len(external) == 0 {
+		return false
+	}
+
+	defs, _ := doc["definitions"].(map[string]any)
+	if defs == nil {
+		defs = make(map[string]any)
+	}
+
+	// definitions which are mere $ref's to a shared definition become the external type
+	localNames := make(map[string]string)
+	for _, name := range sortedKeys(defs) {
+		schema, ok := defs[name].(map[string]any)
+		if !ok {
+			continue
+		}
+
+		ref, ok := schema["$ref"].(string)
+		if !ok {
+			continue
+		}
+
+		file, fragment, remote := resolveRef(specPath, ref)
+		if !remote {
+			continue
+		}
+
+		key := file + "#" + fragment
+		if ext, isShared := external[key]; isShared {
+			defs[name] = deepCopyJSON(ext)
+			localNames[key] = name
+		}
+	}
+
+	added := make(map[string]any)
+	localName := func(key string) string {
+		if name, ok := localNames[key]; ok {
+			return name
+		}
+
+		_, fragment, _ := strings.Cut(key, "#")
+		base, _ := definitionName(fragment)
+		name := base
+		for i := 2; ; i++ {
+			_, taken := defs[name]
+			_, alsoTaken := added[name]
+			if !taken && !alsoTaken {
+				break
+			}
+			name = base + "Shared" + strconv.Itoa(i)
+		}
+
+		added[name] = deepCopyJSON(external[key])
+		localNames[key] = name
+
+		return name
+	}
+
+	var found bool
+	walkRefs(doc, func(obj map[string]any, ref string) {
+		file, fragment, remote := resolveRef(specPath, ref)
+		if !remote {
+			return
+		}
+
+		key := file + "#" + fragment
+		if _, isShared := external[key]; !isShared {
+			obj["$ref"] = key
+
+			return
+		}
+
+		found = true
+		clear(obj)
+		obj["$ref"] = "#" + definitionsPointer + jsonpointer.Escape(localName(key))
+	})
+
+	for name, schema := range added {
+		defs[name] = schema
+	}
+	doc["definitions"] = defs
+
+	return found || len(localNames) > 0
+}
+
+// remoteDefinitions lists the definitions in other documents referred to by a spec document.
+func remoteDefinitions(doc map[string]any, specPath string) []string {
+	keys := make(map[string]bool)
+	walkRefs(doc, func(_ map[string]any, ref string) {
+		file, fragment, remote := resolveRef(specPath, ref)
+		if !remote {
+			return
+		}
+
+		if _, ok := definitionName(fragment); ok {
+			keys[file+"#"+fragment] = true
+		}
+	})
+
+	return sortedKeys(keys)
+}
+
+// resolveRef resolves a $ref found in some file.
+//
+// Remote refs to local files are returned with the absolute path to the file. Refs local to the current
+// document and remote refs to URLs are not considered remote.
+func resolveRef(basePath, ref string) (file, fragment string, remote bool) {
+	file, fragment, _ = strings.Cut(ref, "#")
+	if file == "" || strings.HasPrefix(file, "http://") || strings.HasPrefix(file, "https://") {
+		return "", fragment, false
+	}
+
+	if !filepath.IsAbs(file) {
+		file = filepath.Join(filepath.Dir(basePath), filepath.FromSlash(file))
+	}
+	file = filepath.Clean(file)
+
+	return file, fragment, file != filepath.Clean(basePath)
+}
+
+// definitionName extracts the name of a definition from a JSON pointer like "/definitions/Name".
+func definitionName(fragment string) (string, bool) {
+	name, ok := strings.CutPrefix(fragment, definitionsPointer)
+	if !ok || name == "" || strings.Contains(name, "/") {
+		return "", false
+	}
+
+	return jsonpointer.Unescape(name), true
+}
+
+// walkRefs calls a function on every JSON object with a $ref in a document.
+func walkRefs(node any, fn func(map[string]any, string)) {
+	switch value := node.(type) {
+	case map[string]any:
+		if ref, ok := value["$ref"].(string); ok {
+			fn(value, ref)
+
+			return
+		}
+
+		for _, key := range sortedKeys(value) {
+			walkRefs(value[key], fn)
+		}
+	case []any:
+		for _, item := range value {
+			walkRefs(item, fn)
+		}
+	}
+}
+
+func loadRawDocument(pth string) (map[string]any, error) {
+	load := loading.JSONDoc
+	if loading.YAMLMatcher(pth) {
+		load = loading.YAMLDoc
+	}
+
+	raw, err := load(pth)
+	if err != nil {
+		return nil, err
+	}
+
+	dec := json.NewDecoder(bytes.NewReader(raw))
+	dec.UseNumber()
+
+	var doc map[string]any
+	if err := dec.Decode(&doc); err != nil {
+		return nil, err
+	}
+
+	return doc, nil
+}
+
+func deepCopyJSON(schema map[string]any) map[string]any {
+	buf, _ := json.Marshal(schema)
+	dec := json.NewDecoder(bytes.NewReader(buf))
+	dec.UseNumber()
+
+	var cpy map[string]any
+	_ = dec.Decode(&cpy)
+
+	return cpy
+}
+
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	slices.Sort(keys)
+
+	return keys
+}
@@ -0,0 +1,134 @@
+// SPDX-FileCopyrightText: Copyright 2015-2025 go-swagger maintainers
+// SPDX-License-Identifier: Apache-2.0
+
+package generator
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/go-openapi/testify/v2/assert"
+	"github.com/go-openapi/testify/v2/require"
+)
+
+func TestGenerateMultiSpec(t *testing.T) {
+	defer discardOutput()()
+
+	fixtures, err := filepath.Abs(filepath.Join("..", "fixtures", "multispec"))
+	require.NoError(t, err)
+
+	target := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(target, "go.mod"), []byte("module multispec\n"), readableFile))
+
+	specs := []SpecTarget{
+		{Spec: filepath.Join(fixtures, "orders.yml"), Target: filepath.Join(target, "orders")},
+		{Spec: filepath.Join(fixtures, "billing.yml"), Target: filepath.Join(target, "billing")},
+	}
+	shared := SharedModelsOpts{Target: filepath.Join(target, "shared")}
+
+	var generated, generatedSpecs []string
+	opts := testGenOpts()
+	require.NoError(t, GenerateMultiSpec(specs, shared, opts, func(o *GenOpts) error {
+		generated = append(generated, o.Target)
+		generatedSpecs = append(generatedSpecs, o.Spec)
+
+		return GenerateModels(nil, o)
+	}))
+	assert.Equal(t, []string{specs[0].Target, specs[1].Target}, generated)
+
+	t.Run("should generate with the path of the specs", func(t *testing.T) {
+		assert.Equal(t, []string{specs[0].Spec, specs[1].Spec}, generatedSpecs)
+	})
+
+	t.Run("should generate shared models once", func(t *testing.T) {
+		for _, name := range []string{"address.go", "money.go"} {
+			_, err := os.Stat(filepath.Join(target, "shared", "models", name))
+			require.NoError(t, err)
+
+			for _, spec := range specs {
+				_, err := os.Stat(filepath.Join(spec.Target, "models", name))
+				require.ErrorIs(t, err, os.ErrNotExist)
+			}
+		}
+	})
+
+	t.Run("should keep models not shared with each spec", func(t *testing.T) {
+		_, err := os.Stat(filepath.Join(specs[0].Target, "models", "tags.go"))
+		require.NoError(t, err)
+		_, err = os.Stat(filepath.Join(target, "shared", "models", "tags.go"))
+		require.ErrorIs(t, err, os.ErrNotExist)
+	})
+
+	t.Run("should import the shared models", func(t *testing.T) {
+		for _, file := range []string{
+			filepath.Join(specs[0].Target, "models", "order.go"),
+			filepath.Join(specs[1].Target, "models", "invoice.go"),
+		} {
+			content, err := os.ReadFile(file)
+			require.NoError(t, err)
+			assert.StringContainsT(t, string(content), `sharedmodels "multispec/shared/models"`)
+			assert.StringContainsT(t, string(content), "sharedmodels.Address")
+		}
+	})
+}
+
+func TestGenerateMultiSpec_Errors(t *testing.T) {
+	defer discardOutput()()
+
+	t.Run("should require a spec", func(t *testing.T) {
+		require.Error(t, GenerateMultiSpec(nil, SharedModelsOpts{}, testGenOpts(), nil))
+	})
+
+	t.Run("should report the spec which fails", func(t *testing.T) {
+		err := GenerateMultiSpec([]SpecTarget{{Spec: "nowhere.yml"}}, SharedModelsOpts{}, testGenOpts(), nil)
+		require.Error(t, err)
+		assert.StringContainsT(t, err.Error(), "nowhere.yml")
+	})
+}
+
+func TestResolveRef(t *testing.T) {
+	base := filepath.Join(string(filepath.Separator), "specs", "api.yml")
+
+	for _, tc := range []struct {
+		ref      string
+		file     string
+		fragment string
+		remote   bool
+	}{
+		{ref: "#/definitions/A", fragment: "/definitions/A"},
+		{ref: "http://example.com/common.yml#/definitions/A", fragment: "/definitions/A"},
+		{ref: "api.yml#/definitions/A", file: base, fragment: "/definitions/A"},
+		{
+			ref:      "./common/types.yml#/definitions/A",
+			file:     filepath.Join(string(filepath.Separator), "specs", "common", "types.yml"),
+			fragment: "/definitions/A",
+			remote:   true,
+		},
+		{
+			ref:      "../types.yml#/parameters/p",
+			file:     filepath.Join(string(filepath.Separator), "types.yml"),
+			fragment: "/parameters/p",
+			remote:   true,
+		},
+	} {
+		t.Run(tc.ref, func(t *testing.T) {
+			file, fragment, remote := resolveRef(base, tc.ref)
+			assert.EqualT(t, tc.file, file)
+			assert.EqualT(t, tc.fragment, fragment)
+			assert.EqualT(t, tc.remote, remote)
+		})
+	}
+}
+
+func TestDefinitionName(t *testing.T) {
+	name, ok := definitionName("/definitions/a~1b")
+	assert.TrueT(t, ok)
+	assert.EqualT(t, "a/b", name)
+
+	_, ok = definitionName("/definitions/A/properties/b")
+	assert.FalseT(t, ok)
+
+	_, ok = definitionName("/parameters/p")
+	assert.FalseT(t, ok)
+}
@@ -7,7 +7,6 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
-	"log"
 	"maps"
 	"os"
 	"path/filepath"
@@ -20,7 +19,6 @@ import (
 	"github.com/go-openapi/loads"
 	"github.com/go-openapi/runtime"
 	"github.com/go-openapi/spec"
-	"github.com/go-openapi/swag/jsonutils"
 	"github.com/go-openapi/swag/stringutils"
 )
 
@@ -53,6 +51,8 @@ func sortedResponses(input map[int]spec.Response) responses {
 // It also generates an operation handler interface that uses the parameter model for handling a valid request.
 // Allows for specifying a list of tags to include only certain tags for the generation.
 func GenerateServerOperation(operationNames []string, opts *GenOpts) error {
+	defer opts.beginGeneration()()
+
 	if err := opts.CheckOpts(); err != nil {
 		return err
 	}
@@ -191,13 +191,10 @@ func (o *operationGenerator) Generate() error {
 	for _, pp := range operations {
 		op := pp
 		if o.GenOpts.DumpData {
-			var dynamicOp any
-			if err := jsonutils.FromDynamicJSON(op, &dynamicOp); err != nil {
+			if err := o.GenOpts.dumpData(os.Stdout, op); err != nil {
 				return err
 			}
 
-			_ = dumpData(os.Stdout, dynamicOp)
-
 			continue
 		}
 		if err := o.GenOpts.renderOperation(&op); err != nil {
@@ -235,6 +232,7 @@ type codeGenOpBuilder struct {
 	SecurityDefinitions map[string]spec.SecurityScheme
 	ExtraSchemas        map[string]GenSchema
 	GenOpts             *GenOpts
+	Grouped             bool // the operation is generated in its own package, below the main operations package
 }
 
 //nolint:gocognit,gocyclo,cyclop,maintidx // TODO(fredbi): refactor
@@ -410,7 +408,7 @@ func (b *codeGenOpBuilder) MakeOperation() (GenOperation, error) {
 		Path:                 b.Path,
 		BasePath:             b.BasePath,
 		Tags:                 operation.Tags,
-		UseTags:              len(operation.Tags) > 0 && !b.GenOpts.SkipTagPackages,
+		UseTags:              b.Grouped,
 		Description:          trimBOM(operation.Description),
 		ReceiverName:         receiver,
 		DefaultImports:       b.DefaultImports,
@@ -438,7 +436,7 @@ func (b *codeGenOpBuilder) MakeOperation() (GenOperation, error) {
 		DefaultResponse:      defaultResponse,
 		SuccessResponse:      successResponse,
 		SuccessResponses:     successResponses,
-		ExtraSchemas:         gatherExtraSchemas(b.ExtraSchemas),
+		ExtraSchemas:         gatherExtraSchemas(b.ExtraSchemas, b.GenOpts.warnf),
 		Schemes:              schemeOrDefault(schemes, b.DefaultScheme),
 		SchemeOverrides:      originalSchemes,      // raw operation schemes, for doc
 		ProducesMediaTypes:   produces,             // resolved produces, for codegen
@@ -512,7 +510,7 @@ func (b *codeGenOpBuilder) MakeResponse(receiver, name string, isSuccess bool, r
 
 func (b *codeGenOpBuilder) MakeHeader(receiver, name string, hdr spec.Header) (GenHeader, error) {
 	mangle := b.GenOpts.LanguageOpts.Mangler.ToGoName
-	tpe := simpleResolvedType(hdr.Type, hdr.Format, hdr.Items, &hdr.CommonValidations)
+	tpe := simpleResolvedType(hdr.Type, hdr.Format, hdr.Items, &hdr.CommonValidations, b.GenOpts.warnf)
 
 	id := mangle(name)
 	res := GenHeader{
@@ -558,7 +556,7 @@ func (b *codeGenOpBuilder) MakeHeader(receiver, name string, hdr spec.Header) (G
 func (b *codeGenOpBuilder) MakeHeaderItem(receiver, paramName, indexVar, path, valueExpression string, items, _ *spec.Items) (GenItems, error) {
 	var res GenItems
 	mangler := b.GenOpts.LanguageOpts.Mangler
-	res.resolvedType = simpleResolvedType(items.Type, items.Format, items.Items, &items.CommonValidations)
+	res.resolvedType = simpleResolvedType(items.Type, items.Format, items.Items, &items.CommonValidations, b.GenOpts.warnf)
 
 	res.sharedValidations = sharedValidations{
 		Required:          false,
@@ -603,7 +601,7 @@ func (b *codeGenOpBuilder) MakeParameterItem(receiver, paramName, indexVar, path
 	debugLogf("making parameter item recv=%s param=%s index=%s valueExpr=%s path=%s location=%s", receiver, paramName, indexVar, valueExpression, path, location)
 	var res GenItems
 	mangler := b.GenOpts.LanguageOpts.Mangler
-	res.resolvedType = simpleResolvedType(items.Type, items.Format, items.Items, &items.CommonValidations)
+	res.resolvedType = simpleResolvedType(items.Type, items.Format, items.Items, &items.CommonValidations, b.GenOpts.warnf)
 
 	res.sharedValidations = sharedValidations{
 		Required:          false,
@@ -698,7 +696,7 @@ func (b *codeGenOpBuilder) MakeParameter(receiver string, resolver *typeResolver
 		}
 	} else {
 		// Process parameters declared in other inputs: path, query, header (SimpleSchema)
-		res.resolvedType = simpleResolvedType(param.Type, param.Format, param.Items, &param.CommonValidations)
+		res.resolvedType = simpleResolvedType(param.Type, param.Format, param.Items, &param.CommonValidations, b.GenOpts.warnf)
 		res.sharedValidations = sharedValidations{
 			Required:          param.Required,
 			SchemaValidations: param.Validations(),
@@ -897,11 +895,11 @@ func (b *codeGenOpBuilder) paramMappings(params map[string]spec.Parameter) (map[
 		debugLogf("paramMappings: params: id=%s, In=%q, Name=%q", id, p.In, p.Name)
 		// guard against possible validation failures and/or skipped issues
 		if _, found := idMapping[p.In]; !found {
-			log.Printf(`warning: parameter named %q has an invalid "in": %q. Skipped`, p.Name, p.In)
+			b.GenOpts.warnf(`parameter named %q has an invalid "in": %q. Skipped`, p.Name, p.In)
 			continue
 		}
 		if p.Name == "" {
-			log.Printf(`warning: unnamed parameter (%+v). Skipped`, p)
+			b.GenOpts.warnf(`unnamed parameter (%+v). Skipped`, p)
 			continue
 		}
 
@@ -1288,40 +1286,17 @@ func intersectTags(left, right []string) []string {
 }
 
 // analyze tags for an operation.
+//
+// The package where the operation is generated depends on the strategy to group operations (see [GroupByTag]).
 func (b *codeGenOpBuilder) analyzeTags() (string, []string, bool) {
-	var (
-		filter         []string
-		tag            string
-		hasTagOverride bool
-	)
+	var filter []string
 	if b.GenOpts != nil {
 		filter = b.GenOpts.Tags
 	}
 	intersected := intersectTags(pruneEmpty(b.Operation.Tags), filter)
-	if !b.GenOpts.SkipTagPackages && len(intersected) > 0 {
-		// override generation with: x-go-operation-tag
-		tag, hasTagOverride = b.Operation.Extensions.GetString(xGoOperationTag)
-		if !hasTagOverride {
-			// TODO(fred): this part should be delegated to some new TagsFor(operation) in go-openapi/analysis
-			tag = intersected[0]
-			gtags := b.Doc.Spec().Tags
-			for _, gtag := range gtags {
-				if gtag.Name != tag {
-					continue
-				}
-				//  honor x-go-name in tag
-				if name, hasGoName := gtag.Extensions.GetString(xGoName); hasGoName {
-					tag = name
-					break
-				}
-				//  honor x-go-operation-tag in tag
-				if name, hasOpName := gtag.Extensions.GetString(xGoOperationTag); hasOpName {
-					tag = name
-					break
-				}
-			}
-		}
-	}
+	tag := b.operationGroup(intersected)
+	b.Grouped = tag != ""
+
 	if tag == b.APIPackage {
 		// conflict with "operations" package is handled separately
 		tag = renameOperationPackage(intersected, tag)
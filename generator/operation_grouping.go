@@ -0,0 +1,120 @@
+// SPDX-FileCopyrightText: Copyright 2015-2025 go-swagger maintainers
+// SPDX-License-Identifier: Apache-2.0
+
+package generator
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// Strategies to group operations into packages.
+const (
+	// GroupByTag groups operations by their first tag. This is the default.
+	GroupByTag = "tag"
+
+	// GroupByPath groups operations by the first segment of their path, e.g. "/pets/{id}" is grouped as "pets".
+	GroupByPath = "path"
+
+	// GroupByExtension groups operations by their x-go-package extension.
+	//
+	// Operations without this extension are grouped by their first tag.
+	GroupByExtension = "extension"
+
+	// GroupByRules groups operations according to explicit rules, mapping an operationId to a package.
+	//
+	// Operations which match no rule are grouped by their first tag.
+	GroupByRules = "rules"
+)
+
+// operationGroupings lists the supported strategies to group operations into packages.
+func operationGroupings() []string {
+	return []string{GroupByTag, GroupByPath, GroupByExtension, GroupByRules}
+}
+
+func (g *GenOpts) checkOperationGrouping() error {
+	if g.OperationGrouping == "" || slices.Contains(operationGroupings(), g.OperationGrouping) {
+		return nil
+	}
+
+	return fmt.Errorf("invalid operation grouping %q. Supported strategies: %s",
+		g.OperationGrouping, strings.Join(operationGroupings(), ", "))
+}
+
+// operationGroup determines the package where an operation is generated, before mangling.
+//
+// An empty group means that the operation is generated in the main operations package.
+func (b *codeGenOpBuilder) operationGroup(tags []string) string {
+	if b.GenOpts.SkipTagPackages {
+		return ""
+	}
+
+	switch b.GenOpts.OperationGrouping {
+	case GroupByPath:
+		return firstPathSegment(b.Path)
+
+	case GroupByExtension:
+		if pkg, ok := b.Operation.Extensions.GetString(xGoPackage); ok && pkg != "" {
+			return pkg
+		}
+
+	case GroupByRules:
+		id := b.Operation.ID
+		if id == "" {
+			id = b.Name
+		}
+
+		if pkg, ok := b.GenOpts.OperationPackageRules[id]; ok && pkg != "" {
+			return pkg
+		}
+	}
+
+	return b.tagGroup(tags)
+}
+
+// tagGroup determines the package of an operation from its first tag.
+//
+// The x-go-operation-tag extension on the operation overrides the tag, and
+// the x-go-name or x-go-operation-tag extensions on the tag rename the package.
+func (b *codeGenOpBuilder) tagGroup(tags []string) string {
+	if len(tags) == 0 {
+		return ""
+	}
+
+	// override generation with: x-go-operation-tag
+	if tag, hasTagOverride := b.Operation.Extensions.GetString(xGoOperationTag); hasTagOverride {
+		return tag
+	}
+
+	// TODO(fred): this part should be delegated to some new TagsFor(operation) in go-openapi/analysis
+	tag := tags[0]
+	for _, gtag := range b.Doc.Spec().Tags {
+		if gtag.Name != tag {
+			continue
+		}
+		//  honor x-go-name in tag
+		if name, hasGoName := gtag.Extensions.GetString(xGoName); hasGoName {
+			return name
+		}
+		//  honor x-go-operation-tag in tag
+		if name, hasOpName := gtag.Extensions.GetString(xGoOperationTag); hasOpName {
+			return name
+		}
+	}
+
+	return tag
+}
+
+// firstPathSegment returns the first segment of a path which is not a path parameter.
+func firstPathSegment(pth string) string {
+	for segment := range strings.SplitSeq(pth, "/") {
+		if segment == "" || strings.HasPrefix(segment, "{") {
+			continue
+		}
+
+		return segment
+	}
+
+	return ""
+}
@@ -0,0 +1,128 @@
+// SPDX-FileCopyrightText: Copyright 2015-2025 go-swagger maintainers
+// SPDX-License-Identifier: Apache-2.0
+
+package generator
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/go-openapi/testify/v2/assert"
+	"github.com/go-openapi/testify/v2/require"
+)
+
+func TestOperationGrouping(t *testing.T) {
+	defer discardOutput()()
+
+	const spec = "../fixtures/codegen/operation-grouping.yml"
+
+	packagesFor := func(t *testing.T, isClient bool, configure func(*GenOpts)) map[string]string {
+		t.Helper()
+
+		opts := testGenOpts()
+		opts.Spec = filepath.FromSlash(spec)
+		opts.IsClient = isClient
+		configure(opts)
+		require.NoError(t, opts.CheckOpts())
+		require.NoError(t, opts.EnsureDefaults())
+
+		appGen, err := newAppGenerator("grouping", nil, nil, opts)
+		require.NoError(t, err)
+
+		app, err := appGen.makeCodegenApp()
+		require.NoError(t, err)
+
+		packages := make(map[string]string)
+		for _, group := range app.OperationGroups {
+			for _, op := range group.Operations {
+				assert.EqualT(t, op.Package != "operations", op.UseTags, op.Name)
+				packages[op.Name] = op.Package
+			}
+		}
+
+		return packages
+	}
+
+	for _, isClient := range []bool{false, true} {
+		target := "server"
+		if isClient {
+			target = "client"
+		}
+
+		t.Run(target+" should group by first tag by default", func(t *testing.T) {
+			assert.Equal(t, map[string]string{
+				"listPets":    "docs",
+				"getPet":      "docs",
+				"placeOrder":  "docs",
+				"getAnything": "operations",
+			}, packagesFor(t, isClient, func(*GenOpts) {}))
+		})
+
+		t.Run(target+" should group by first path segment", func(t *testing.T) {
+			assert.Equal(t, map[string]string{
+				"listPets":    "pets",
+				"getPet":      "pets",
+				"placeOrder":  "stores",
+				"getAnything": "operations",
+			}, packagesFor(t, isClient, func(opts *GenOpts) { opts.OperationGrouping = GroupByPath }))
+		})
+
+		t.Run(target+" should group by extension, then by first tag", func(t *testing.T) {
+			assert.Equal(t, map[string]string{
+				"listPets":    "inventory",
+				"getPet":      "docs",
+				"placeOrder":  "docs",
+				"getAnything": "operations",
+			}, packagesFor(t, isClient, func(opts *GenOpts) { opts.OperationGrouping = GroupByExtension }))
+		})
+
+		t.Run(target+" should group by rules, then by first tag", func(t *testing.T) {
+			assert.Equal(t, map[string]string{
+				"listPets":    "docs",
+				"getPet":      "pets",
+				"placeOrder":  "store",
+				"getAnything": "pets",
+			}, packagesFor(t, isClient, func(opts *GenOpts) {
+				opts.OperationGrouping = GroupByRules
+				opts.OperationPackageRules = map[string]string{
+					"getPet":      "pets",
+					"getAnything": "pets",
+					"placeOrder":  "store",
+				}
+			}))
+		})
+
+		t.Run(target+" should not group operations with SkipTagPackages", func(t *testing.T) {
+			packages := packagesFor(t, isClient, func(opts *GenOpts) {
+				opts.OperationGrouping = GroupByPath
+				opts.SkipTagPackages = true
+			})
+			for name, pkg := range packages {
+				assert.EqualT(t, "operations", pkg, name)
+			}
+		})
+	}
+
+	t.Run("should reject an unknown strategy", func(t *testing.T) {
+		opts := testGenOpts()
+		opts.Spec = filepath.FromSlash(spec)
+		opts.OperationGrouping = "method"
+		err := opts.CheckOpts()
+		require.Error(t, err)
+		assert.StringContainsT(t, err.Error(), `invalid operation grouping "method"`)
+	})
+}
+
+func TestFirstPathSegment(t *testing.T) {
+	for pth, expected := range map[string]string{
+		"/pets":               "pets",
+		"/pets/{id}":          "pets",
+		"/{tenant}/pets/{id}": "pets",
+		"stores/orders":       "stores",
+		"/":                   "",
+		"/{id}":               "",
+		"":                    "",
+	} {
+		assert.EqualT(t, expected, firstPathSegment(pth), pth)
+	}
+}
@@ -0,0 +1,266 @@
+// SPDX-FileCopyrightText: Copyright 2015-2025 go-swagger maintainers
+// SPDX-License-Identifier: Apache-2.0
+
+package generator
+
+import (
+	"bytes"
+	"go/parser"
+	"go/token"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/Masterminds/semver/v3"
+)
+
+// Commands run by the go:generate directive of generate.go, see [GenOptsCommon.GoGenerate].
+const (
+	GoGenerateSwagger = "swagger" // the swagger binary, found in the PATH
+	GoGenerateGoRun   = "go-run"  // go run of the go-swagger module, pinned to the version of the generator when released
+)
+
+// generatedMarker marks generated go files.
+const generatedMarker = "// Code generated by go-swagger; DO NOT EDIT."
+
+var (
+	packageDocTemplate = TemplateOpts{
+		Name:     "packageDoc",
+		Source:   "asset:packagesDoc",
+		Target:   "{{ .Context.Dir }}",
+		FileName: "doc.go",
+	}
+
+	goGenerateTemplate = TemplateOpts{
+		Name:     "goGenerate",
+		Source:   "asset:packagesGenerate",
+		Target:   "{{ .Context.Dir }}",
+		FileName: "generate.go",
+	}
+)
+
+// ReplayFunc builds the command line replaying the generation, from some directory relative to the target.
+type ReplayFunc func(dir string) []string
+
+// GenPackageDoc is the data of the doc.go of a generated package, and of generate.go at the root of the target.
+type GenPackageDoc struct {
+	GenCommon
+
+	Package    string   // name of the package
+	Dir        string   // directory of the package
+	Spec       string   // the spec, relative to the directory of the package. Empty when several specs are generated
+	Title      string   // title of the spec
+	Version    string   // version of the spec
+	Models     int      // number of definitions generated in the package
+	Operations int      // number of operations generated in the package
+	Command    []string // command line regenerating the code, from the directory of the package
+	Directive  string   // command of the go:generate directive, for generate.go
+}
+
+// CommandLine is the command line regenerating the code, with quoted arguments when needed.
+func (d *GenPackageDoc) CommandLine() string {
+	return quoteArgs(d.Command)
+}
+
+// writePackageDocs writes a doc.go in the generated packages which have no package documentation,
+// and generate.go at the root of the target, as requested by the options.
+func (g *GenOpts) writePackageDocs(files generatedFiles) error {
+	if !g.PackageDocs && g.GoGenerate == "" {
+		return nil
+	}
+
+	target, err := filepath.Abs(g.Target)
+	if err != nil {
+		return err
+	}
+
+	base := g.packageDocBase()
+	if g.PackageDocs {
+		for _, dir := range files.packageDirs() {
+			doc, ok := g.packageDoc(base, dir, files)
+			if !ok {
+				continue
+			}
+
+			if err := g.write(&packageDocTemplate, doc); err != nil {
+				return err
+			}
+		}
+	}
+
+	if g.GoGenerate == "" {
+		return nil
+	}
+
+	doc := g.describePackage(base, target, g.LanguageOpts.ManglePackageName(filepath.Base(target), "api"))
+	if pkg, _ := g.packageClause(target, files); pkg != "" {
+		doc.Package = pkg
+	}
+	doc.Directive = g.goGenerateDirective()
+	if doc.Directive == "" {
+		g.warnf("generate.go is not generated: the command line of the generation is unknown")
+
+		return nil
+	}
+
+	return g.write(&goGenerateTemplate, doc)
+}
+
+// packageDocBase is the description of the spec, common to all packages.
+func (g *GenOpts) packageDocBase() GenPackageDoc {
+	base := GenPackageDoc{
+		GenCommon: GenCommon{
+			Copyright:        g.Copyright,
+			TargetImportPath: g.LanguageOpts.BaseImport(g.Target),
+		},
+	}
+
+	specPath, err := findSwaggerSpec(g.Spec)
+	if err != nil {
+		// several specs are generated
+		return base
+	}
+
+	if base.Spec, err = filepath.Abs(specPath); err != nil {
+		base.Spec = specPath
+	}
+
+	if doc, err := loadRawDocument(specPath); err == nil {
+		if info, ok := doc["info"].(map[string]any); ok {
+			base.Title, _ = info["title"].(string)
+			base.Version, _ = info["version"].(string)
+		}
+	}
+
+	return base
+}
+
+// packageDoc describes a generated package, unless its documentation is generated already.
+func (g *GenOpts) packageDoc(base GenPackageDoc, dir string, files generatedFiles) (*GenPackageDoc, bool) {
+	pkg, documented := g.packageClause(dir, files)
+	if pkg == "" || documented {
+		return nil, false
+	}
+
+	doc := g.describePackage(base, dir, pkg)
+	models, operations := make(map[string]bool), make(map[string]bool)
+	for file, e := range files {
+		if filepath.Dir(file) != dir {
+			continue
+		}
+
+		switch {
+		case strings.HasPrefix(e.Element, "#/definitions/"):
+			models[e.Element] = true
+		case strings.HasPrefix(e.Element, "#/paths/"):
+			operations[e.Element] = true
+		}
+	}
+	doc.Models, doc.Operations = len(models), len(operations)
+
+	return doc, true
+}
+
+func (g *GenOpts) describePackage(base GenPackageDoc, dir, pkg string) *GenPackageDoc {
+	doc := base
+	doc.Package = pkg
+	doc.Dir = dir
+
+	if doc.Spec != "" {
+		if rel, err := filepath.Rel(dir, doc.Spec); err == nil {
+			doc.Spec = filepath.ToSlash(rel)
+		}
+	}
+
+	target, err := filepath.Abs(g.Target)
+	if err != nil {
+		return &doc
+	}
+
+	// files are located like the files of templates, from the target
+	if rel, err := filepath.Rel(target, dir); err == nil {
+		doc.Dir = filepath.Join(g.Target, rel)
+	}
+
+	if g.ReplayCommand != nil {
+		if rel, err := filepath.Rel(dir, target); err == nil {
+			doc.Command = g.ReplayCommand(rel)
+		}
+	}
+
+	return &doc
+}
+
+// goGenerateDirective is the command of the go:generate directive, run from the root of the target.
+func (g *GenOpts) goGenerateDirective() string {
+	if g.ReplayCommand == nil {
+		return ""
+	}
+
+	command := g.ReplayCommand(".")
+	if len(command) == 0 || g.GoGenerate != GoGenerateGoRun {
+		return quoteArgs(command)
+	}
+
+	module := generatorModule + "/cmd/swagger"
+	if version := generatorVersion(); isReleased(version) {
+		module += "@" + version
+	} else {
+		g.warnf("the go:generate directive runs the latest generator: this build %q has no version to pin", version)
+	}
+
+	return quoteArgs(append([]string{"go", "run", module}, command[1:]...))
+}
+
+// isReleased tells if a version of the generator can be fetched by go run, e.g. not "(devel)" nor a dirty build.
+func isReleased(version string) bool {
+	v, err := semver.StrictNewVersion(strings.TrimPrefix(version, "v"))
+
+	return err == nil && v.Metadata() == ""
+}
+
+// packageClause reads the package name of the generated go files in a directory, and tells if the package is documented,
+// by a generated file or by a doc.go which is not generated.
+func (g *GenOpts) packageClause(dir string, files generatedFiles) (string, bool) {
+	var pkg string
+	for file := range files {
+		if filepath.Dir(file) != dir || filepath.Ext(file) != ".go" || strings.HasSuffix(file, "_test.go") {
+			continue
+		}
+
+		src, err := g.fs().ReadFile(file)
+		if err != nil {
+			continue
+		}
+
+		parsed, err := parser.ParseFile(token.NewFileSet(), file, src, parser.PackageClauseOnly|parser.ParseComments)
+		if err != nil {
+			continue
+		}
+
+		if parsed.Doc != nil {
+			return parsed.Name.Name, true
+		}
+		pkg = parsed.Name.Name
+	}
+
+	// a doc.go left by a previous generation is generated again
+	if content, err := g.fs().ReadFile(filepath.Join(dir, packageDocTemplate.FileName)); err == nil && !bytes.Contains(content, []byte(generatedMarker)) {
+		return pkg, true
+	}
+
+	return pkg, false
+}
+
+// quoteArgs joins the arguments of a command line, quoting the arguments which need it.
+func quoteArgs(args []string) string {
+	quoted := make([]string, 0, len(args))
+	for _, arg := range args {
+		if arg == "" || strings.ContainsAny(arg, " \t\"'\\$") {
+			arg = strconv.Quote(arg)
+		}
+		quoted = append(quoted, arg)
+	}
+
+	return strings.Join(quoted, " ")
+}
@@ -0,0 +1,126 @@
+// SPDX-FileCopyrightText: Copyright 2015-2025 go-swagger maintainers
+// SPDX-License-Identifier: Apache-2.0
+
+package generator
+
+import (
+	"bytes"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/go-openapi/testify/v2/assert"
+	"github.com/go-openapi/testify/v2/require"
+)
+
+func TestRunWithHooks_PackageDocs(t *testing.T) {
+	defer discardOutput()()
+
+	// the package of generate.go is named after the target
+	target := filepath.Join(t.TempDir(), "docs")
+	require.NoError(t, os.MkdirAll(target, readableDir))
+	require.NoError(t, os.WriteFile(filepath.Join(target, "go.mod"), []byte("module docs\n"), readableFile))
+	spec, err := filepath.Abs("../fixtures/bugs/1042/fixture-1042.yaml")
+	require.NoError(t, err)
+
+	generate := func(t *testing.T) map[string]Event {
+		t.Helper()
+
+		events := make(map[string]Event)
+		opts := testGenOpts()
+		opts.Spec = spec
+		opts.Target = target
+		opts.PackageDocs = true
+		opts.GoGenerate = GoGenerateSwagger
+		opts.ReplayCommand = func(dir string) []string {
+			return []string{"swagger", "generate", "server", "--target=" + dir, "--name=docs api"}
+		}
+		opts.Events = func(e Event) {
+			if e.Kind == EventFileWritten || e.Kind == EventFileSkipped {
+				if rel, err := filepath.Rel(target, e.Path); err == nil {
+					events[filepath.ToSlash(rel)] = e
+				}
+			}
+		}
+
+		require.NoError(t, RunWithHooks(opts, func(opts *GenOpts) error {
+			return GenerateServer("docs", nil, nil, opts)
+		}))
+
+		return events
+	}
+
+	read := func(t *testing.T, name string) string {
+		t.Helper()
+
+		content, err := os.ReadFile(filepath.Join(target, filepath.FromSlash(name)))
+		require.NoError(t, err)
+
+		return string(content)
+	}
+
+	t.Run("should document the generated packages", func(t *testing.T) {
+		events := generate(t)
+		assert.EqualT(t, "packageDoc", events["models/doc.go"].Template)
+		assert.EqualT(t, "goGenerate", events["generate.go"].Template)
+
+		models := read(t, "models/doc.go")
+		assert.StringContainsT(t, models, "// Package models is generated by go-swagger from ../")
+		assert.StringContainsT(t, models, "fixture-1042.yaml (allOf marshalling 0.0).\n")
+		assert.StringContainsT(t, models, "// It holds the code of 2 definitions of the spec.\n")
+		assert.StringContainsT(t, models, "//\tswagger generate server --target=.. \"--name=docs api\"\n")
+
+		operations := read(t, "restapi/operations/doc.go")
+		assert.StringContainsT(t, operations, "// It holds the code of 1 operation of the spec.\n")
+		assert.StringContainsT(t, operations, "--target=../..")
+
+		// the documentation generated by the server templates is kept
+		assert.EqualT(t, "doc", events["restapi/doc.go"].Template)
+		assert.StringContainsT(t, read(t, "restapi/doc.go"), "// Package restapi allOf marshalling")
+
+		generated := read(t, "generate.go")
+		assert.StringContainsT(t, generated, "package docs\n")
+		assert.StringContainsT(t, generated, "//go:generate swagger generate server --target=. \"--name=docs api\"\n")
+	})
+
+	t.Run("should leave unchanged documentation untouched", func(t *testing.T) {
+		events := generate(t)
+		assert.EqualT(t, SkipReasonUnchanged, events["models/doc.go"].Reason)
+		assert.EqualT(t, SkipReasonUnchanged, events["generate.go"].Reason)
+	})
+
+	t.Run("should not override the documentation of a package", func(t *testing.T) {
+		const doc = "// Package models holds our models.\npackage models\n"
+		require.NoError(t, os.WriteFile(filepath.Join(target, "models", "doc.go"), []byte(doc), readableFile))
+
+		events := generate(t)
+		_, found := events["models/doc.go"]
+		assert.FalseT(t, found)
+		assert.EqualT(t, doc, read(t, "models/doc.go"))
+	})
+}
+
+func TestGoGenerateDirective(t *testing.T) {
+	opts := testGenOpts()
+	opts.GoGenerate = GoGenerateGoRun
+	assert.EqualT(t, "", opts.goGenerateDirective())
+
+	opts.ReplayCommand = func(dir string) []string {
+		return []string{"swagger", "generate", "model", "--target=" + dir}
+	}
+	var logs bytes.Buffer
+	opts.Logger = slog.New(slog.NewTextHandler(&logs, nil))
+	directive := opts.goGenerateDirective()
+	assert.TrueT(t, strings.HasPrefix(directive, "go run github.com/go-swagger/go-swagger/cmd/swagger"))
+	assert.TrueT(t, strings.HasSuffix(directive, " generate model --target=."))
+	// test binaries are development builds: the version cannot be pinned
+	assert.StringContainsT(t, logs.String(), "level=WARN")
+	assert.StringContainsT(t, logs.String(), "the go:generate directive runs the latest generator")
+
+	assert.TrueT(t, isReleased("v0.33.1"))
+	assert.TrueT(t, isReleased("v0.0.0-20261019111030-93bfcd19fe7a"))
+	assert.FalseT(t, isReleased("v0.0.0-20261019111030-93bfcd19fe7a+dirty"))
+	assert.FalseT(t, isReleased("(devel)"))
+}
@@ -0,0 +1,946 @@
+// SPDX-FileCopyrightText: Copyright 2015-2025 go-swagger maintainers
+// SPDX-License-Identifier: Apache-2.0
+
+package generator
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"maps"
+	"os"
+	"path"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/go-swagger/go-swagger/generator/internal/language"
+)
+
+const (
+	// protoFieldExtension sets the number of the field of a property, in its message.
+	protoFieldExtension = "x-proto-field"
+	// protoEnumExtension sets the numbers of the values of an enum, by value.
+	protoEnumExtension = "x-proto-enum"
+	// protoReservedExtension records the numbers of the removed fields of a message, or of the removed values of an enum.
+	protoReservedExtension = "x-proto-reserved"
+
+	// protoLockFile is the file name of the numbers of the last generation, next to the .proto file.
+	protoLockFile = "proto.lock.json"
+	// protoTemplate is the name of the template of the .proto file, in the layout.
+	protoTemplate = "proto"
+
+	// protoConversionsPackage is the package of the conversion functions between the models and the code generated by protoc.
+	protoConversionsPackage = "protoconv"
+	// protoAlias is the alias of the package generated by protoc, in the conversion functions.
+	protoAlias = "pb"
+
+	protoMaxNumber     = 1<<29 - 1
+	protoReservedFirst = 19000 // numbers reserved by the implementation of protocol buffers
+	protoReservedLast  = 19999
+)
+
+// ProtoOpts for rendering a spec as protocol buffers.
+//
+// Names are mangled like for go code, so that the conversion functions refer to the fields of the models.
+// Unlike go code, the .proto file may be generated outside of a go module.
+func ProtoOpts(extraInitialisms ...string) *language.Options {
+	opts := language.GolangOpts(extraInitialisms...)
+	opts.BaseImportFunc = func(target string) string {
+		base, _ := language.DefaultGoBaseImportErr(target)
+
+		return base
+	}
+
+	return opts
+}
+
+// ProtoSectionOpts for a given opts: a .proto file with a message per model,
+// and optionally go functions converting the models to and from the types generated by protoc.
+func ProtoSectionOpts(gen *GenOpts) {
+	gen.Sections.Models = nil
+	gen.Sections.PostModels = nil
+	gen.Sections.Operations = nil
+	gen.Sections.OperationGroups = nil
+	gen.Sections.SecuritySchemes = nil
+	gen.Sections.Tags = nil
+	gen.Sections.Responses = nil
+	gen.Sections.Parameters = nil
+	gen.LanguageOpts = ProtoOpts(gen.WithExtraInitialisms...)
+	gen.Sections.Application = []TemplateOpts{
+		{
+			Name:       protoTemplate,
+			Source:     "asset:protoSchema",
+			Target:     "{{ joinFilePath .Target \"proto\" }}",
+			FileName:   "{{ .Opts.ProtoPackage }}.proto",
+			SkipFormat: true,
+		},
+		{
+			Name:     "conversions",
+			Source:   "asset:protoConversions",
+			Target:   "{{ joinFilePath .Target \"" + protoConversionsPackage + "\" }}",
+			FileName: "conversions.go",
+			When:     ".Opts.ProtoConversions",
+		},
+	}
+}
+
+// GenerateProto generates a .proto file with messages for the definitions of a swagger specification.
+//
+// The numbers of fields are stable: they are set with the x-proto-field extension of properties,
+// or recorded in the target for the next generations.
+func GenerateProto(name string, modelNames []string, opts *GenOpts) error {
+	defer opts.beginGeneration()()
+
+	if opts.LanguageOpts == nil {
+		opts.LanguageOpts = ProtoOpts(opts.WithExtraInitialisms...)
+	}
+
+	if err := opts.EnsureDefaults(); err != nil {
+		return err
+	}
+	ProtoSectionOpts(opts)
+	opts.IgnoreOperations = true // only definitions are generated
+
+	generator, err := newAppGenerator(name, modelNames, nil, opts)
+	if err != nil {
+		return err
+	}
+
+	return generator.GenerateProto()
+}
+
+func (a *appGenerator) GenerateProto() error {
+	opts := a.GenOpts
+	if opts.ProtoPackage == "" {
+		opts.ProtoPackage = protoIdent(opts.LanguageOpts.Mangler.ToFileName(a.Name), "api")
+	}
+	for part := range strings.SplitSeq(opts.ProtoPackage, ".") {
+		if protoIdent(part, "") != part {
+			return fmt.Errorf("invalid proto package %q", opts.ProtoPackage)
+		}
+	}
+
+	if opts.ProtoConversions {
+		baseImport := opts.LanguageOpts.BaseImport(opts.Target)
+		if baseImport == "" && (opts.ExistingModels == "" || opts.ProtoGoPackage == "") {
+			return errors.New("conversions require a target in a go module, or both existing models and the go package of the .proto file")
+		}
+
+		if opts.ProtoGoPackage == "" {
+			// protoc generates the code next to the .proto file, with paths=source_relative
+			opts.ProtoGoPackage = path.Join(baseImport, "proto")
+		}
+	}
+
+	app, err := a.makeCodegenApp()
+	if err != nil {
+		return err
+	}
+
+	lockPath, err := opts.protoLockPath(&app)
+	if err != nil {
+		return err
+	}
+
+	lock, err := opts.readProtoLock(lockPath)
+	if err != nil {
+		return err
+	}
+
+	pascalize, ok := opts.funcMap["pascalize"].(func(string) string)
+	if !ok {
+		return errors.New("internal error: expected pascalize to be func(string) string")
+	}
+
+	if err := newProtoBuilder(opts.LanguageOpts, pascalize, lock).number(&app); err != nil {
+		return err
+	}
+
+	if a.DumpData {
+		return opts.dumpData(os.Stdout, app)
+	}
+
+	start := time.Now()
+	opts.openCache()
+	defer opts.closeCache()
+
+	if err := opts.renderApplication(&app); err != nil {
+		return err
+	}
+
+	if err := opts.writeProtoLock(lockPath, lock); err != nil {
+		return err
+	}
+
+	if err := opts.saveCache(); err != nil {
+		return err
+	}
+	opts.emitSince(start, Event{Kind: EventGenerationDone})
+
+	return nil
+}
+
+// protoLock records the numbers of the fields of messages and of the values of enums,
+// so they remain the same from a generation to the next one.
+//
+// It is meant to be committed with the .proto file.
+type protoLock struct {
+	Messages map[string]*protoNumbers `json:"messages"`
+	Enums    map[string]*protoNumbers `json:"enums"`
+}
+
+// protoNumbers are the numbers of the fields of a message, or of the values of an enum.
+type protoNumbers struct {
+	Numbers map[string]int `json:"numbers"`           // by field name, or by enum value
+	Removed map[string]int `json:"removed,omitempty"` // numbers of removed fields or values: they are reserved
+}
+
+// protoLockPath locates the lock of proto numbers, in the directory of the .proto file.
+func (g *GenOpts) protoLockPath(app *GenApp) (string, error) {
+	for i := range g.Sections.Application {
+		t := &g.Sections.Application[i]
+		if t.Name != protoTemplate {
+			continue
+		}
+
+		dir, _, err := g.location(t, app)
+		if err != nil {
+			return "", fmt.Errorf("failed to resolve the location of the .proto file: %w", err)
+		}
+
+		return filepath.Join(dir, protoLockFile), nil
+	}
+
+	return filepath.Join(g.Target, protoLockFile), nil
+}
+
+// readProtoLock reads the numbers of the previous generation.
+func (g *GenOpts) readProtoLock(pth string) (*protoLock, error) {
+	lock := &protoLock{
+		Messages: make(map[string]*protoNumbers),
+		Enums:    make(map[string]*protoNumbers),
+	}
+
+	buf, err := g.fs().ReadFile(pth)
+	if errors.Is(err, fs.ErrNotExist) {
+		return lock, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if err := json.Unmarshal(buf, lock); err != nil {
+		return nil, fmt.Errorf("invalid lock of proto numbers %s: %w", pth, err)
+	}
+
+	return lock, nil
+}
+
+func (g *GenOpts) writeProtoLock(pth string, lock *protoLock) error {
+	buf, err := json.MarshalIndent(lock, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	if err := g.fs().MkdirAll(filepath.Dir(pth), readAllDir); err != nil {
+		return err
+	}
+
+	return g.fs().WriteFile(pth, append(buf, '\n'), readAllFile)
+}
+
+// assignProtoNumbers numbers the fields of a message or the values of an enum, identified by keys.
+//
+// Explicit numbers come first, then the numbers of the previous generation. Other keys get new numbers,
+// above any number ever used: the numbers of removed keys are reserved, unless the key comes back.
+func assignProtoNumbers(what string, keys []string, explicit map[string]int, previous *protoNumbers) (*protoNumbers, error) {
+	assigned := &protoNumbers{
+		Numbers: make(map[string]int, len(keys)),
+		Removed: make(map[string]int),
+	}
+	if previous == nil {
+		previous = &protoNumbers{}
+	}
+	maps.Copy(assigned.Removed, previous.Removed)
+
+	highest := 0
+	taken := make(map[int]string, len(keys))
+	take := func(key string, number int) error {
+		if other, conflict := taken[number]; conflict {
+			return fmt.Errorf("%s: number %d is assigned to both %q and %q", what, number, other, key)
+		}
+		taken[number] = key
+		assigned.Numbers[key] = number
+		delete(assigned.Removed, key)
+		highest = max(highest, number)
+
+		return nil
+	}
+
+	for _, key := range keys {
+		number, ok := explicit[key]
+		if !ok {
+			continue
+		}
+
+		if number < 1 || number > protoMaxNumber || number >= protoReservedFirst && number <= protoReservedLast {
+			return nil, fmt.Errorf("%s: invalid number %d for %q", what, number, key)
+		}
+
+		if err := take(key, number); err != nil {
+			return nil, err
+		}
+	}
+
+	for _, key := range keys {
+		if _, ok := assigned.Numbers[key]; ok {
+			continue
+		}
+
+		number, ok := previous.Numbers[key]
+		if !ok {
+			number, ok = previous.Removed[key]
+		}
+		if !ok {
+			continue
+		}
+
+		if err := take(key, number); err != nil {
+			return nil, err
+		}
+	}
+
+	for key, number := range previous.Numbers {
+		if _, kept := assigned.Numbers[key]; !kept {
+			assigned.Removed[key] = number
+		}
+	}
+
+	for key, number := range assigned.Removed {
+		if other, conflict := taken[number]; conflict {
+			return nil, fmt.Errorf("%s: number %d of %q is reserved for the removed %q", what, number, other, key)
+		}
+		highest = max(highest, number)
+	}
+
+	next := highest + 1
+	for _, key := range keys {
+		if _, ok := assigned.Numbers[key]; ok {
+			continue
+		}
+
+		if next >= protoReservedFirst && next <= protoReservedLast {
+			next = protoReservedLast + 1
+		}
+		if err := take(key, next); err != nil {
+			return nil, err
+		}
+		next++
+	}
+
+	return assigned, nil
+}
+
+// protoFile is the data of the templates generating protocol buffers, built from the models by the "protoFile" function.
+type protoFile struct {
+	Package   string
+	GoPackage string   // go_package option
+	Imports   []string // imported .proto files
+	Messages  []*protoMessage
+	Enums     []*protoEnum
+
+	// for the conversion functions
+	GoImports     map[string]string // packages imported by the conversion functions, by alias
+	ProtoAlias    string
+	UsesJSON      bool // some values are converted to google.protobuf.Value through JSON
+	UsesTimestamp bool
+	UsesEnums     bool
+	UsesText      bool // some strings are converted with MarshalText and UnmarshalText
+}
+
+// protoMessage is a message, defined by a model.
+type protoMessage struct {
+	Name      string
+	GoName    string // name of the message in the code generated by protoc
+	Comment   []string
+	Fields    []*protoField
+	Reserved  []int
+	Removed   []string // names of removed fields
+	Model     string   // qualified go type of the model
+	Converted bool     // conversion functions are generated
+	ToProto   string   // statements converting the model m into the message p
+	FromProto string   // statements converting the message p into the model m
+
+	schema *GenSchema
+}
+
+// protoField is a field of a message.
+type protoField struct {
+	Name     string
+	JSONName string // the name of the property, when it is not the default JSON name of the field
+	Type     string
+	Number   int
+	Repeated bool
+	Optional bool
+	Comment  []string
+
+	key    string // name of the field, for numbering
+	goName string // name of the field in the model
+	schema *GenSchema
+	typ    *protoType
+}
+
+// protoEnum is an enum, defined by a named model or by a property.
+type protoEnum struct {
+	Name     string
+	GoName   string // name of the enum in the code generated by protoc
+	Values   []*protoEnumValue
+	Reserved []int
+
+	schema   *GenSchema
+	declared bool // defined by a model
+}
+
+// protoEnumValue is a value of an enum.
+type protoEnumValue struct {
+	Name   string
+	GoName string // name of the constant in the code generated by protoc
+	Value  string // value in the spec
+	Number int
+}
+
+// protoFileFunc builds the data of the templates generating protocol buffers from an application.
+func protoFileFunc(lang *language.Options, pascalize func(string) string) func(any) (*protoFile, error) {
+	return func(in any) (*protoFile, error) {
+		var app *GenApp
+		switch data := in.(type) {
+		case GenApp:
+			app = &data
+		case *GenApp:
+			app = data
+		default:
+			return nil, fmt.Errorf("protoFile should be called with a GenApp, but got: %T", in)
+		}
+
+		return newProtoBuilder(lang, pascalize, nil).build(app)
+	}
+}
+
+// protoBuilder maps the models of an application to protocol buffers.
+type protoBuilder struct {
+	lang      *language.Options
+	pascalize func(string) string
+	lock      *protoLock // numbers of the previous generation, when numbering. Otherwise, numbers are read from extensions
+
+	file        *protoFile
+	messages    map[string]*protoMessage
+	enums       map[string]*protoEnum
+	polymorphic map[string]bool
+	modelsAlias string
+}
+
+func newProtoBuilder(lang *language.Options, pascalize func(string) string, lock *protoLock) *protoBuilder {
+	return &protoBuilder{
+		lang:        lang,
+		pascalize:   pascalize,
+		lock:        lock,
+		messages:    make(map[string]*protoMessage),
+		enums:       make(map[string]*protoEnum),
+		polymorphic: make(map[string]bool),
+	}
+}
+
+// number assigns stable numbers to the fields of messages and to the values of enums, using the lock of
+// the previous generation. Numbers are recorded as extensions of the schemas, for templates, and in the lock.
+func (b *protoBuilder) number(app *GenApp) error {
+	file, err := b.build(app)
+	if err != nil {
+		return err
+	}
+
+	for _, message := range file.Messages {
+		for _, field := range message.Fields {
+			setProtoExtension(field.schema, protoFieldExtension, field.Number)
+		}
+	}
+
+	for _, enum := range file.Enums {
+		numbers := make(map[string]int, len(enum.Values))
+		for _, value := range enum.Values {
+			numbers[value.Value] = value.Number
+		}
+		setProtoExtension(enum.schema, protoEnumExtension, numbers)
+	}
+
+	return nil
+}
+
+func (b *protoBuilder) build(app *GenApp) (*protoFile, error) {
+	b.file = &protoFile{
+		ProtoAlias: protoAlias,
+		GoImports:  make(map[string]string),
+	}
+	if app.GenOpts != nil {
+		b.file.Package = app.GenOpts.ProtoPackage
+		b.file.GoPackage = app.GenOpts.ProtoGoPackage
+	}
+	b.resolveImports(app)
+
+	// messages and named enums are known before their fields refer to them
+	for i := range app.Models {
+		model := &app.Models[i]
+		if model.IsBaseType || model.IsSubType {
+			b.polymorphic[protoTypeName(model.GoType)] = true
+		}
+
+		b.declare(&model.GenSchema)
+		for j := range model.ExtraSchemas {
+			b.declare(&model.ExtraSchemas[j])
+		}
+	}
+
+	for _, message := range b.file.Messages {
+		if err := b.buildMessage(message); err != nil {
+			return nil, err
+		}
+	}
+
+	for _, enum := range b.file.Enums {
+		if err := b.buildEnum(enum); err != nil {
+			return nil, err
+		}
+	}
+
+	slices.SortFunc(b.file.Messages, func(a, b *protoMessage) int { return strings.Compare(a.Name, b.Name) })
+	slices.SortFunc(b.file.Enums, func(a, b *protoEnum) int { return strings.Compare(a.Name, b.Name) })
+	slices.Sort(b.file.Imports)
+	b.file.Imports = slices.Compact(b.file.Imports)
+
+	return b.file, nil
+}
+
+// resolveImports resolves the packages of the models, and the go package generated by protoc, for conversions.
+func (b *protoBuilder) resolveImports(app *GenApp) {
+	b.modelsAlias = path.Base(defaultModelsTarget)
+	if app.GenOpts != nil {
+		b.modelsAlias = b.lang.ManglePackageName(app.GenOpts.ModelPackage, defaultModelsTarget)
+		if app.GenOpts.ExistingModels != "" {
+			b.modelsAlias = path.Base(defaultModelsTarget)
+		}
+	}
+
+	maps.Copy(b.file.GoImports, app.DefaultImports)
+	if goPackage, _, _ := strings.Cut(b.file.GoPackage, ";"); goPackage != "" {
+		b.file.GoImports[protoAlias] = goPackage
+	}
+}
+
+// declare a message or an enum for a named schema.
+func (b *protoBuilder) declare(schema *GenSchema) {
+	name := protoTypeName(schema.GoType)
+
+	if protoIsEnum(schema) {
+		b.addEnum(name, schema).declared = true
+
+		return
+	}
+
+	if !protoIsMessage(schema) {
+		return
+	}
+
+	message := &protoMessage{
+		Name:      name,
+		GoName:    protoGoCamelCase(name),
+		Comment:   protoComment(schema.Title, schema.Description),
+		Model:     b.qualify(schema.GoType),
+		Converted: !b.polymorphic[name],
+		schema:    schema,
+	}
+	b.messages[name] = message
+	b.file.Messages = append(b.file.Messages, message)
+}
+
+func (b *protoBuilder) addEnum(name string, schema *GenSchema) *protoEnum {
+	if enum, ok := b.enums[name]; ok {
+		return enum
+	}
+
+	enum := &protoEnum{
+		Name:   name,
+		GoName: protoGoCamelCase(name),
+		schema: schema,
+	}
+	b.enums[name] = enum
+	b.file.Enums = append(b.file.Enums, enum)
+
+	return enum
+}
+
+func (b *protoBuilder) buildMessage(message *protoMessage) error {
+	schema := message.schema
+	var fields []*protoField
+
+	// properties of anonymous members of allOf are promoted to the model, like named members are embedded
+	for i := range schema.AllOf {
+		member := &schema.AllOf[i]
+		if member.IsAnonymous || member.GoType == "" {
+			for j := range member.Properties {
+				fields = append(fields, b.propertyField(message, &member.Properties[j]))
+			}
+
+			continue
+		}
+
+		goName := protoTypeName(member.GoType)
+		fields = append(fields, &protoField{
+			Name:    protoIdent(b.lang.Mangler.ToFileName(goName), "field"),
+			Comment: protoComment(member.Title, member.Description),
+			goName:  goName,
+			schema:  member,
+			typ:     b.resolve(member, message.Name+goName, false),
+		})
+	}
+
+	for i := range schema.Properties {
+		fields = append(fields, b.propertyField(message, &schema.Properties[i]))
+	}
+
+	keys := make([]string, 0, len(fields))
+	explicit := make(map[string]int)
+	for _, field := range fields {
+		field.Name = uniqueProtoName(field.Name, keys)
+		field.key = field.Name
+		keys = append(keys, field.key)
+
+		if number, ok := protoNumber(field.schema.Extensions[protoFieldExtension]); ok {
+			explicit[field.key] = number
+		}
+
+		field.Type = field.typ.proto
+		field.Repeated = field.typ.kind == protoRepeated
+		field.Optional = field.typ.optional()
+		if field.Repeated {
+			field.Type = field.typ.elem.proto
+		}
+	}
+
+	numbers, err := assignProtoNumbers("message "+message.Name, keys, explicit, b.previous(b.lockedMessages(), message.Name, schema))
+	if err != nil {
+		return err
+	}
+	b.record(b.lockedMessages(), message.Name, schema, numbers)
+
+	for _, field := range fields {
+		field.Number = numbers.Numbers[field.key]
+	}
+	message.Fields = fields
+	message.Reserved, message.Removed = protoReserved(numbers.Removed)
+
+	if message.Converted && b.lock == nil {
+		message.ToProto, message.FromProto = b.conversions(message)
+	}
+
+	return nil
+}
+
+func (b *protoBuilder) propertyField(message *protoMessage, property *GenSchema) *protoField {
+	goName := b.pascalize(property.Name)
+	name := property.OriginalName
+	if name == "" {
+		name = property.Name
+	}
+
+	field := &protoField{
+		Name:    protoIdent(b.lang.Mangler.ToFileName(name), "field"),
+		Comment: protoComment(property.Title, property.Description),
+		goName:  goName,
+		schema:  property,
+		typ:     b.resolve(property, message.Name+goName, !property.IsMap && property.IsNullable && !property.IsSuperAlias),
+	}
+
+	if protoJSONCamelCase(field.Name) != name {
+		field.JSONName = name
+	}
+
+	return field
+}
+
+func (b *protoBuilder) buildEnum(enum *protoEnum) error {
+	prefix := strings.ToUpper(b.lang.Mangler.ToFileName(enum.Name)) + "_"
+	values := make([]*protoEnumValue, 0, len(enum.schema.Enum))
+	keys := make([]string, 0, len(enum.schema.Enum))
+	names := []string{prefix + "UNSPECIFIED"}
+
+	for _, v := range enum.schema.Enum {
+		if v == nil {
+			continue
+		}
+
+		value := fmt.Sprint(v)
+		if slices.Contains(keys, value) {
+			continue
+		}
+		keys = append(keys, value)
+
+		name := uniqueProtoName(prefix+strings.ToUpper(protoIdent(b.lang.Mangler.ToFileName(value), "empty")), names)
+		names = append(names, name)
+		values = append(values, &protoEnumValue{
+			Name:   name,
+			GoName: enum.GoName + "_" + name,
+			Value:  value,
+		})
+	}
+
+	explicit := make(map[string]int)
+	for key, v := range protoNumberMap(enum.schema.Extensions[protoEnumExtension]) {
+		explicit[key] = v
+	}
+
+	numbers, err := assignProtoNumbers("enum "+enum.Name, keys, explicit, b.previous(b.lockedEnums(), enum.Name, enum.schema))
+	if err != nil {
+		return err
+	}
+	b.record(b.lockedEnums(), enum.Name, enum.schema, numbers)
+
+	for _, value := range values {
+		value.Number = numbers.Numbers[value.Value]
+	}
+	enum.Values = append([]*protoEnumValue{{Name: names[0], GoName: enum.GoName + "_" + names[0]}}, values...)
+	enum.Reserved, _ = protoReserved(numbers.Removed)
+
+	return nil
+}
+
+func (b *protoBuilder) lockedMessages() map[string]*protoNumbers {
+	if b.lock == nil {
+		return nil
+	}
+
+	return b.lock.Messages
+}
+
+func (b *protoBuilder) lockedEnums() map[string]*protoNumbers {
+	if b.lock == nil {
+		return nil
+	}
+
+	return b.lock.Enums
+}
+
+// previous numbers of a message or an enum: from the lock when numbering, or the reserved numbers recorded
+// as an extension, when the numbers are already assigned.
+func (b *protoBuilder) previous(locked map[string]*protoNumbers, name string, schema *GenSchema) *protoNumbers {
+	if b.lock != nil {
+		return locked[name]
+	}
+
+	return &protoNumbers{Removed: protoNumberMap(schema.Extensions[protoReservedExtension])}
+}
+
+func (b *protoBuilder) record(locked map[string]*protoNumbers, name string, schema *GenSchema, numbers *protoNumbers) {
+	if b.lock == nil {
+		return
+	}
+
+	locked[name] = numbers
+	if len(numbers.Removed) > 0 {
+		setProtoExtension(schema, protoReservedExtension, numbers.Removed)
+	}
+}
+
+// qualify a go type of the models, to refer to it from the package of conversions.
+func (b *protoBuilder) qualify(goType string) string {
+	if goType == "" || strings.Contains(goType, ".") || protoGoBuiltins[goType] {
+		return goType
+	}
+
+	return b.modelsAlias + "." + goType
+}
+
+// protoIsMessage tells if a named schema is defined as a message.
+func protoIsMessage(schema *GenSchema) bool {
+	if schema.IsArray || schema.IsMap || schema.IsTuple || schema.IsInterface || schema.IsPrimitive || schema.IsExternal {
+		return false
+	}
+
+	return len(schema.Properties) > 0 || len(schema.AllOf) > 0 || schema.IsComplexObject
+}
+
+// protoIsEnum tells if a schema is a string enum, defined as a proto enum.
+func protoIsEnum(schema *GenSchema) bool {
+	return len(schema.Enum) > 0 && schema.SwaggerType == "string" && !schema.IsArray && !schema.IsMap
+}
+
+// protoTypeName is the name of a message or an enum defined by a model, from its go type.
+func protoTypeName(goType string) string {
+	goType = strings.TrimLeft(goType, "*")
+	if i := strings.LastIndex(goType, "."); i >= 0 {
+		return goType[i+1:]
+	}
+
+	return goType
+}
+
+func setProtoExtension(schema *GenSchema, key string, value any) {
+	// the extensions may be shared with other schemas
+	extensions := make(map[string]any, len(schema.Extensions)+1)
+	maps.Copy(extensions, schema.Extensions)
+	extensions[key] = value
+	schema.Extensions = extensions
+}
+
+func protoNumber(v any) (int, bool) {
+	switch n := v.(type) {
+	case int:
+		return n, true
+	case int64:
+		return int(n), true
+	case float64:
+		return int(n), n == float64(int(n))
+	case json.Number:
+		i, err := strconv.Atoi(n.String())
+
+		return i, err == nil
+	default:
+		return 0, false
+	}
+}
+
+func protoNumberMap(v any) map[string]int {
+	switch m := v.(type) {
+	case map[string]int:
+		return m
+	case map[string]any:
+		numbers := make(map[string]int, len(m))
+		for key, value := range m {
+			if number, ok := protoNumber(value); ok {
+				numbers[key] = number
+			}
+		}
+
+		return numbers
+	default:
+		return nil
+	}
+}
+
+// protoReserved lists the reserved numbers and names of removed fields or values.
+func protoReserved(removed map[string]int) ([]int, []string) {
+	numbers := slices.Sorted(maps.Values(removed))
+	names := slices.Sorted(maps.Keys(removed))
+
+	return slices.Compact(numbers), names
+}
+
+// protoIdent makes an identifier of protocol buffers from a name.
+func protoIdent(name, fallback string) string {
+	var b strings.Builder
+	for _, r := range name {
+		switch {
+		case r >= 'a' && r <= 'z', r >= 'A' && r <= 'Z', r >= '0' && r <= '9', r == '_':
+			b.WriteRune(r)
+		default:
+			b.WriteByte('_')
+		}
+	}
+
+	ident := strings.Trim(b.String(), "_")
+	switch {
+	case ident == "":
+		return fallback
+	case ident[0] >= '0' && ident[0] <= '9':
+		return fallback + "_" + ident
+	default:
+		return ident
+	}
+}
+
+func uniqueProtoName(name string, taken []string) string {
+	unique := name
+	for i := 2; slices.Contains(taken, unique); i++ {
+		unique = name + "_" + strconv.Itoa(i)
+	}
+
+	return unique
+}
+
+func protoComment(title, description string) []string {
+	text := strings.TrimSpace(description)
+	if text == "" {
+		text = strings.TrimSpace(title)
+	}
+	if text == "" {
+		return nil
+	}
+
+	return strings.Split(text, "\n")
+}
+
+// protoGoCamelCase is the go name of an identifier in the code generated by protoc.
+func protoGoCamelCase(s string) string {
+	var b []byte
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		switch {
+		case c == '_' && i == 0:
+			b = append(b, 'X')
+		case c == '_' && i+1 < len(s) && isASCIILower(s[i+1]):
+			// skipped: the next letter is upper cased
+		case isASCIIDigit(c):
+			b = append(b, c)
+		default:
+			if isASCIILower(c) {
+				c -= 'a' - 'A'
+			}
+			b = append(b, c)
+			for ; i+1 < len(s) && isASCIILower(s[i+1]); i++ {
+				b = append(b, s[i+1])
+			}
+		}
+	}
+
+	return string(b)
+}
+
+// protoGoFieldName is the go name of a field in the code generated by protoc.
+func protoGoFieldName(name string) string {
+	goName := protoGoCamelCase(name)
+	if protoGoReservedNames[goName] {
+		goName += "_"
+	}
+
+	return goName
+}
+
+// protoJSONCamelCase is the default JSON name of a field.
+func protoJSONCamelCase(s string) string {
+	var b []byte
+	var wasUnderscore bool
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		if c != '_' {
+			if wasUnderscore && isASCIILower(c) {
+				c -= 'a' - 'A'
+			}
+			b = append(b, c)
+		}
+		wasUnderscore = c == '_'
+	}
+
+	return string(b)
+}
+
+func isASCIILower(c byte) bool { return c >= 'a' && c <= 'z' }
+func isASCIIDigit(c byte) bool { return c >= '0' && c <= '9' }
+
+// protoGoReservedNames are the names of the methods of the messages generated by protoc:
+// fields with these names are suffixed.
+var protoGoReservedNames = map[string]bool{
+	"Reset": true, "String": true, "ProtoMessage": true, "ProtoReflect": true, "Descriptor": true,
+	"Marshal": true, "Unmarshal": true, "ExtensionRangeArray": true, "ExtensionMap": true,
+}
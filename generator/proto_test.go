@@ -0,0 +1,199 @@
+// SPDX-FileCopyrightText: Copyright 2015-2025 go-swagger maintainers
+// SPDX-License-Identifier: Apache-2.0
+
+package generator
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/go-openapi/testify/v2/assert"
+	"github.com/go-openapi/testify/v2/require"
+)
+
+func TestGenerateProto(t *testing.T) {
+	defer discardOutput()()
+
+	target := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(target, "go.mod"), []byte("module example.com/petstore\n\ngo 1.25\n"), 0o600))
+
+	generate := func(t *testing.T, spec string) {
+		t.Helper()
+
+		opts := testGenOpts()
+		opts.LanguageOpts = nil
+		opts.Spec = spec
+		opts.Target = target
+		opts.ProtoConversions = true
+		require.NoError(t, GenerateProto("petstore", nil, opts))
+	}
+
+	read := func(t *testing.T, name string) string {
+		t.Helper()
+
+		content, err := os.ReadFile(filepath.Join(target, filepath.FromSlash(name)))
+		require.NoError(t, err)
+
+		return string(content)
+	}
+
+	generate(t, "../fixtures/codegen/proto.yml")
+
+	t.Run("should generate messages and enums for definitions", func(t *testing.T) {
+		code := read(t, "proto/petstore.proto")
+
+		for _, line := range []string{
+			"// Code generated by go-swagger; DO NOT EDIT.",
+			`syntax = "proto3";`,
+			"package petstore;",
+			`import "google/protobuf/timestamp.proto";`,
+			`option go_package = "example.com/petstore/proto";`,
+			"enum PetKind {\n  PET_KIND_UNSPECIFIED = 0;\n  PET_KIND_DOG = 1; // \"dog\"\n  PET_KIND_CAT = 2; // \"cat\"\n}",
+			"message Dog {\n  Pet pet = 1;\n  bool barks = 2;\n}",
+			"  optional int32 age = 1;",
+			"  google.protobuf.Duration age = 9;",
+			"  google.protobuf.Value any = 10;",
+			"  google.protobuf.Timestamp born = 12;",
+			"  repeated Pet friends = 13;",
+			"  optional PetKind kind = 14;",
+			"  map<string, string> labels = 15;",
+			"  google.protobuf.Value matrix = 16;",
+			"  map<string, Owner> owners = 20;",
+			"  bytes photo = 21;",
+			"  float weight = 24;",
+			`  double x_rate = 25 [json_name = "x-rate"];`,
+			"message PetAddress {\n  string street = 1;\n}",
+		} {
+			assert.StringContainsT(t, code, line)
+		}
+	})
+
+	t.Run("should number with the extensions first", func(t *testing.T) {
+		code := read(t, "proto/petstore.proto")
+
+		assert.StringContainsT(t, code, "  int64 id = 7;")
+		assert.StringContainsT(t, code, "enum Status {\n  STATUS_UNSPECIFIED = 0;\n  STATUS_ACTIVE = 6; // \"active\"\n  STATUS_RETIRED = 5; // \"retired\"\n}")
+	})
+
+	t.Run("should generate conversions", func(t *testing.T) {
+		code := read(t, "protoconv/conversions.go")
+
+		for _, line := range []string{
+			"package protoconv",
+			`pb "example.com/petstore/proto"`,
+			`"dog": pb.PetKind_PET_KIND_DOG,`,
+			"func PetToProto(m *models.Pet) (*pb.Pet, error) {",
+			"func PetFromProto(p *pb.Pet) (*models.Pet, error) {",
+			"p.Born = protoTimestamp(m.Born)",
+			"m.Born = strfmt.DateTime(p.Born.AsTime())",
+			"p.Tags = m.Tags",
+			`return nil, fmt.Errorf("converting Pet.kind: %w", err)`,
+			"v2, err := modelText[strfmt.Email](*p.Email)",
+			"// Owner.animal is not converted: polymorphic types are not converted",
+		} {
+			assert.StringContainsT(t, code, line)
+		}
+		assert.StringNotContainsT(t, code, "func AnimalToProto")
+	})
+
+	t.Run("should keep numbers and reserve removed fields", func(t *testing.T) {
+		spec, err := os.ReadFile("../fixtures/codegen/proto.yml")
+		require.NoError(t, err)
+		changed := filepath.Join(t.TempDir(), "proto.yml")
+		require.NoError(t, os.WriteFile(changed, []byte(strings.Replace(string(spec), "      nickname:", "      color:", 1)), 0o600))
+
+		generate(t, changed)
+		code := read(t, "proto/petstore.proto")
+		assert.StringContainsT(t, code, "message Pet {\n  reserved 18;\n  reserved \"nickname\";")
+		assert.StringContainsT(t, code, "  optional string color = 26;")
+		assert.StringContainsT(t, code, "  int64 id = 7;")
+		assert.StringContainsT(t, code, "  double x_rate = 25 [json_name = \"x-rate\"];")
+
+		generate(t, "../fixtures/codegen/proto.yml")
+		code = read(t, "proto/petstore.proto")
+		assert.StringContainsT(t, code, "  optional string nickname = 18;")
+		assert.StringContainsT(t, code, "message Pet {\n  reserved 26;\n  reserved \"color\";")
+	})
+
+	t.Run("should record numbers next to the .proto file", func(t *testing.T) {
+		assert.FileExists(t, filepath.Join(target, "proto", protoLockFile))
+		assert.FileNotExists(t, filepath.Join(target, cacheDir, protoLockFile))
+	})
+}
+
+func TestGenerateProtoOutsideModule(t *testing.T) {
+	defer discardOutput()()
+
+	opts := testGenOpts()
+	opts.LanguageOpts = nil
+	opts.Spec = "../fixtures/codegen/proto.yml"
+	opts.Target = t.TempDir()
+	opts.ProtoPackage = "acme.pets.v1"
+	require.NoError(t, GenerateProto("", nil, opts))
+
+	matches, err := filepath.Glob(filepath.Join(opts.Target, "proto", "*.proto"))
+	require.NoError(t, err)
+	require.Len(t, matches, 1)
+	content, err := os.ReadFile(matches[0])
+	require.NoError(t, err)
+	assert.StringContainsT(t, string(content), "package acme.pets.v1;")
+	assert.StringNotContainsT(t, string(content), "go_package")
+
+	opts.ProtoConversions = true
+	require.ErrorContains(t, GenerateProto("", nil, opts), "conversions require a target in a go module")
+
+	opts.ProtoConversions = false
+	opts.ProtoPackage = "acme-pets"
+	require.ErrorContains(t, GenerateProto("", nil, opts), `invalid proto package "acme-pets"`)
+}
+
+func TestAssignProtoNumbers(t *testing.T) {
+	t.Run("should number new keys after all known numbers", func(t *testing.T) {
+		numbers, err := assignProtoNumbers("message M", []string{"a", "b", "c", "d"}, map[string]int{"c": 3}, &protoNumbers{
+			Numbers: map[string]int{"a": 1, "e": 5},
+		})
+		require.NoError(t, err)
+		assert.Equal(t, map[string]int{"a": 1, "b": 6, "c": 3, "d": 7}, numbers.Numbers)
+		assert.Equal(t, map[string]int{"e": 5}, numbers.Removed)
+	})
+
+	t.Run("should restore the number of a key which comes back", func(t *testing.T) {
+		numbers, err := assignProtoNumbers("message M", []string{"a", "e"}, nil, &protoNumbers{
+			Numbers: map[string]int{"a": 1},
+			Removed: map[string]int{"e": 5},
+		})
+		require.NoError(t, err)
+		assert.Equal(t, map[string]int{"a": 1, "e": 5}, numbers.Numbers)
+		assert.Empty(t, numbers.Removed)
+	})
+
+	t.Run("should skip the numbers reserved by protocol buffers", func(t *testing.T) {
+		numbers, err := assignProtoNumbers("message M", []string{"a", "b"}, map[string]int{"a": 18999}, nil)
+		require.NoError(t, err)
+		assert.EqualT(t, 20000, numbers.Numbers["b"])
+	})
+
+	t.Run("should reject conflicting numbers", func(t *testing.T) {
+		_, err := assignProtoNumbers("message M", []string{"a", "b"}, map[string]int{"a": 1, "b": 1}, nil)
+		require.ErrorContains(t, err, `message M: number 1 is assigned to both "a" and "b"`)
+
+		_, err = assignProtoNumbers("message M", []string{"a"}, map[string]int{"a": 5}, &protoNumbers{
+			Removed: map[string]int{"e": 5},
+		})
+		require.ErrorContains(t, err, `number 5 of "a" is reserved for the removed "e"`)
+
+		_, err = assignProtoNumbers("message M", []string{"a"}, map[string]int{"a": 19500}, nil)
+		require.ErrorContains(t, err, `invalid number 19500 for "a"`)
+	})
+}
+
+func TestProtoNames(t *testing.T) {
+	assert.EqualT(t, "PetKind", protoGoCamelCase("PetKind"))
+	assert.EqualT(t, "XRate", protoGoCamelCase("x_rate"))
+	assert.EqualT(t, "String_", protoGoFieldName("string"))
+	assert.EqualT(t, "xRate", protoJSONCamelCase("x_rate"))
+	assert.EqualT(t, "field_2xx", protoIdent("2xx", "field"))
+	assert.EqualT(t, "a_b", protoIdent("a-b", "field"))
+}
@@ -0,0 +1,560 @@
+// SPDX-FileCopyrightText: Copyright 2015-2025 go-swagger maintainers
+// SPDX-License-Identifier: Apache-2.0
+
+package generator
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+const (
+	protoTimestampType = "google.protobuf.Timestamp"
+	protoDurationType  = "google.protobuf.Duration"
+	protoValueType     = "google.protobuf.Value"
+)
+
+// protoKind tells how a value is represented by protocol buffers.
+type protoKind int
+
+const (
+	protoScalar      protoKind = iota
+	protoEnumKind              // a string enum
+	protoText                  // a string format, converted with MarshalText and UnmarshalText
+	protoBytes                 // a base64 encoded string
+	protoTimestamp             // a date-time
+	protoDuration              // a duration
+	protoMessageKind           // a model with properties
+	protoJSON                  // any JSON value, e.g. nested containers or interfaces
+	protoRepeated              // an array
+	protoMap                   // a map with string keys
+)
+
+// protoType is the representation of a schema by protocol buffers, and its go types on both sides of conversions.
+type protoType struct {
+	kind    protoKind
+	proto   string     // type in the .proto file
+	goProto string     // go type in the code generated by protoc, for a scalar
+	model   string     // qualified go type of the model, without pointer
+	pointer bool       // the model holds a pointer
+	elem    *protoType // element of an array or a map
+	enum    *protoEnum
+	message *protoMessage
+	skip    string // the reason why this value is not converted
+}
+
+// optional fields have explicit presence: a nullable scalar or enum.
+func (t *protoType) optional() bool {
+	switch t.kind {
+	case protoScalar, protoEnumKind, protoText:
+		return t.pointer
+	default:
+		return false
+	}
+}
+
+// protoScalars maps the swagger types and formats of scalars to protocol buffers and go types.
+var protoScalars = map[string][2]string{
+	"string":         {"string", "string"},
+	"boolean":        {"bool", "bool"},
+	"integer":        {"int64", "int64"},
+	"integer/int8":   {"int32", "int32"},
+	"integer/int16":  {"int32", "int32"},
+	"integer/int32":  {"int32", "int32"},
+	"integer/int64":  {"int64", "int64"},
+	"integer/uint8":  {"uint32", "uint32"},
+	"integer/uint16": {"uint32", "uint32"},
+	"integer/uint32": {"uint32", "uint32"},
+	"integer/uint64": {"uint64", "uint64"},
+	"number":         {"double", "float64"},
+	"number/float":   {"float", "float32"},
+	"number/double":  {"double", "float64"},
+}
+
+// protoGoBuiltins are the predeclared go types, which are not qualified by the package of models.
+var protoGoBuiltins = map[string]bool{
+	"any": true, "bool": true, "byte": true, "error": true, "rune": true, "string": true,
+	"int": true, "int8": true, "int16": true, "int32": true, "int64": true,
+	"uint": true, "uint8": true, "uint16": true, "uint32": true, "uint64": true, "uintptr": true,
+	"float32": true, "float64": true, "complex64": true, "complex128": true,
+	"map": true, "interface": true, "struct": true, "func": true, "chan": true,
+}
+
+var rxGoIdent = regexp.MustCompile(`\.?[A-Za-z_][A-Za-z0-9_]*(\.[A-Za-z_][A-Za-z0-9_]*)?`)
+
+// qualifyAll qualifies the models in a go type expression, e.g. map[string][]*Pet.
+func (b *protoBuilder) qualifyAll(goType string) string {
+	return rxGoIdent.ReplaceAllStringFunc(goType, func(ident string) string {
+		if strings.Contains(ident, ".") {
+			return ident
+		}
+
+		return b.qualify(ident)
+	})
+}
+
+// resolve the representation of a schema: enums defined by properties are named after them.
+func (b *protoBuilder) resolve(schema *GenSchema, enumName string, pointer bool) *protoType {
+	goType := strings.TrimPrefix(schema.GoType, "*")
+	t := &protoType{
+		model:   b.qualifyAll(goType),
+		pointer: pointer,
+	}
+	name := protoTypeName(goType)
+
+	switch {
+	case schema.IsStream:
+		t.kind, t.proto, t.skip = protoBytes, "bytes", "binary streams are not converted"
+
+	case schema.IsArray && schema.Items != nil:
+		elemGoType, literal := strings.CutPrefix(goType, "[]")
+		t.elem = b.resolve(schema.Items, enumName, protoElemPointer(elemGoType, literal, schema.Items))
+		t.kind = protoRepeated
+		t.proto = "repeated " + t.elem.proto
+		if t.elem.kind == protoRepeated || t.elem.kind == protoMap {
+			b.asJSON(t)
+		}
+
+	case schema.IsMap && schema.AdditionalProperties != nil:
+		_, elemGoType, literal := strings.Cut(goType, "]")
+		t.elem = b.resolve(schema.AdditionalProperties, enumName, protoElemPointer(elemGoType, literal, schema.AdditionalProperties))
+		t.kind = protoMap
+		t.proto = "map<string, " + t.elem.proto + ">"
+		if t.elem.kind == protoRepeated || t.elem.kind == protoMap {
+			b.asJSON(t)
+		}
+
+	case b.messages[name] != nil && !schema.IsArray && !schema.IsMap:
+		t.kind = protoMessageKind
+		t.message = b.messages[name]
+		t.proto = name
+		if b.polymorphic[name] {
+			t.skip = "polymorphic types are not converted"
+		}
+
+	case b.enums[name] != nil && b.enums[name].declared:
+		t.kind = protoEnumKind
+		t.enum = b.enums[name]
+		t.proto = name
+
+	case protoIsEnum(schema):
+		t.kind = protoEnumKind
+		t.enum = b.addEnum(b.uniqueTypeName(enumName, schema), schema)
+		t.proto = t.enum.Name
+
+	case schema.IsCustomFormatter && strings.HasPrefix(goType, "strfmt."):
+		switch goType {
+		case "strfmt.DateTime":
+			t.kind, t.proto = protoTimestamp, protoTimestampType
+			b.file.Imports = append(b.file.Imports, "google/protobuf/timestamp.proto")
+		case "strfmt.Duration":
+			t.kind, t.proto = protoDuration, protoDurationType
+			b.file.Imports = append(b.file.Imports, "google/protobuf/duration.proto")
+		case "strfmt.Base64":
+			t.kind, t.proto, t.goProto = protoBytes, "bytes", "[]byte"
+		default:
+			t.kind, t.proto, t.goProto = protoText, "string", "string"
+		}
+
+	case (schema.IsPrimitive || schema.IsAliased) && !schema.IsCustomFormatter && !schema.IsExternal:
+		scalar, ok := protoScalars[schema.SwaggerType+"/"+schema.SwaggerFormat]
+		if !ok {
+			scalar, ok = protoScalars[schema.SwaggerType]
+		}
+		if !ok {
+			b.asJSON(t)
+
+			break
+		}
+		t.kind, t.proto, t.goProto = protoScalar, scalar[0], scalar[1]
+
+	default:
+		// interfaces, tuples, external types and anything else are preserved as JSON
+		b.asJSON(t)
+	}
+
+	return t
+}
+
+// protoElemPointer tells if the elements of a slice or a map are pointers, from the go type of the container,
+// or from the schema of elements, when the container is a named type.
+func protoElemPointer(elemGoType string, literal bool, elem *GenSchema) bool {
+	if literal {
+		return strings.HasPrefix(elemGoType, "*")
+	}
+
+	return !elem.IsMap && elem.IsNullable && !elem.IsSuperAlias
+}
+
+func (b *protoBuilder) asJSON(t *protoType) {
+	t.kind, t.proto, t.elem = protoJSON, protoValueType, nil
+	b.file.Imports = append(b.file.Imports, "google/protobuf/struct.proto")
+}
+
+// uniqueTypeName deconflicts the name of an enum defined by a property from the names of other types.
+func (b *protoBuilder) uniqueTypeName(name string, schema *GenSchema) string {
+	unique := name
+	for i := 2; ; i++ {
+		enum, isEnum := b.enums[unique]
+		_, isMessage := b.messages[unique]
+		if !isMessage && (!isEnum || enum.schema == schema) {
+			return unique
+		}
+		unique = name + strconv.Itoa(i)
+	}
+}
+
+// conversions builds the bodies of the functions converting a model to its message, and back.
+//
+// The model is m and the message is p.
+func (b *protoBuilder) conversions(message *protoMessage) (string, string) {
+	to := &protoConversion{builder: b}
+	from := &protoConversion{builder: b}
+
+	for _, field := range message.Fields {
+		what := message.Name + "." + field.Name
+		if reason := field.typ.unconverted(); reason != "" {
+			to.linef("// %s is not converted: %s", what, reason)
+			from.linef("// %s is not converted: %s", what, reason)
+
+			continue
+		}
+
+		pbField := "p." + protoGoFieldName(field.Name)
+		modelField := "m." + field.goName
+		to.toProto(field.typ, modelField, field.typ.pointer, field.Optional, assign(pbField), what)
+		from.fromProto(field.typ, pbField, field.Optional, field.typ.pointer, assign(modelField), what)
+	}
+
+	return to.String(), from.String()
+}
+
+// unconverted tells why a value is not converted, if it isn't.
+func (t *protoType) unconverted() string {
+	if t.skip != "" {
+		return t.skip
+	}
+	if t.elem != nil {
+		return t.elem.unconverted()
+	}
+
+	return ""
+}
+
+func assign(lvalue string) func(string) string {
+	return func(expr string) string { return lvalue + " = " + expr }
+}
+
+// protoConversion writes the statements converting values between models and messages.
+type protoConversion struct {
+	strings.Builder
+
+	builder *protoBuilder
+	vars    int
+}
+
+func (c *protoConversion) linef(format string, args ...any) {
+	fmt.Fprintf(c, format, args...)
+	c.WriteByte('\n')
+}
+
+func (c *protoConversion) newVar(prefix string) string {
+	c.vars++
+
+	return prefix + strconv.Itoa(c.vars)
+}
+
+// check the error of a conversion, in a function returning a pointer and an error.
+func (c *protoConversion) check(what string) {
+	c.linef("if err != nil {")
+	c.linef("return nil, fmt.Errorf(%q, err)", "converting "+what+": %w")
+	c.linef("}")
+}
+
+// result declares a variable for the result of a conversion, when it may fail.
+func (c *protoConversion) result(expr string, fails bool, what string) string {
+	if !fails {
+		return expr
+	}
+
+	v := c.newVar("v")
+	c.linef("%s, err := %s", v, expr)
+	c.check(what)
+
+	return v
+}
+
+// toProto converts a model value src, which is a pointer when srcPtr is true.
+func (c *protoConversion) toProto(t *protoType, src string, srcPtr, dstOptional bool, set func(string) string, what string) {
+	b := c.builder
+
+	switch t.kind {
+	case protoRepeated, protoMap:
+		if t.elem.identical() {
+			c.linef("%s", set(c.convertTo(t.goProtoType(b), t.goProtoType(b), src)))
+
+			break
+		}
+
+		elemType := t.elem.goProtoType(b)
+		dst, elem := c.newVar("s"), c.newVar("e")
+		c.linef("if len(%s) > 0 {", src)
+		if t.kind == protoRepeated {
+			c.linef("%s := make([]%s, 0, len(%s))", dst, elemType, src)
+			c.linef("for _, %s := range %s {", elem, src)
+			c.toProto(t.elem, elem, t.elem.pointer, false, func(expr string) string {
+				return dst + " = append(" + dst + ", " + expr + ")"
+			}, what)
+		} else {
+			key := c.newVar("k")
+			c.linef("%s := make(map[string]%s, len(%s))", dst, elemType, src)
+			c.linef("for %s, %s := range %s {", key, elem, src)
+			c.toProto(t.elem, elem, t.elem.pointer, false, assign(dst+"["+key+"]"), what)
+		}
+		c.linef("}")
+		c.linef("%s", set(dst))
+		c.linef("}")
+
+	case protoMessageKind:
+		if !srcPtr {
+			src = "&" + src
+		}
+		c.linef("%s", set(c.result(t.message.Name+"ToProto("+src+")", true, what)))
+
+	case protoJSON:
+		b.file.UsesJSON = true
+		c.linef("%s", set(c.result("protoJSONToProto("+src+")", true, what)))
+
+	default:
+		value := src
+		if srcPtr {
+			c.linef("if %s != nil {", src)
+			value = "*" + src
+		}
+
+		expr, fails := c.valueToProto(t, value)
+		expr = c.result(expr, fails, what)
+		if dstOptional {
+			if fails {
+				expr = "&" + expr
+			} else {
+				v := c.newVar("v")
+				c.linef("%s := %s", v, expr)
+				expr = "&" + v
+			}
+		}
+		c.linef("%s", set(expr))
+
+		if srcPtr {
+			c.linef("}")
+		}
+	}
+}
+
+// valueToProto converts a model value, which is not a pointer.
+func (c *protoConversion) valueToProto(t *protoType, value string) (string, bool) {
+	b := c.builder
+
+	switch t.kind {
+	case protoEnumKind:
+		b.file.UsesEnums = true
+
+		return "protoEnumValue(" + t.enum.ToProtoValues() + ", " + value + ")", true
+	case protoText:
+		b.file.UsesText = true
+
+		return "protoText(" + value + ")", true
+	case protoTimestamp:
+		b.file.UsesTimestamp = true
+
+		return "protoTimestamp(" + value + ")", false
+	case protoDuration:
+		return "durationpb.New(time.Duration(" + value + "))", false
+	default:
+		if t.model == t.goProto {
+			return value, false
+		}
+
+		return t.goProto + "(" + value + ")", false
+	}
+}
+
+// fromProto converts a message value src, which is a pointer when srcOptional is true.
+func (c *protoConversion) fromProto(t *protoType, src string, srcOptional, dstPtr bool, set func(string) string, what string) {
+	b := c.builder
+	modelType := t.model
+	if dstPtr {
+		modelType = "*" + modelType
+	}
+
+	switch t.kind {
+	case protoRepeated, protoMap:
+		if t.elem.identical() {
+			c.linef("%s", set(c.convertTo(modelType, t.model, src)))
+
+			break
+		}
+
+		elemType := t.elem.model
+		if t.elem.pointer {
+			elemType = "*" + elemType
+		}
+		dst, elem := c.newVar("s"), c.newVar("e")
+		c.linef("if len(%s) > 0 {", src)
+		if t.kind == protoRepeated {
+			c.linef("%s := make([]%s, 0, len(%s))", dst, elemType, src)
+			c.linef("for _, %s := range %s {", elem, src)
+			c.fromProto(t.elem, elem, false, t.elem.pointer, func(expr string) string {
+				return dst + " = append(" + dst + ", " + expr + ")"
+			}, what)
+		} else {
+			key := c.newVar("k")
+			c.linef("%s := make(map[string]%s, len(%s))", dst, elemType, src)
+			c.linef("for %s, %s := range %s {", key, elem, src)
+			c.fromProto(t.elem, elem, false, t.elem.pointer, assign(dst+"["+key+"]"), what)
+		}
+		c.linef("}")
+		c.linef("%s", set(c.convertTo(modelType, t.model, dst)))
+		c.linef("}")
+
+	case protoJSON:
+		b.file.UsesJSON = true
+		v := c.newVar("v")
+		c.linef("var %s %s", v, modelType)
+		c.linef("if err := protoJSONFromProto(%s, &%s); err != nil {", src, v)
+		c.linef("return nil, fmt.Errorf(%q, err)", "converting "+what+": %w")
+		c.linef("}")
+		c.linef("%s", set(v))
+
+	case protoMessageKind:
+		v := c.result(t.message.Name+"FromProto("+src+")", true, what)
+		if dstPtr {
+			c.linef("%s", set(v))
+
+			break
+		}
+		c.linef("if %s != nil {", v)
+		c.linef("%s", set("*"+v))
+		c.linef("}")
+
+	default:
+		value := src
+		nilable := srcOptional || t.kind == protoTimestamp || t.kind == protoDuration
+		if nilable {
+			c.linef("if %s != nil {", src)
+		}
+		if srcOptional {
+			value = "*" + src
+		}
+
+		expr, fails := c.valueFromProto(t, value)
+		expr = c.result(expr, fails, what)
+		if dstPtr {
+			if fails {
+				expr = "&" + expr
+			} else {
+				v := c.newVar("v")
+				c.linef("%s := %s", v, expr)
+				expr = "&" + v
+			}
+		}
+		c.linef("%s", set(expr))
+
+		if nilable {
+			c.linef("}")
+		}
+	}
+}
+
+// valueFromProto converts a message value, which is not a pointer unless it's a well-known type.
+func (c *protoConversion) valueFromProto(t *protoType, value string) (string, bool) {
+	b := c.builder
+
+	switch t.kind {
+	case protoEnumKind:
+		b.file.UsesEnums = true
+
+		return "modelEnumValue[" + t.model + "](" + t.enum.FromProtoValues() + ", " + value + ")", true
+	case protoText:
+		b.file.UsesText = true
+
+		return "modelText[" + t.model + "](" + value + ")", true
+	case protoTimestamp:
+		return t.model + "(" + value + ".AsTime())", false
+	case protoDuration:
+		return t.model + "(" + value + ".AsDuration())", false
+	default:
+		if t.model == t.goProto {
+			return value, false
+		}
+
+		return t.model + "(" + value + ")", false
+	}
+}
+
+// convertTo converts a slice or a map to a named type of the models.
+func (c *protoConversion) convertTo(modelType, model, value string) string {
+	if strings.HasPrefix(model, "[]") || strings.HasPrefix(model, "map[") {
+		if strings.HasPrefix(modelType, "*") {
+			return "&" + value
+		}
+
+		return value
+	}
+
+	if strings.HasPrefix(modelType, "*") {
+		v := c.newVar("v")
+		c.linef("%s := %s(%s)", v, model, value)
+
+		return "&" + v
+	}
+
+	return model + "(" + value + ")"
+}
+
+// identical tells if a value has the same go type in the models and in the code generated by protoc.
+func (t *protoType) identical() bool {
+	return t.kind == protoScalar && !t.pointer && t.model == t.goProto
+}
+
+// goProtoType is the go type of a value in the code generated by protoc.
+func (t *protoType) goProtoType(b *protoBuilder) string {
+	switch t.kind {
+	case protoEnumKind:
+		return protoAlias + "." + t.enum.GoName
+	case protoMessageKind:
+		return "*" + protoAlias + "." + t.message.GoName
+	case protoTimestamp:
+		return "*timestamppb.Timestamp"
+	case protoDuration:
+		return "*durationpb.Duration"
+	case protoJSON:
+		return "*structpb.Value"
+	case protoRepeated:
+		return "[]" + t.elem.goProtoType(b)
+	case protoMap:
+		return "map[string]" + t.elem.goProtoType(b)
+	default:
+		return t.goProto
+	}
+}
+
+// ToProtoValues is the name of the variable mapping the values of the enum to the constants generated by protoc.
+func (e *protoEnum) ToProtoValues() string {
+	return lowerFirst(e.Name) + "ToProto"
+}
+
+// FromProtoValues is the name of the variable mapping the constants generated by protoc to the values of the enum.
+func (e *protoEnum) FromProtoValues() string {
+	return lowerFirst(e.Name) + "FromProto"
+}
+
+func lowerFirst(s string) string {
+	if s == "" {
+		return s
+	}
+
+	return strings.ToLower(s[:1]) + s[1:]
+}
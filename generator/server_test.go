@@ -194,6 +194,7 @@ func TestServer_OperationGroups(t *testing.T) {
 		_ = os.RemoveAll(filepath.Join(".", "restapi"))
 		_ = os.RemoveAll(filepath.Join(".", "search"))
 		_ = os.RemoveAll(filepath.Join(".", "tasks"))
+		_ = os.RemoveAll(filepath.Join(".", cacheDir))
 	}()
 
 	gen, err := testAppGenerator(t, "../fixtures/codegen/simplesearch.yml", "search")
@@ -5,12 +5,12 @@ package generator
 
 import (
 	"bytes"
-	"encoding/json"
+	"context"
 	"errors"
 	"fmt"
-	"io"
 	"io/fs"
-	"log"
+	"log/slog"
+	"maps"
 	"os"
 	"path"
 	"path/filepath"
@@ -19,7 +19,9 @@ import (
 	"slices"
 	"sort"
 	"strings"
+	"sync"
 	"text/template"
+	"time"
 
 	"github.com/go-openapi/analysis"
 	"github.com/go-openapi/loads"
@@ -274,6 +276,10 @@ func MarkdownSectionOpts(gen *GenOpts, output string) {
 	gen.Sections.PostModels = nil
 	gen.Sections.OperationGroups = nil
 	gen.Sections.Operations = nil
+	gen.Sections.SecuritySchemes = nil
+	gen.Sections.Tags = nil
+	gen.Sections.Responses = nil
+	gen.Sections.Parameters = nil
 	gen.LanguageOpts = MarkdownOpts()
 	gen.Sections.Application = []TemplateOpts{
 		{
@@ -293,6 +299,7 @@ type TemplateOpts struct {
 	FileName   string `mapstructure:"file_name"`
 	SkipExists bool   `mapstructure:"skip_exists"`
 	SkipFormat bool   `mapstructure:"skip_format"` // not a feature, but for debugging. generated code before formatting might not work because of unused imports.
+	When       string `mapstructure:"when"`        // condition to render this template for an item, e.g. `.Context.Enum`
 }
 
 // SectionOpts allows for specifying options to customize the templates used for generation.
@@ -302,6 +309,10 @@ type SectionOpts struct {
 	OperationGroups []TemplateOpts `mapstructure:"operation_groups"`
 	Models          []TemplateOpts `mapstructure:"models"`
 	PostModels      []TemplateOpts `mapstructure:"post_models"`
+	SecuritySchemes []TemplateOpts `mapstructure:"security_schemes"`
+	Tags            []TemplateOpts `mapstructure:"tags"`
+	Responses       []TemplateOpts `mapstructure:"responses"`
+	Parameters      []TemplateOpts `mapstructure:"parameters"`
 }
 
 // GenOptsCommon the options for the generator.
@@ -366,9 +377,42 @@ type GenOptsCommon struct {
 	ReturnErrors           bool
 	WithCustomFormatter    bool
 	WithExtraInitialisms   []string
-
-	templates *templatesrepo.Repository
-	funcMap   template.FuncMap
+	Force                  bool               // regenerate all files, even when the generation cache tells they are up to date
+	Concurrency            int                // maximum number of files rendered in parallel. Defaults to GOMAXPROCS
+	FS                     WriteFS            `json:"-"` // file system where generated files are written. Defaults to the local file system
+	Logger                 *slog.Logger       `json:"-"` // logger for the progress of the generation. Defaults to the standard logger
+	Events                 EventHandler       `json:"-"` // receives structured progress events
+	SplitModelsByFile      bool               // generate definitions imported from other documents in a sub-package named after their file
+	ModelPackageRules      []ModelPackageRule // route definitions into sub-packages of the models package, by the prefix of their name
+	OperationGrouping      string             // strategy to group operations into packages: tag (default), path, extension or rules
+	OperationPackageRules  map[string]string  // route operations into packages by operationId, with the "rules" grouping
+	Explain                bool               // report how each file is produced, with the events for written files
+	Hooks                  HooksOpts          // commands run before and after the generation, see [RunWithHooks]
+	MergeEdits             bool               // merge the edits of existing files generated with skip_exists with their new content
+	Verify                 bool               // type-check the generated packages, see [RunWithHooks]
+	PackageDocs            bool               // generate a doc.go in generated packages without package documentation
+	GoGenerate             string             // generate generate.go at the root of the target, with a go:generate directive: swagger or go-run
+	ReplayCommand          ReplayFunc         `json:"-"` // command line replaying the generation from some directory, for doc.go and generate.go
+	TemplatePack           string             // template pack: a contributed pack, a directory or a go module "path[@version][//subdir]"
+	TemplatePackOptions    map[string]string  // values for the options declared by the template pack
+	PackOptions            map[string]any     // resolved options of the template pack, available to templates
+	DumpSelector           string             // with DumpData, the part of the data to dump: the name of a model or of an operation, or a JSONPath
+	DumpFormat             string             // with DumpData, the format of the dumped data: json (default) or yaml
+	FuncPlugins            []FuncPluginOpts   // external programs providing template functions, see [FuncPluginOpts]
+	ProtoPackage           string             // package of the generated .proto file. Defaults to the name of the API
+	ProtoGoPackage         string             // go_package of the generated .proto file, imported by the conversion functions
+	ProtoConversions       bool               // generate go functions converting the models to and from the types generated by protoc
+
+	templatePack *TemplatePack
+
+	templates   *templatesrepo.Repository
+	funcMap     template.FuncMap
+	cache       *generationCache
+	specContent []byte          // content of the spec at Spec, when it is altered in memory (e.g. by a multi-spec generation)
+	funcPlugins *funcPluginSet  // running function plugins, shared by the copies of the options
+	eventsMx    *sync.Mutex     // serializes the delivery of events, shared by the copies of the options
+	packages    *modelPackages  // sub-packages of the models package, when models are split
+	ctx         context.Context //nolint:containedctx // set for the duration of a generation by the Generator
 }
 
 // CheckOpts carries out some global consistency checks on options.
@@ -387,16 +431,30 @@ func (g *GenOpts) CheckOpts() error {
 		return fmt.Errorf("you shouldn't specify an absolute path in --server-package: %s", g.ServerPackage)
 	}
 
+	if err := g.checkExistingModels(); err != nil {
+		return err
+	}
+
+	if err := g.checkOperationGrouping(); err != nil {
+		return err
+	}
+
 	if strings.HasPrefix(g.Spec, "http://") || strings.HasPrefix(g.Spec, "https://") {
 		return nil
 	}
 
-	pth, err := findSwaggerSpec(g.Spec)
-	if err != nil {
-		return err
+	pth := g.Spec
+	if g.specContent == nil {
+		// a spec altered in memory is not searched on disk
+		found, err := findSwaggerSpec(g.Spec)
+		if err != nil {
+			return err
+		}
+		pth = found
 	}
 
 	// ensure spec path is absolute
+	var err error
 	g.Spec, err = filepath.Abs(pth)
 	if err != nil {
 		return fmt.Errorf("could not locate spec: %s", g.Spec)
@@ -416,6 +474,39 @@ func (g *GenOpts) CheckOpts() error {
 	return nil
 }
 
+// checkExistingModels verifies that the existing models package may be imported by the generated code.
+//
+// Packages located in the module of the target or in a module of the go workspace must exist.
+// Packages provided by other modules are left to the go tool.
+func (g *GenOpts) checkExistingModels() error {
+	if g.ExistingModels == "" || g.LanguageOpts == nil {
+		return nil
+	}
+
+	importPath := g.LanguageOpts.ManglePackagePath(g.ExistingModels, "")
+	dir, err := g.LanguageOpts.ImportDir(g.Target, importPath)
+	if err != nil {
+		return fmt.Errorf("could not resolve existing models %q: %w", importPath, err)
+	}
+
+	if dir == "" {
+		return nil
+	}
+
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return fmt.Errorf("existing models %q should be located in %q: %w", importPath, dir, err)
+	}
+
+	for _, entry := range entries {
+		if !entry.IsDir() && strings.HasSuffix(entry.Name(), ".go") {
+			return nil
+		}
+	}
+
+	return fmt.Errorf("existing models %q should be located in %q, but this directory contains no go source", importPath, dir)
+}
+
 // TargetPath returns the target generation path relative to the server package.
 // This method is used by templates, e.g. with {{ .TargetPath }}
 //
@@ -490,6 +581,11 @@ func (g *GenOpts) EnsureDefaults() error {
 		g.LanguageOpts = language.GolangOpts(g.WithExtraInitialisms...)
 	}
 
+	// the layout of a template pack fills the sections which are not set
+	if err := g.loadTemplatePack(); err != nil {
+		return err
+	}
+
 	DefaultSectionOpts(g)
 
 	assets := defaultAssets()
@@ -497,6 +593,12 @@ func (g *GenOpts) EnsureDefaults() error {
 
 	g.funcMap = DefaultFuncMap(g.LanguageOpts)
 	g.templates = templatesrepo.NewRepository(g.funcMap)
+	g.templates.SetLogger(g.logf)
+	if g.funcPlugins == nil {
+		// function plugins may be tracked by a generation already
+		g.funcPlugins = &funcPluginSet{}
+	}
+	g.eventsMx = &sync.Mutex{}
 	if err := g.templates.LoadDefaults(assets); err != nil {
 		fatal(err)
 	}
@@ -536,22 +638,48 @@ func (g *GenOpts) EnsureDefaults() error {
 	return nil
 }
 
-func (g *GenOpts) location(t *TemplateOpts, data any) (string, string, error) {
+// templateContext is the data available to the target, file name and condition of a template in the layout.
+type templateContext struct {
+	Name, CliAppName,
+	Package, APIPackage, ServerPackage, ClientPackage, CliPackage, ModelPackage, MainPackage,
+	Target string
+	Tags       []string
+	UseTags    bool
+	Extensions map[string]any // vendor extensions of the item, if any
+	Opts       *GenOpts       // generation options
+	Context    any
+}
+
+func (g *GenOpts) templateContext(data any) (templateContext, error) {
 	v := reflect.Indirect(reflect.ValueOf(data))
 	fld := v.FieldByName("Name")
 	var name string
 	if fld.IsValid() {
-		log.Println("name field", fld.String())
+		debugLogf("name field %s", fld.String())
 		name = fld.String()
 	}
 
+	// security schemes and named parameters are known by their key in the spec
+	switch item := data.(type) {
+	case *GenSecurityScheme:
+		name = item.ID
+	case *GenParameter:
+		name = item.ID
+	}
+
 	fldpack := v.FieldByName("Package")
 	pkg := g.APIPackage
 	if fldpack.IsValid() {
-		log.Println("package field", fldpack.String())
+		debugLogf("package field %s", fldpack.String())
 		pkg = fldpack.String()
 	}
 
+	modelPkg := g.ModelPackage
+	if fldModelPkg := v.FieldByName("ModelPackage"); fldModelPkg.IsValid() && fldModelPkg.String() != "" {
+		// models split into sub-packages
+		modelPkg = fldModelPkg.String()
+	}
+
 	var tags []string
 	tagsF := v.FieldByName("Tags")
 	if tagsF.IsValid() {
@@ -566,28 +694,16 @@ func (g *GenOpts) location(t *TemplateOpts, data any) (string, string, error) {
 		var ok bool
 		useTags, ok = useTagsF.Interface().(bool)
 		if !ok {
-			return "", "", fmt.Errorf("expected UseTags to be bool, but got %T", useTagsF.Interface())
+			return templateContext{}, fmt.Errorf("expected UseTags to be bool, but got %T", useTagsF.Interface())
 		}
 	}
 
-	pthTpl, err := template.New(t.Name + "-target").Funcs(g.funcMap).Parse(t.Target)
-	if err != nil {
-		return "", "", err
-	}
-
-	fNameTpl, err := template.New(t.Name + "-filename").Funcs(g.funcMap).Parse(t.FileName)
-	if err != nil {
-		return "", "", err
+	var extensions map[string]any
+	if extensionsF := v.FieldByName("Extensions"); extensionsF.IsValid() {
+		extensions, _ = extensionsF.Interface().(map[string]any)
 	}
 
-	d := struct {
-		Name, CliAppName,
-		Package, APIPackage, ServerPackage, ClientPackage, CliPackage, ModelPackage, MainPackage,
-		Target string
-		Tags    []string
-		UseTags bool
-		Context any
-	}{
+	return templateContext{
 		Name:          name,
 		CliAppName:    g.CliAppName,
 		Package:       pkg,
@@ -595,12 +711,31 @@ func (g *GenOpts) location(t *TemplateOpts, data any) (string, string, error) {
 		ServerPackage: g.ServerPackage,
 		ClientPackage: g.ClientPackage,
 		CliPackage:    g.CliPackage,
-		ModelPackage:  g.ModelPackage,
+		ModelPackage:  modelPkg,
 		MainPackage:   g.MainPackage,
 		Target:        g.Target,
 		Tags:          tags,
 		UseTags:       useTags,
+		Extensions:    extensions,
+		Opts:          g,
 		Context:       data,
+	}, nil
+}
+
+func (g *GenOpts) location(t *TemplateOpts, data any) (string, string, error) {
+	d, err := g.templateContext(data)
+	if err != nil {
+		return "", "", err
+	}
+
+	pthTpl, err := template.New(t.Name + "-target").Funcs(g.funcMap).Parse(t.Target)
+	if err != nil {
+		return "", "", err
+	}
+
+	fNameTpl, err := template.New(t.Name + "-filename").Funcs(g.funcMap).Parse(t.FileName)
+	if err != nil {
+		return "", "", err
 	}
 
 	var pthBuf bytes.Buffer
@@ -615,59 +750,98 @@ func (g *GenOpts) location(t *TemplateOpts, data any) (string, string, error) {
 	return pthBuf.String(), g.fileName(fNameBuf.String()), nil
 }
 
-func (g *GenOpts) render(t *TemplateOpts, data any) ([]byte, error) {
-	var templ *template.Template
+// matchesCondition evaluates the "when" condition of a template against the data of an item.
+//
+// The condition is a template pipeline, e.g. `.Context.Enum` or `hasKey .Extensions "x-streaming"`,
+// evaluated with the same data as the target and the file name. A template without a condition always matches.
+func (g *GenOpts) matchesCondition(t *TemplateOpts, data any) (bool, error) {
+	if strings.TrimSpace(t.When) == "" {
+		return true, nil
+	}
 
-	if strings.HasPrefix(strings.ToLower(t.Source), "asset:") {
-		tt, err := g.templates.Get(strings.TrimPrefix(t.Source, "asset:"))
-		if err != nil {
-			return nil, err
-		}
-		templ = tt
+	d, err := g.templateContext(data)
+	if err != nil {
+		return false, err
 	}
 
-	if templ == nil {
-		// try to load from repository (and enable dependencies)
-		name := g.LanguageOpts.Mangler.ToJSONName(strings.TrimSuffix(t.Source, ".gotmpl"))
-		tt, err := g.templates.Get(name)
-		if err == nil {
-			templ = tt
-		}
+	condTpl, err := template.New(t.Name + "-when").Funcs(g.funcMap).Parse("{{ if " + t.When + " }}true{{ end }}")
+	if err != nil {
+		return false, fmt.Errorf("invalid condition %q for template %s: %w", t.When, t.Name, err)
 	}
 
-	if templ == nil {
-		// try to load template from disk, in TemplateDir if specified
-		// (dependencies resolution is limited to preloaded assets)
-		var templateFile string
-		if g.TemplateDir != "" {
-			templateFile = filepath.Join(g.TemplateDir, t.Source)
-		} else {
-			templateFile = t.Source
-		}
-		content, err := os.ReadFile(templateFile)
-		if err != nil {
-			return nil, fmt.Errorf("error while opening %s template file: %w", templateFile, err)
-		}
-		tt, err := template.New(t.Source).Funcs(g.funcMap).Parse(string(content))
-		if err != nil {
-			return nil, fmt.Errorf("template parsing failed on template %s: %w", t.Name, err)
-		}
-		templ = tt
+	var condBuf bytes.Buffer
+	if err := condTpl.Execute(&condBuf, d); err != nil {
+		return false, fmt.Errorf("could not evaluate condition %q for template %s: %w", t.When, t.Name, err)
 	}
 
-	if templ == nil {
-		return nil, fmt.Errorf("template %q not found", t.Source)
+	return condBuf.String() == "true", nil
+}
+
+func (g *GenOpts) render(t *TemplateOpts, data any) ([]byte, error) {
+	templ, _, err := g.resolveTemplate(t)
+	if err != nil {
+		return nil, err
 	}
 
+	return g.execute(t, templ, data)
+}
+
+// execute a template of the layout, once resolved.
+func (g *GenOpts) execute(t *TemplateOpts, templ *template.Template, data any) ([]byte, error) {
 	var tBuf bytes.Buffer
 	if err := templ.Execute(&tBuf, data); err != nil {
 		return nil, fmt.Errorf("template execution failed for template %s: %w", t.Name, err)
 	}
-	log.Printf("executed template %s", t.Source)
+	debugLogf("executed template %s", t.Source)
 
 	return tBuf.Bytes(), nil
 }
 
+// resolveTemplate finds the template to render for a template of the layout.
+//
+// The template is looked up in the repository, then on disk. It returns where the template was found:
+// templates from the repository are known by their name in the repository, templates loaded from disk by their file.
+func (g *GenOpts) resolveTemplate(t *TemplateOpts) (*template.Template, TemplateSource, error) {
+	if strings.HasPrefix(strings.ToLower(t.Source), "asset:") {
+		name := strings.TrimPrefix(t.Source, "asset:")
+		tt, err := g.templates.Get(name)
+		if err != nil {
+			return nil, TemplateSource{}, err
+		}
+
+		return tt, TemplateSource{Name: name}, nil
+	}
+
+	// try to load from repository (and enable dependencies)
+	name := g.LanguageOpts.Mangler.ToJSONName(strings.TrimSuffix(t.Source, ".gotmpl"))
+	if tt, err := g.templates.Get(name); err == nil {
+		return tt, TemplateSource{Name: name}, nil
+	}
+
+	// try to load template from disk, in TemplateDir if specified
+	// (dependencies resolution is limited to preloaded assets)
+	templateFile := g.templateFile(t)
+	content, err := os.ReadFile(templateFile)
+	if err != nil {
+		return nil, TemplateSource{}, fmt.Errorf("error while opening %s template file: %w", templateFile, err)
+	}
+	tt, err := template.New(t.Source).Funcs(g.funcMap).Parse(string(content))
+	if err != nil {
+		return nil, TemplateSource{}, fmt.Errorf("template parsing failed on template %s: %w", t.Name, err)
+	}
+
+	return tt, TemplateSource{Name: t.Source, File: templateFile, Origin: OriginDisk}, nil
+}
+
+// templateFile is the file of a template of the layout, when it is not found in the repository of templates.
+func (g *GenOpts) templateFile(t *TemplateOpts) string {
+	if g.TemplateDir != "" {
+		return filepath.Join(g.TemplateDir, t.Source)
+	}
+
+	return t.Source
+}
+
 // Render template and write generated source code
 // generated code is reformatted ("linted"), which gives an
 // additional level of checking. If this step fails, the generated
@@ -678,25 +852,82 @@ func (g *GenOpts) write(t *TemplateOpts, data any) error {
 		return fmt.Errorf("failed to resolve template location for template %s: %w", t.Name, err)
 	}
 
-	if t.SkipExists && fileExists(dir, fname) {
-		debugLogf("skipping generation of %s because it already exists and skip_exist directive is set for %s",
-			filepath.Join(dir, fname), t.Name)
+	if err := g.context().Err(); err != nil {
+		return err
+	}
+
+	matches, err := g.matchesCondition(t, data)
+	if err != nil {
+		return err
+	}
+	if !matches {
+		g.debugf("skipping generation of %s because the condition %q of %s is not met", filepath.Join(dir, fname), t.When, t.Name)
+		g.emit(Event{Kind: EventFileSkipped, Path: filepath.Join(dir, fname), Template: t.Name, Reason: SkipReasonCondition})
 		return nil
 	}
 
-	log.Printf("creating generated file %q in %q as %s", fname, dir, t.Name)
-	content, err := g.render(t, data)
+	// with MergeEdits, the edits of an existing file are merged with its new content
+	var (
+		pristine, edited []byte
+		merging          bool
+	)
+	if t.SkipExists && g.fileExists(dir, fname) {
+		if g.MergeEdits {
+			pristine, merging = g.readPristine(filepath.Join(dir, fname))
+			if !merging {
+				g.warnf("cannot merge the edits of %s: its previously generated content is unknown", filepath.Join(dir, fname))
+			}
+		}
+
+		if merging {
+			edited, err = g.fs().ReadFile(filepath.Join(dir, fname))
+			if err != nil {
+				return err
+			}
+		} else {
+			debugLogf("skipping generation of %s because it already exists and skip_exist directive is set for %s",
+				filepath.Join(dir, fname), t.Name)
+			skipped := Event{Kind: EventFileSkipped, Path: filepath.Join(dir, fname), Template: t.Name, Element: specElement(data), Reason: SkipReasonExists}
+			if g.Explain {
+				_, source, err := g.resolveTemplate(t)
+				if err != nil {
+					return fmt.Errorf("failed to resolve template %s: %w", t.Name, err)
+				}
+				if skipped.Explain, err = g.explain(t, source, data); err != nil {
+					return fmt.Errorf("could not explain the generation of %q: %w", filepath.Join(dir, fname), err)
+				}
+			}
+			g.emit(skipped)
+
+			return nil
+		}
+	}
+
+	g.logf("creating generated file %q in %q as %s", fname, dir, t.Name)
+	start := time.Now()
+	templ, source, err := g.resolveTemplate(t)
+	if err != nil {
+		return fmt.Errorf("failed rendering template data for %s: %w", t.Name, err)
+	}
+	content, err := g.execute(t, templ, data)
 	if err != nil {
 		return fmt.Errorf("failed rendering template data for %s: %w", t.Name, err)
 	}
 
+	var explanation *Explanation
+	if g.Explain {
+		if explanation, err = g.explain(t, source, data); err != nil {
+			return fmt.Errorf("could not explain the generation of %q: %w", filepath.Join(dir, fname), err)
+		}
+	}
+
 	if dir != "" {
-		_, exists := os.Stat(dir)
-		if os.IsNotExist(exists) {
+		_, exists := g.fs().Stat(dir)
+		if errors.Is(exists, fs.ErrNotExist) {
 			debugLogf("creating directory %q for \"%s\"", dir, t.Name)
 			// Directory settings consistent with file privileges.
 			// Environment's umask may alter this setup
-			if e := os.MkdirAll(dir, readAllDir); e != nil {
+			if e := g.fs().MkdirAll(dir, readAllDir); e != nil {
 				return e
 			}
 		}
@@ -715,20 +946,45 @@ func (g *GenOpts) write(t *TemplateOpts, data any) error {
 			language.WithFormatLocalPrefixes(baseImport),
 		)
 		if err != nil {
-			log.Printf("source formatting failed on template-generated source (%q for %s). Check that your template produces valid code", filepath.Join(dir, fname), t.Name)
-			writeerr = os.WriteFile(filepath.Join(dir, fname), content, readAllFile) // #nosec
+			g.warnf("source formatting failed on template-generated source (%q for %s). Check that your template produces valid code", filepath.Join(dir, fname), t.Name)
+			writeerr = g.fs().WriteFile(filepath.Join(dir, fname), content, readAllFile) // #nosec
 			if writeerr != nil {
 				return fmt.Errorf("failed to write (unformatted) file %q in %q: %w", fname, dir, writeerr)
 			}
-			log.Printf("unformatted generated source %q has been dumped for template debugging purposes. DO NOT build on this source!", fname)
+			g.warnf("unformatted generated source %q has been dumped for template debugging purposes. DO NOT build on this source!", fname)
 			return fmt.Errorf("source formatting on generated source %q failed: %w", t.Name, err)
 		}
 	}
 
-	writeerr = os.WriteFile(filepath.Join(dir, fname), formatted, readAllFile) // #nosec
+	if t.SkipExists {
+		// the generated content is kept, to merge the edits of this file at the next generation
+		if err := g.writePristine(filepath.Join(dir, fname), formatted); err != nil {
+			return fmt.Errorf("failed to keep the generated content of %q: %w", filepath.Join(dir, fname), err)
+		}
+	}
+
+	var conflicts int
+	if merging {
+		formatted, conflicts = mergeEdits(pristine, edited, formatted)
+		if conflicts > 0 {
+			g.warnf("%d conflicts merging the edits of %s with its new content: look for conflict markers", conflicts, filepath.Join(dir, fname))
+		}
+	}
+
+	if existing, readerr := g.fs().ReadFile(filepath.Join(dir, fname)); readerr == nil && !g.Force && bytes.Equal(existing, formatted) {
+		// files are not touched when their content is unchanged, so editors and build caches are not disturbed
+		g.debugf("skipping generation of %s because its content is unchanged", filepath.Join(dir, fname))
+		g.emit(Event{Kind: EventFileSkipped, Path: filepath.Join(dir, fname), Template: t.Name, Element: specElement(data), Reason: SkipReasonUnchanged, Explain: explanation})
+
+		return nil
+	}
+
+	writeerr = g.fs().WriteFile(filepath.Join(dir, fname), formatted, readAllFile) // #nosec
 	if writeerr != nil {
 		return fmt.Errorf("failed to write file %q in %q: %w", fname, dir, writeerr)
 	}
+	g.emitSince(start, Event{Kind: EventFileWritten, Path: filepath.Join(dir, fname), Template: t.Name, Element: specElement(data), Merged: merging, Conflicts: conflicts, Explain: explanation})
+
 	return err
 }
 
@@ -753,7 +1009,7 @@ func (g *GenOpts) shouldRenderOperations() bool {
 }
 
 func (g *GenOpts) renderApplication(app *GenApp) error {
-	log.Printf("rendering %d templates for application %s", len(g.Sections.Application), app.Name)
+	g.logf("rendering %d templates for application %s", len(g.Sections.Application), app.Name)
 	for _, tp := range g.Sections.Application {
 		templ := tp
 		if !g.shouldRenderApp(&templ, app) {
@@ -765,7 +1021,7 @@ func (g *GenOpts) renderApplication(app *GenApp) error {
 	}
 
 	if len(g.Sections.PostModels) > 0 {
-		log.Printf("post-rendering from %d models", len(app.Models))
+		g.logf("post-rendering from %d models", len(app.Models))
 		for _, templateToPin := range g.Sections.PostModels {
 			templateConfig := templateToPin
 			for _, modelToPin := range app.Models {
@@ -777,55 +1033,87 @@ func (g *GenOpts) renderApplication(app *GenApp) error {
 		}
 	}
 
-	return nil
+	return g.renderSections(app)
 }
 
 func (g *GenOpts) renderOperationGroup(gg *GenOperationGroup) error {
-	log.Printf("rendering %d templates for operation group %s", len(g.Sections.OperationGroups), g.Name)
-	for _, tp := range g.Sections.OperationGroups {
-		templ := tp
-		if !g.shouldRenderOperations() {
-			continue
-		}
+	g.debugf("rendering %d templates for operation group %s", len(g.Sections.OperationGroups), gg.Name)
+	start := time.Now()
+	err := g.cachedRender("operation_group:"+gg.Name, g.Sections.OperationGroups, gg, func() error {
+		for _, tp := range g.Sections.OperationGroups {
+			templ := tp
+			if !g.shouldRenderOperations() {
+				continue
+			}
 
-		if err := g.write(&templ, gg); err != nil {
-			return err
+			if err := g.write(&templ, gg); err != nil {
+				return err
+			}
 		}
+		return nil
+	})
+	if err != nil {
+		return err
 	}
+	g.emitSince(start, Event{Kind: EventOperationGroupRendered, Name: gg.Name})
+
 	return nil
 }
 
 func (g *GenOpts) renderOperation(gg *GenOperation) error {
-	log.Printf("rendering %d templates for operation %s", len(g.Sections.Operations), g.Name)
-	for _, tp := range g.Sections.Operations {
-		templ := tp
-		if !g.shouldRenderOperations() {
-			continue
-		}
+	g.debugf("rendering %d templates for operation %s", len(g.Sections.Operations), gg.Name)
+	start := time.Now()
+	err := g.cachedRender("operation:"+gg.Package+"/"+gg.Name, g.Sections.Operations, gg, func() error {
+		for _, tp := range g.Sections.Operations {
+			templ := tp
+			if !g.shouldRenderOperations() {
+				continue
+			}
 
-		if err := g.write(&templ, gg); err != nil {
-			return err
+			if err := g.write(&templ, gg); err != nil {
+				return err
+			}
 		}
+		return nil
+	})
+	if err != nil {
+		return err
 	}
+	g.emitSince(start, Event{Kind: EventOperationRendered, Name: gg.Name})
+
 	return nil
 }
 
 func (g *GenOpts) renderDefinition(gg *GenDefinition) error {
-	log.Printf("rendering %d templates for model %s", len(g.Sections.Models), gg.Name)
-	for _, tp := range g.Sections.Models {
-		templ := tp
-		if !g.IncludeModel {
-			continue
-		}
+	g.debugf("rendering %d templates for model %s", len(g.Sections.Models), gg.Name)
+	start := time.Now()
+	err := g.cachedRender("model:"+gg.Name, g.Sections.Models, gg, func() error {
+		for _, tp := range g.Sections.Models {
+			templ := tp
+			if !g.IncludeModel {
+				continue
+			}
 
-		if err := g.write(&templ, gg); err != nil {
-			return err
+			if err := g.write(&templ, gg); err != nil {
+				return err
+			}
 		}
+		return nil
+	})
+	if err != nil {
+		return err
 	}
+	g.emitSince(start, Event{Kind: EventModelRendered, Name: gg.Name})
+
 	return nil
 }
 
 func (g *GenOptsCommon) setTemplates() error {
+	// functions of plugins are known to templates when they are parsed
+	if err := g.startFuncPlugins(); err != nil {
+		return err
+	}
+
 	if g.Template != "" {
 		// set contrib templates
 		if err := g.templates.LoadContrib(g.Template, embeddedAssets{}); err != nil {
@@ -833,6 +1121,13 @@ func (g *GenOptsCommon) setTemplates() error {
 		}
 	}
 
+	if g.templatePack != nil {
+		// set templates from a template pack
+		if err := g.templatePack.loadTemplates(g.templates); err != nil {
+			return err
+		}
+	}
+
 	g.templates.SetAllowOverride(g.AllowTemplateOverride)
 
 	if g.TemplateDir != "" {
@@ -862,6 +1157,7 @@ func (g *GenOpts) defaultImports() map[string]string {
 		modelsAlias = path.Base(defaultModelsTarget)
 	}
 	defaultImports[modelsAlias] = importPath
+	maps.Copy(defaultImports, g.packages.imports("", false))
 
 	// resolve model representing an authenticated principal
 	alias, _, target := g.resolvePrincipal()
@@ -947,6 +1243,12 @@ func fileExists(target, name string) bool {
 	return !os.IsNotExist(err)
 }
 
+// fileExists tells if a generated file already exists in the target file system.
+func (g *GenOpts) fileExists(target, name string) bool {
+	_, err := g.fs().Stat(filepath.Join(target, name))
+	return !errors.Is(err, fs.ErrNotExist)
+}
+
 func gatherModels(specDoc *loads.Document, modelNames []string) (map[string]spec.Schema, error) {
 	modelNames = pruneEmpty(modelNames)
 	models, mnc := make(map[string]spec.Schema), len(modelNames)
@@ -1109,7 +1411,7 @@ func securityRequirements(orig []map[string][]string) (result []analysis.Securit
 // gatherExtraSchemas produces a sorted list of extra schemas.
 //
 // ExtraSchemas are inlined types rendered in the same model file.
-func gatherExtraSchemas(extraMap map[string]GenSchema) (extras GenSchemaList) {
+func gatherExtraSchemas(extraMap map[string]GenSchema, warnf func(string, ...any)) (extras GenSchemaList) {
 	extraKeys := make([]string, 0, len(extraMap))
 	for k := range extraMap {
 		extraKeys = append(extraKeys, k)
@@ -1118,7 +1420,7 @@ func gatherExtraSchemas(extraMap map[string]GenSchema) (extras GenSchemaList) {
 	for _, k := range extraKeys {
 		// figure out if top level validations are needed
 		p := extraMap[k]
-		p.HasValidations = shallowValidationLookup(p)
+		p.HasValidations = shallowValidationLookup(p, warnf)
 		extras = append(extras, p)
 	}
 	return extras
@@ -1143,16 +1445,6 @@ func gatherURISchemes(swsp *spec.Swagger, operation spec.Operation) ([]string, [
 	return schemes, extraSchemes
 }
 
-func dumpData(w io.Writer, data any) error {
-	bb, err := json.MarshalIndent(data, "", "  ")
-	if err != nil {
-		return err
-	}
-	_, _ = fmt.Fprintln(w, string(bb))
-
-	return nil
-}
-
 func importAlias(pkg string) string {
 	_, k := path.Split(pkg)
 	return k
@@ -33,7 +33,14 @@ func TestMain(m *testing.M) {
 	// initializations to run tests in this package
 	log.SetFlags(log.LstdFlags | log.Lshortfile)
 	initSchemaValidationTest()
-	os.Exit(m.Run())
+
+	// tests generating in the package directory share the state of the generator:
+	// they start from a clean state and leave none behind
+	_ = os.RemoveAll(cacheDir)
+	code := m.Run()
+	_ = os.RemoveAll(cacheDir)
+
+	os.Exit(code)
 }
 
 func opts() *GenOpts {
@@ -130,6 +137,46 @@ func TestShared_CheckOpts(t *testing.T) {
 	require.Error(t, err)
 }
 
+func TestShared_CheckExistingModels(t *testing.T) {
+	t.Setenv("GOWORK", "")
+
+	root := t.TempDir()
+	for name, content := range map[string]string{
+		"go.work":             "go 1.25\n\nuse (\n\t./api\n\t./models\n)\n",
+		"api/go.mod":          "module example.com/api\n",
+		"models/go.mod":       "module example.com/models\n",
+		"models/dto/model.go": "package dto\n",
+	} {
+		pth := filepath.Join(root, filepath.FromSlash(name))
+		require.NoError(t, os.MkdirAll(filepath.Dir(pth), readAllDir))
+		require.NoError(t, os.WriteFile(pth, []byte(content), readableFile))
+	}
+
+	opts := testGenOpts()
+	opts.Spec = "../fixtures/codegen/simplesearch.yml"
+	opts.Target = filepath.Join(root, "api")
+
+	t.Run("should accept existing models in another module of the workspace", func(t *testing.T) {
+		opts.ExistingModels = "example.com/models/dto"
+		require.NoError(t, opts.CheckOpts())
+	})
+
+	t.Run("should accept existing models from a dependency", func(t *testing.T) {
+		opts.ExistingModels = "github.com/example/dto"
+		require.NoError(t, opts.CheckOpts())
+	})
+
+	t.Run("should reject missing existing models", func(t *testing.T) {
+		opts.ExistingModels = "example.com/models/missing"
+		err := opts.CheckOpts()
+		require.Error(t, err)
+		assert.StringContainsT(t, err.Error(), filepath.Join(root, "models", "missing"))
+
+		opts.ExistingModels = "example.com/api/bespoke"
+		require.Error(t, opts.CheckOpts())
+	})
+}
+
 func TestShared_EnsureDefaults(t *testing.T) {
 	opts := &GenOpts{}
 	require.NoError(t, opts.EnsureDefaults())
@@ -378,6 +425,71 @@ func TestShared_ExecTemplate(t *testing.T) {
 	})
 }
 
+func TestShared_ConditionalTemplate(t *testing.T) {
+	defer discardOutput()()
+
+	streaming := &GenOperation{Name: "watch", Tags: []string{"events"}, Extensions: map[string]any{"x-streaming": true}}
+	plain := &GenOperation{Name: "get", Tags: []string{"pets"}}
+	enum := &GenDefinition{GenSchema: GenSchema{Name: "color"}}
+	enum.Enum = []any{"red", "green"}
+
+	for _, tc := range []struct {
+		when     string
+		data     any
+		expected bool
+	}{
+		{when: "", data: plain, expected: true},
+		{when: `hasKey .Extensions "x-streaming"`, data: streaming, expected: true},
+		{when: `hasKey .Extensions "x-streaming"`, data: plain, expected: false},
+		{when: `has "pets" .Tags`, data: plain, expected: true},
+		{when: `has "pets" .Tags`, data: streaming, expected: false},
+		{when: ".Context.Enum", data: enum, expected: true},
+		{when: ".Context.Enum", data: &GenDefinition{GenSchema: GenSchema{Name: "pet"}}, expected: false},
+		{when: "not .Opts.IsClient", data: plain, expected: true},
+	} {
+		opts := testGenOpts()
+		matches, err := opts.matchesCondition(&TemplateOpts{Name: "conditional", When: tc.when}, tc.data)
+		require.NoError(t, err, tc.when)
+		assert.EqualT(t, tc.expected, matches, tc.when)
+	}
+
+	t.Run("should fail on an invalid condition", func(t *testing.T) {
+		opts := testGenOpts()
+		_, err := opts.matchesCondition(&TemplateOpts{Name: "conditional", When: "hasKey .Extensions"}, plain)
+		require.Error(t, err)
+		assert.StringContainsT(t, err.Error(), "could not evaluate condition")
+	})
+
+	t.Run("should skip the file when the condition is not met", func(t *testing.T) {
+		opts := testGenOpts()
+		opts.Target = t.TempDir()
+		require.NoError(t, os.WriteFile(filepath.Join(opts.Target, "go.mod"), []byte("module conditional\n"), readableFile))
+		require.NoError(t, opts.templates.AddFile("conditional", "package {{ .Name }}"))
+
+		var skipped []Event
+		opts.Events = func(e Event) {
+			if e.Kind == EventFileSkipped {
+				skipped = append(skipped, e)
+			}
+		}
+
+		tplOpts := TemplateOpts{
+			Name:     "conditional",
+			Source:   "asset:conditional",
+			Target:   "{{ .Target }}",
+			FileName: "{{ .Name }}.go",
+			When:     `hasKey .Extensions "x-streaming"`,
+		}
+		require.NoError(t, opts.write(&tplOpts, plain))
+		require.NoError(t, opts.write(&tplOpts, streaming))
+
+		assert.FileNotExists(t, filepath.Join(opts.Target, "get.go"))
+		assert.FileExists(t, filepath.Join(opts.Target, "watch.go"))
+		require.Len(t, skipped, 1)
+		assert.EqualT(t, SkipReasonCondition, skipped[0].Reason)
+	})
+}
+
 // Test correctly parsed templates, with bad formatting.
 func TestShared_BadFormatTemplate(t *testing.T) {
 	defer discardOutput()()
@@ -572,9 +684,10 @@ func TestShared_GatherModel(t *testing.T) {
 
 func TestShared_DumpWrongData(t *testing.T) {
 	w := io.Discard
+	opts := &GenOpts{}
 
 	t.Run("should not be able to dump things that don't marshal as JSON", func(t *testing.T) {
-		require.Error(t, dumpData(w, struct {
+		require.Error(t, opts.dumpData(w, struct {
 			A func() string
 			B string
 		}{
@@ -584,7 +697,7 @@ func TestShared_DumpWrongData(t *testing.T) {
 	})
 
 	t.Run("should dump any data, with unmarshallable fields exlicitly excluded", func(t *testing.T) {
-		require.NoError(t, dumpData(w, struct {
+		require.NoError(t, opts.dumpData(w, struct {
 			A func() string `json:"-"`
 			B string
 		}{
@@ -592,7 +705,7 @@ func TestShared_DumpWrongData(t *testing.T) {
 			B: "xyz",
 		}))
 
-		require.NoError(t, dumpData(w, struct {
+		require.NoError(t, opts.dumpData(w, struct {
 			a func() string
 			B string
 		}{
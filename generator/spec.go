@@ -7,10 +7,10 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
-	"log"
 	"os"
 	"path/filepath"
 	"strings"
+	"time"
 
 	"github.com/go-openapi/analysis"
 	swaggererrors "github.com/go-openapi/errors"
@@ -23,12 +23,34 @@ import (
 	yamlv2 "gopkg.in/yaml.v2"
 )
 
+// loadSpec loads the spec document. A spec altered in memory is loaded from its content,
+// with the path of the spec to resolve its relative $ref's.
+func (g *GenOpts) loadSpec() (*loads.Document, error) {
+	if g.specContent == nil {
+		return loads.Spec(g.Spec)
+	}
+
+	return loads.Spec(g.Spec, loads.WithDocLoader(func(pth string, opts ...loading.Option) (json.RawMessage, error) {
+		if pth == g.Spec {
+			return g.specContent, nil
+		}
+
+		if loading.YAMLMatcher(pth) {
+			return loading.YAMLDoc(pth, opts...)
+		}
+
+		return loads.JSONDoc(pth, opts...)
+	}))
+}
+
 func (g *GenOpts) validateAndFlattenSpec() (*loads.Document, error) {
 	// Load spec document
-	specDoc, err := loads.Spec(g.Spec)
+	start := time.Now()
+	specDoc, err := g.loadSpec()
 	if err != nil {
 		return nil, err
 	}
+	g.emitSince(start, Event{Kind: EventSpecLoaded, Path: g.Spec})
 
 	// If accepts definitions only, add dummy swagger header to pass validation
 	if g.AcceptDefinitionsOnly {
@@ -40,7 +62,8 @@ func (g *GenOpts) validateAndFlattenSpec() (*loads.Document, error) {
 
 	// Validate if needed
 	if g.ValidateSpec {
-		log.Printf("validating spec %v", g.Spec)
+		g.logf("validating spec %v", g.Spec)
+		start = time.Now()
 		validationErrors := validate.Spec(specDoc, strfmt.Default)
 		if validationErrors != nil {
 			var b strings.Builder
@@ -62,7 +85,8 @@ func (g *GenOpts) validateAndFlattenSpec() (*loads.Document, error) {
 
 		// TODO(fredbi): due to uncontrolled $ref state in spec, we need to reload the spec atm, or flatten won't
 		// work properly (validate expansion alters the $ref cache in go-openapi/spec)
-		specDoc, _ = loads.Spec(g.Spec)
+		specDoc, _ = g.loadSpec()
+		g.emitSince(start, Event{Kind: EventSpecValidated, Path: g.Spec})
 	}
 
 	// Flatten spec
@@ -94,6 +118,7 @@ func (g *GenOpts) validateAndFlattenSpec() (*loads.Document, error) {
 
 	g.printFlattenOpts()
 
+	start = time.Now()
 	if err = analysis.Flatten(*g.FlattenOpts); err != nil {
 		return nil, err
 	}
@@ -107,6 +132,7 @@ func (g *GenOpts) validateAndFlattenSpec() (*loads.Document, error) {
 		// ensure that Pristine refreshes its row root document.
 		specDoc = specDoc.Pristine()
 	}
+	g.emitSince(start, Event{Kind: EventSpecFlattened, Path: g.Spec})
 
 	// yields the preprocessed spec document
 	return specDoc, nil
@@ -121,8 +147,15 @@ func (g *GenOpts) analyzeSpec() (*loads.Document, *analysis.Spec, error) {
 
 	// spec preprocessing option
 	if g.PropertiesSpecOrder {
-		g.Spec = WithAutoXOrder(g.Spec)
-		specDoc, err = loads.Spec(g.Spec)
+		if g.specContent != nil {
+			if g.specContent, err = autoXOrder(g.specContent); err != nil {
+				return nil, nil, err
+			}
+		} else {
+			g.Spec = WithAutoXOrder(g.Spec)
+		}
+
+		specDoc, err = g.loadSpec()
 		if err != nil {
 			return nil, nil, err
 		}
@@ -144,7 +177,7 @@ func (g *GenOpts) printFlattenOpts() {
 	default:
 		preprocessingOption = "full flattening"
 	}
-	log.Printf("preprocessing spec with option:  %s", preprocessingOption)
+	g.logf("preprocessing spec with option:  %s", preprocessingOption)
 }
 
 // findSwaggerSpec fetches a default swagger spec if none is provided.
@@ -176,9 +209,34 @@ func findSwaggerSpec(nm string) (string, error) {
 
 // WithAutoXOrder amends the spec to specify property order as they appear
 // in the spec (supports yaml documents only).
+func WithAutoXOrder(specPath string) string {
+	data, err := loading.LoadFromFileOrHTTP(specPath)
+	if err != nil {
+		panic(err)
+	}
+
+	out, err := autoXOrder(data)
+	if err != nil {
+		panic(err)
+	}
+
+	tmpDir, err := os.MkdirTemp("", "go-swagger-")
+	if err != nil {
+		panic(err)
+	}
+
+	tmpFile := filepath.Join(tmpDir, filepath.Base(specPath))
+	if err := os.WriteFile(tmpFile, out, readableFile); err != nil {
+		panic(err)
+	}
+	return tmpFile
+}
+
+// autoXOrder adds an x-order extension to the properties of the schemas of a spec document, in the order
+// they appear in the document.
 //
 //nolint:gocognit // TODO(fredbi): refactor
-func WithAutoXOrder(specPath string) string {
+func autoXOrder(data []byte) ([]byte, error) {
 	lookFor := func(ele any, key string) (yamlv2.MapSlice, bool) {
 		if slice, ok := ele.(yamlv2.MapSlice); ok {
 			for _, v := range slice {
@@ -226,14 +284,9 @@ func WithAutoXOrder(specPath string) string {
 		}
 	}
 
-	data, err := loading.LoadFromFileOrHTTP(specPath)
-	if err != nil {
-		panic(err)
-	}
-
 	yamlDoc, err := BytesToYAMLv2Doc(data)
 	if err != nil {
-		panic(err)
+		return nil, err
 	}
 
 	if defs, ok := lookFor(yamlDoc, "definitions"); ok {
@@ -244,21 +297,7 @@ func WithAutoXOrder(specPath string) string {
 
 	addXOrder(yamlDoc)
 
-	out, err := yamlv2.Marshal(yamlDoc)
-	if err != nil {
-		panic(err)
-	}
-
-	tmpDir, err := os.MkdirTemp("", "go-swagger-")
-	if err != nil {
-		panic(err)
-	}
-
-	tmpFile := filepath.Join(tmpDir, filepath.Base(specPath))
-	if err := os.WriteFile(tmpFile, out, readableFile); err != nil {
-		panic(err)
-	}
-	return tmpFile
+	return yamlv2.Marshal(yamlDoc)
 }
 
 // BytesToYAMLv2Doc converts a byte slice into a YAML document.
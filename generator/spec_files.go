@@ -0,0 +1,52 @@
+// SPDX-FileCopyrightText: Copyright 2015-2025 go-swagger maintainers
+// SPDX-License-Identifier: Apache-2.0
+
+package generator
+
+import (
+	"path/filepath"
+	"strings"
+)
+
+// SpecFiles lists the local files which make up a spec: the spec document, then every file reached through $ref, transitively.
+//
+// With an empty name, the spec is searched like for a generation (swagger.{json,yml,yaml} in the current directory).
+// A spec served over http has no local file. Files reached through $ref which cannot be loaded are listed,
+// but not searched for more $ref.
+func SpecFiles(spec string) ([]string, error) {
+	if strings.HasPrefix(spec, "http://") || strings.HasPrefix(spec, "https://") {
+		return nil, nil
+	}
+
+	specPath, err := findSwaggerSpec(spec)
+	if err != nil {
+		return nil, err
+	}
+
+	if specPath, err = filepath.Abs(specPath); err != nil {
+		return nil, err
+	}
+
+	files := []string{specPath}
+	seen := map[string]bool{specPath: true}
+	for i := 0; i < len(files); i++ {
+		doc, err := loadRawDocument(files[i])
+		if err != nil {
+			if i == 0 {
+				return nil, err
+			}
+
+			continue
+		}
+
+		walkRefs(doc, func(_ map[string]any, ref string) {
+			file, _, remote := resolveRef(files[i], ref)
+			if remote && !seen[file] {
+				seen[file] = true
+				files = append(files, file)
+			}
+		})
+	}
+
+	return files, nil
+}
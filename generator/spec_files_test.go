@@ -0,0 +1,71 @@
+// SPDX-FileCopyrightText: Copyright 2015-2025 go-swagger maintainers
+// SPDX-License-Identifier: Apache-2.0
+
+package generator
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/go-openapi/testify/v2/assert"
+	"github.com/go-openapi/testify/v2/require"
+)
+
+func TestSpecFiles(t *testing.T) {
+	dir := t.TempDir()
+	files := map[string]string{
+		"swagger.yml": `swagger: "2.0"
+info: {title: files, version: "1"}
+paths:
+  /pets:
+    $ref: ./paths/pets.yml
+definitions:
+  Pet:
+    $ref: "./common/types.yml#/definitions/Pet"
+  Self:
+    $ref: "#/definitions/Pet"
+  Remote:
+    $ref: "https://example.com/types.yml#/definitions/Remote"
+`,
+		"paths/pets.yml": `get:
+  responses:
+    200:
+      description: pets
+      schema:
+        $ref: "../common/types.yml#/definitions/Pet"
+`,
+		"common/types.yml": `definitions:
+  Pet:
+    type: object
+    properties:
+      owner:
+        $ref: "./missing.yml#/definitions/Owner"
+`,
+	}
+	for name, content := range files {
+		pth := filepath.Join(dir, filepath.FromSlash(name))
+		require.NoError(t, os.MkdirAll(filepath.Dir(pth), 0o700))
+		require.NoError(t, os.WriteFile(pth, []byte(content), readableFile))
+	}
+
+	found, err := SpecFiles(filepath.Join(dir, "swagger.yml"))
+	require.NoError(t, err)
+	assert.Equal(t, []string{
+		filepath.Join(dir, "swagger.yml"),
+		filepath.Join(dir, "common", "types.yml"),
+		filepath.Join(dir, "paths", "pets.yml"),
+		filepath.Join(dir, "common", "missing.yml"),
+	}, found)
+
+	t.Run("should not list the files of a remote spec", func(t *testing.T) {
+		found, err := SpecFiles("https://example.com/swagger.yml")
+		require.NoError(t, err)
+		assert.Empty(t, found)
+	})
+
+	t.Run("should fail with a missing spec", func(t *testing.T) {
+		_, err := SpecFiles(filepath.Join(dir, "missing.yml"))
+		require.Error(t, err)
+	})
+}
@@ -33,6 +33,7 @@ type GenDefinition struct {
 	GenSchema
 
 	Package        string
+	ModelPackage   string // the sub-package of the models package where this model is generated, when models are split
 	CliPackage     string
 	Imports        map[string]string
 	DefaultImports map[string]string
@@ -664,6 +665,23 @@ func (g GenOperations) Len() int           { return len(g) }
 func (g GenOperations) Less(i, j int) bool { return g[i].Name < g[j].Name }
 func (g GenOperations) Swap(i, j int)      { g[i], g[j] = g[j], g[i] }
 
+// GenTag represents a tag, with the operations which carry this tag.
+//
+// Operations carrying several tags are listed under each of their tags.
+type GenTag struct {
+	GenCommon
+
+	Name         string
+	Description  string
+	ExternalDocs *spec.ExternalDocumentation
+	Extensions   map[string]any
+	Operations   GenOperations
+	GenOpts      *GenOpts
+}
+
+// GenTags is an ordered collection of tags, as declared by the spec.
+type GenTags []GenTag
+
 // GenApp represents all the meta data needed to generate an application
 // from a swagger spec.
 type GenApp struct {
@@ -696,6 +714,9 @@ type GenApp struct {
 	Models                     []GenDefinition
 	Operations                 GenOperations
 	OperationGroups            GenOperationGroups
+	TagDefinitions             GenTags       // tags with their operations, for the "tags" section
+	NamedResponses             []GenResponse // responses declared in #/responses, for the "responses" section
+	NamedParameters            GenParameters // parameters declared in #/parameters, for the "parameters" section
 	SwaggerJSON                string
 	// Embedded specs: this is important for when the generated server adds routes.
 	// NOTE: there is a distinct advantage to having this in runtime rather than generated code.
@@ -8,11 +8,11 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
-	"log"
 	"os"
 	"path"
 	"path/filepath"
 	"sort"
+	"time"
 
 	"github.com/go-openapi/analysis"
 	"github.com/go-openapi/loads"
@@ -22,6 +22,8 @@ import (
 
 // GenerateServer generates a server application.
 func GenerateServer(name string, modelNames, operationIDs []string, opts *GenOpts) error {
+	defer opts.beginGeneration()()
+
 	generator, err := newAppGenerator(name, modelNames, operationIDs, opts)
 	if err != nil {
 		return err
@@ -31,6 +33,8 @@ func GenerateServer(name string, modelNames, operationIDs []string, opts *GenOpt
 
 // GenerateSupport generates the supporting files for an API.
 func GenerateSupport(name string, modelNames, operationIDs []string, opts *GenOpts) error {
+	defer opts.beginGeneration()()
+
 	generator, err := newAppGenerator(name, modelNames, operationIDs, opts)
 	if err != nil {
 		return err
@@ -40,6 +44,8 @@ func GenerateSupport(name string, modelNames, operationIDs []string, opts *GenOp
 
 // GenerateMarkdown documentation for a swagger specification.
 func GenerateMarkdown(output string, modelNames, operationIDs []string, opts *GenOpts) error {
+	defer opts.beginGeneration()()
+
 	if output == "." || output == "" {
 		output = "markdown.md"
 	}
@@ -95,11 +101,16 @@ func newAppGenerator(name string, modelNames, operationIDs []string, opts *GenOp
 		return nil, err
 	}
 
+	specPath := opts.Spec
 	specDoc, analyzed, err := opts.analyzeSpec()
 	if err != nil {
 		return nil, err
 	}
 
+	if opts.packages, err = opts.planModelPackages(specPath, specDoc); err != nil {
+		return nil, err
+	}
+
 	models, err := gatherModels(specDoc, modelNames)
 	if err != nil {
 		return nil, err
@@ -158,48 +169,72 @@ func (a *appGenerator) Generate() error {
 	}
 
 	if a.DumpData {
-		return dumpData(os.Stdout, app)
+		return a.GenOpts.dumpData(os.Stdout, app)
 	}
 
-	// NOTE: relative to previous implem with chan.
-	// IPC removed concurrent execution because of the FuncMap that is being shared
-	// templates are now lazy loaded so there is concurrent map access I can't guard
+	// incremental generation: skip items which fingerprint is unchanged since the previous run
+	start := time.Now()
+	a.GenOpts.openCache()
+	defer a.GenOpts.closeCache()
+
+	// models, then operations and operation groups are rendered and formatted by a bounded pool of workers.
+	// Supporting files are rendered last, once all models and operations are available.
 	if a.GenOpts.IncludeModel {
-		log.Printf("rendering %d models", len(app.Models))
+		a.GenOpts.logf("rendering %d models", len(app.Models))
+		jobs := make([]renderJob, 0, len(app.Models))
 		for _, md := range app.Models {
 			mod := md
 			mod.IncludeModel = true
 			mod.IncludeValidator = a.GenOpts.IncludeValidator
-			if err := a.GenOpts.renderDefinition(&mod); err != nil {
-				return err
-			}
+			jobs = append(jobs, func() error {
+				return a.GenOpts.renderDefinition(&mod)
+			})
+		}
+
+		if err := a.GenOpts.renderConcurrently(jobs); err != nil {
+			return err
 		}
 	}
 
 	if a.GenOpts.IncludeHandler {
-		log.Printf("rendering %d operation groups (tags)", app.OperationGroups.Len())
+		a.GenOpts.logf("rendering %d operation groups (tags)", app.OperationGroups.Len())
+		jobs := make([]renderJob, 0, len(app.Operations)+len(app.OperationGroups))
 		for _, g := range app.OperationGroups {
 			opg := g
-			log.Printf("rendering %d operations for %s", opg.Operations.Len(), opg.Name)
+			a.GenOpts.logf("rendering %d operations for %s", opg.Operations.Len(), opg.Name)
 			for _, p := range opg.Operations {
 				op := p
-				if err := a.GenOpts.renderOperation(&op); err != nil {
-					return err
-				}
+				jobs = append(jobs, func() error {
+					return a.GenOpts.renderOperation(&op)
+				})
 			}
 			// optional OperationGroups templates generation
-			if err := a.GenOpts.renderOperationGroup(&opg); err != nil {
-				return fmt.Errorf("error while rendering operation group: %w", err)
-			}
+			jobs = append(jobs, func() error {
+				if err := a.GenOpts.renderOperationGroup(&opg); err != nil {
+					return fmt.Errorf("error while rendering operation group: %w", err)
+				}
+
+				return nil
+			})
+		}
+
+		if err := a.GenOpts.renderConcurrently(jobs); err != nil {
+			return err
 		}
 	}
 
 	if a.GenOpts.IncludeSupport {
-		log.Printf("rendering support")
+		a.GenOpts.logf("rendering support")
 		if err := a.GenerateSupport(&app); err != nil {
 			return err
 		}
 	}
+
+	if err := a.GenOpts.saveCache(); err != nil {
+		return err
+	}
+	a.GenOpts.emitSince(start, Event{Kind: EventGenerationDone})
+
 	return nil
 }
 
@@ -254,7 +289,7 @@ func (a *appGenerator) makeSecuritySchemes() GenSecuritySchemes {
 
 //nolint:gocognit,gocyclo,cyclop,maintidx // TODO(fredbi): refactor
 func (a *appGenerator) makeCodegenApp() (GenApp, error) {
-	log.Println("building a plan for generation")
+	a.GenOpts.logf("building a plan for generation")
 
 	sw := a.SpecDoc.Spec()
 	receiver := a.Receiver
@@ -263,7 +298,7 @@ func (a *appGenerator) makeCodegenApp() (GenApp, error) {
 	produces, _ := a.makeProduces()
 	security := a.makeSecuritySchemes()
 
-	log.Println("generation target", a.Target)
+	a.GenOpts.logf("generation target %s", a.Target)
 
 	baseImport := a.GenOpts.LanguageOpts.BaseImport(a.Target)
 	defaultImports := a.GenOpts.defaultImports()
@@ -282,7 +317,7 @@ func (a *appGenerator) makeCodegenApp() (GenApp, error) {
 		imports[implAlias] = a.GenOpts.ImplementationPackage
 	}
 
-	log.Printf("planning definitions (found: %d)", len(a.Models))
+	a.GenOpts.logf("planning definitions (found: %d)", len(a.Models))
 
 	genModels := make(GenDefinitions, 0, len(a.Models))
 	for mn, m := range a.Models {
@@ -311,7 +346,7 @@ func (a *appGenerator) makeCodegenApp() (GenApp, error) {
 	}
 	sort.Sort(genModels)
 
-	log.Printf("planning operations (found: %d)", len(a.Operations))
+	a.GenOpts.logf("planning operations (found: %d)", len(a.Operations))
 
 	genOps := make(GenOperations, 0, len(a.Operations))
 	consumesIndex := make(map[string][]string)
@@ -344,7 +379,7 @@ func (a *appGenerator) makeCodegenApp() (GenApp, error) {
 			DefaultConsumes:  a.DefaultConsumes,
 		}
 
-		tag, tags, ok := bldr.analyzeTags()
+		_, tags, ok := bldr.analyzeTags()
 		if !ok {
 			continue // operation filtered according to CLI params
 		}
@@ -383,7 +418,7 @@ func (a *appGenerator) makeCodegenApp() (GenApp, error) {
 		}
 		producesIndex[bldr.Name] = allProduces
 
-		if !a.GenOpts.SkipTagPackages && tag != "" {
+		if bldr.Grouped {
 			importPath := filepath.ToSlash(
 				path.Join(
 					baseImport,
@@ -411,13 +446,13 @@ func (a *appGenerator) makeCodegenApp() (GenApp, error) {
 		opsGroupedByPackage[operation.PackageAlias] = append(opsGroupedByPackage[operation.PackageAlias], operation)
 	}
 
-	log.Printf("grouping operations into packages (packages: %d)", len(opsGroupedByPackage))
+	a.GenOpts.logf("grouping operations into packages (packages: %d)", len(opsGroupedByPackage))
 
 	opGroups := make(GenOperationGroups, 0, len(opsGroupedByPackage))
 	const sensibleConsumesAlloc = 2
 
 	for k, v := range opsGroupedByPackage {
-		log.Printf("operations for package %q (found: %d)", k, len(v))
+		a.GenOpts.logf("operations for package %q (found: %d)", k, len(v))
 		sort.Sort(v)
 
 		consumesInGroup := make([]string, 0, sensibleConsumesAlloc)
@@ -477,7 +512,7 @@ func (a *appGenerator) makeCodegenApp() (GenApp, error) {
 	}
 	sort.Sort(opGroups)
 
-	log.Println("planning meta data and facades")
+	a.GenOpts.logf("planning meta data and facades")
 
 	var collectedSchemes, extraSchemes []string
 	for _, op := range genOps {
@@ -497,6 +532,23 @@ func (a *appGenerator) makeCodegenApp() (GenApp, error) {
 		basePath = sw.BasePath
 	}
 
+	// named responses and parameters are only planned when some templates iterate over them
+	var namedResponses []GenResponse
+	if len(a.GenOpts.Sections.Responses) > 0 || a.GenOpts.DumpData {
+		var err error
+		if namedResponses, err = a.makeNamedResponses(defaultImports, imports); err != nil {
+			return GenApp{}, err
+		}
+	}
+
+	var namedParameters GenParameters
+	if len(a.GenOpts.Sections.Parameters) > 0 || a.GenOpts.DumpData {
+		var err error
+		if namedParameters, err = a.makeNamedParameters(defaultImports, imports); err != nil {
+			return GenApp{}, err
+		}
+	}
+
 	jsonb, err := json.MarshalIndent(a.SpecDoc.OrigSpec(), "", "  ")
 	if err != nil {
 		return GenApp{}, err
@@ -535,6 +587,9 @@ func (a *appGenerator) makeCodegenApp() (GenApp, error) {
 		Models:                     genModels,
 		Operations:                 genOps,
 		OperationGroups:            opGroups,
+		TagDefinitions:             a.makeTagDefinitions(genOps, baseImport),
+		NamedResponses:             namedResponses,
+		NamedParameters:            namedParameters,
 		Principal:                  a.GenOpts.PrincipalAlias(),
 		SwaggerJSON:                generateReadableSpec(jsonb),
 		FlatSwaggerJSON:            generateReadableSpec(flatjsonb),
@@ -0,0 +1,467 @@
+// SPDX-FileCopyrightText: Copyright 2015-2025 go-swagger maintainers
+// SPDX-License-Identifier: Apache-2.0
+
+package generator
+
+import (
+	"bytes"
+	"cmp"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"go/build"
+	"os"
+	"os/exec"
+	"path"
+	"path/filepath"
+	"reflect"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/Masterminds/semver/v3"
+	"github.com/spf13/viper"
+	"golang.org/x/mod/modfile"
+	"golang.org/x/mod/module"
+	modsemver "golang.org/x/mod/semver"
+
+	templatesrepo "github.com/go-swagger/go-swagger/generator/internal/templates-repo"
+)
+
+// TemplatePackManifest is the name of the manifest of a template pack, at the root of the pack.
+const TemplatePackManifest = "pack.yml"
+
+// Types of the options of a template pack.
+const (
+	PackOptionString = "string"
+	PackOptionBool   = "bool"
+)
+
+// TemplatePack is a set of templates, described by a manifest.
+//
+// A pack may be contributed with the generator (e.g. "stratoscale"), or found in a local directory,
+// or in a go module. Its templates override the default templates, including protected ones,
+// and may in turn be overridden by the templates of the TemplateDir.
+type TemplatePack struct {
+	Name        string `mapstructure:"name"`
+	Version     string `mapstructure:"version"`
+	Description string `mapstructure:"description"`
+
+	// Generator is a constraint on the versions of the generator which support the pack, e.g. ">= 0.31, < 0.40".
+	Generator string `mapstructure:"generator"`
+
+	// Layout is the layout of the files generated by the pack. Sections left empty retain the default layout.
+	Layout SectionOpts `mapstructure:"layout"`
+
+	// Options are passed to the templates of the pack, as PackOptions.
+	Options []TemplatePackOption `mapstructure:"options"`
+
+	// Imports are the packages which the generated code needs, besides the ones needed by the default templates.
+	// They are reported when the go module of the target does not require them.
+	Imports []string `mapstructure:"imports"`
+
+	// Dir is the directory of the pack on disk, empty for a contributed pack.
+	Dir string `mapstructure:"-"`
+
+	contrib string // name of a contributed pack
+}
+
+// TemplatePackOption is an option declared by a template pack.
+type TemplatePackOption struct {
+	Name        string `mapstructure:"name"`
+	Description string `mapstructure:"description"`
+	Type        string `mapstructure:"type"` // string (default) or bool
+	Default     string `mapstructure:"default"`
+}
+
+// LoadTemplatePack resolves a template pack and reads its manifest.
+//
+// The reference to a pack is either:
+//   - the name of a pack contributed with the generator, e.g. "stratoscale"
+//   - a local directory
+//   - a go module, with an optional version and sub-directory: "path[@version][//subdir]".
+//     The module is looked up in the module cache, and downloaded there if not found.
+//     Without a version, the highest version found in the module cache is used.
+//
+// The context cancels the download of a module.
+func LoadTemplatePack(ctx context.Context, ref string) (*TemplatePack, error) {
+	if ref == "" {
+		return nil, errors.New("a template pack is required")
+	}
+
+	if manifest, err := Asset(contribAssetPath(ref, TemplatePackManifest)); err == nil {
+		pack, err := readTemplatePack(manifest, ref)
+		if err != nil {
+			return nil, err
+		}
+		pack.contrib = ref
+
+		return pack, nil
+	}
+
+	dir, err := templatePackDir(ctx, ref)
+	if err != nil {
+		return nil, err
+	}
+
+	manifest, err := os.ReadFile(filepath.Join(dir, TemplatePackManifest))
+	if err != nil {
+		return nil, fmt.Errorf("template pack %q has no manifest: %w", ref, err)
+	}
+
+	pack, err := readTemplatePack(manifest, ref)
+	if err != nil {
+		return nil, err
+	}
+	pack.Dir = dir
+
+	return pack, nil
+}
+
+func contribAssetPath(name, file string) string {
+	return path.Join("templates", "contrib", name, file)
+}
+
+func readTemplatePack(manifest []byte, ref string) (*TemplatePack, error) {
+	v := viper.New()
+	v.SetConfigType("yaml")
+	if err := v.ReadConfig(bytes.NewReader(manifest)); err != nil {
+		return nil, fmt.Errorf("invalid manifest for template pack %q: %w", ref, err)
+	}
+
+	var pack TemplatePack
+	if err := v.Unmarshal(&pack); err != nil {
+		return nil, fmt.Errorf("invalid manifest for template pack %q: %w", ref, err)
+	}
+
+	if pack.Name == "" {
+		return nil, fmt.Errorf("invalid manifest for template pack %q: a name is required", ref)
+	}
+
+	for i, option := range pack.Options {
+		if option.Name == "" {
+			return nil, fmt.Errorf("invalid manifest for template pack %q: option #%d has no name", ref, i)
+		}
+
+		switch option.Type {
+		case "":
+			pack.Options[i].Type = PackOptionString
+		case PackOptionString:
+		case PackOptionBool:
+			if _, err := strconv.ParseBool(cmp.Or(option.Default, "false")); err != nil {
+				return nil, fmt.Errorf("invalid manifest for template pack %q: invalid default for option %q: %w", ref, option.Name, err)
+			}
+		default:
+			return nil, fmt.Errorf("invalid manifest for template pack %q: option %q has an unsupported type %q", ref, option.Name, option.Type)
+		}
+	}
+
+	return &pack, nil
+}
+
+// String identifies a pack with its version.
+func (p *TemplatePack) String() string {
+	if p.Version == "" {
+		return p.Name
+	}
+
+	return p.Name + " " + p.Version
+}
+
+// CheckGenerator verifies that the pack supports some version of the generator.
+//
+// Development builds of the generator, which have no released version, support all packs.
+func (p *TemplatePack) CheckGenerator(version string) error {
+	if p.Generator == "" {
+		return nil
+	}
+
+	constraint, err := semver.NewConstraint(p.Generator)
+	if err != nil {
+		return fmt.Errorf("template pack %s has an invalid generator constraint %q: %w", p, p.Generator, err)
+	}
+
+	current, err := semver.NewVersion(version)
+	if err != nil || current.Prerelease() != "" {
+		debugLogf("generator version %q is not a semantic version: the constraint of template pack %s is not checked", version, p)
+
+		return nil
+	}
+
+	if !constraint.Check(current) {
+		return fmt.Errorf("template pack %s requires a generator %s, but this generator is %s", p, p.Generator, version)
+	}
+
+	return nil
+}
+
+// OptionValues resolves the values of the options of the pack, with their defaults.
+//
+// Values of bool options are converted to bool. Values for options which the pack does not declare are an error.
+func (p *TemplatePack) OptionValues(values map[string]string) (map[string]any, error) {
+	resolved := make(map[string]any, len(p.Options))
+
+	for name := range values {
+		if !slices.ContainsFunc(p.Options, func(option TemplatePackOption) bool { return option.Name == name }) {
+			return nil, fmt.Errorf("template pack %s has no option %q", p, name)
+		}
+	}
+
+	for _, option := range p.Options {
+		value, isSet := values[option.Name]
+		if !isSet {
+			value = option.Default
+		}
+
+		if option.Type != PackOptionBool {
+			resolved[option.Name] = value
+
+			continue
+		}
+
+		b, err := strconv.ParseBool(cmp.Or(value, "false"))
+		if err != nil {
+			return nil, fmt.Errorf("invalid value for option %q of template pack %s: %w", option.Name, p, err)
+		}
+		resolved[option.Name] = b
+	}
+
+	return resolved, nil
+}
+
+// applyLayout sets the sections of the layout declared by the pack, unless they are already set.
+func (p *TemplatePack) applyLayout(sections *SectionOpts) {
+	packLayout := reflect.ValueOf(p.Layout)
+	layout := reflect.ValueOf(sections).Elem()
+
+	for i := range layout.NumField() {
+		if layout.Field(i).Len() == 0 && packLayout.Field(i).Len() > 0 {
+			layout.Field(i).Set(packLayout.Field(i))
+		}
+	}
+}
+
+func (p *TemplatePack) loadTemplates(repo *templatesrepo.Repository) error {
+	if p.contrib != "" {
+		return repo.LoadContrib(p.contrib, embeddedAssets{})
+	}
+
+	return repo.LoadPack(p.Dir)
+}
+
+// loadTemplatePack loads the manifest of the template pack, resolves its options and applies its layout.
+func (g *GenOpts) loadTemplatePack() error {
+	if g.TemplatePack == "" {
+		if len(g.TemplatePackOptions) > 0 {
+			return errors.New("options of a template pack are set, but no template pack is used")
+		}
+
+		return nil
+	}
+
+	pack, err := LoadTemplatePack(g.context(), g.TemplatePack)
+	if err != nil {
+		return err
+	}
+
+	if err := pack.CheckGenerator(generatorVersion()); err != nil {
+		return err
+	}
+
+	values, err := pack.OptionValues(g.TemplatePackOptions)
+	if err != nil {
+		return err
+	}
+
+	g.PackOptions = values
+	pack.applyLayout(&g.Sections)
+	g.templatePack = pack
+	debugLogf("using template pack %s", pack)
+
+	return nil
+}
+
+// noticePackImports reports the packages needed by the code generated with the template pack
+// which the go module of the target does not require, or all of them when the target is not in a go module.
+//
+// Files generated in memory or in an archive are left alone.
+func (g *GenOpts) noticePackImports(target string) {
+	pack := g.templatePack
+	if pack == nil || len(pack.Imports) == 0 {
+		return
+	}
+
+	if _, onDisk := g.fs().(osFS); !onDisk {
+		return
+	}
+
+	missing := pack.Imports
+	goMod := findGoMod(target)
+	if goMod != "" {
+		content, err := os.ReadFile(goMod)
+		if err != nil {
+			g.warnf("could not check the packages needed by template pack %s: %v", pack, err)
+
+			return
+		}
+
+		mod, err := modfile.ParseLax(goMod, content, nil)
+		if err != nil {
+			g.warnf("could not check the packages needed by template pack %s: %v", pack, err)
+
+			return
+		}
+
+		if missing = missingImports(mod, pack.Imports); len(missing) == 0 {
+			return
+		}
+	}
+
+	g.warnf("the code generated with template pack %s needs these packages in your go.mod: %s (go get %s)",
+		pack, strings.Join(missing, ", "), strings.Join(missing, " "),
+	)
+}
+
+// missingImports returns the packages which are not provided by a go module or its requirements.
+//
+// Packages may be given with a version, e.g. github.com/stretchr/testify@v1.9.0.
+func missingImports(mod *modfile.File, imports []string) []string {
+	provided := make([]string, 0, len(mod.Require)+1)
+	if mod.Module != nil {
+		provided = append(provided, mod.Module.Mod.Path)
+	}
+	for _, require := range mod.Require {
+		provided = append(provided, require.Mod.Path)
+	}
+
+	var missing []string
+	for _, imported := range imports {
+		pkg, _, _ := strings.Cut(imported, "@")
+		if !slices.ContainsFunc(provided, func(modulePath string) bool {
+			return pkg == modulePath || strings.HasPrefix(pkg, modulePath+"/")
+		}) {
+			missing = append(missing, imported)
+		}
+	}
+
+	return missing
+}
+
+// findGoMod finds the go.mod file of the module containing a directory, or returns an empty string.
+func findGoMod(dir string) string {
+	dir, err := filepath.Abs(dir)
+	if err != nil {
+		return ""
+	}
+
+	for {
+		goMod := filepath.Join(dir, "go.mod")
+		if info, err := os.Stat(goMod); err == nil && !info.IsDir() {
+			return goMod
+		}
+
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return ""
+		}
+		dir = parent
+	}
+}
+
+// LoadedTemplatePack returns the template pack used for generation, if any.
+//
+// The pack is loaded by EnsureDefaults.
+func (g *GenOpts) LoadedTemplatePack() *TemplatePack {
+	return g.templatePack
+}
+
+// templatePackDir resolves the directory of a template pack on disk.
+func templatePackDir(ctx context.Context, ref string) (string, error) {
+	if info, err := os.Stat(ref); err == nil && info.IsDir() {
+		return ref, nil
+	}
+
+	modulePath, subDir, _ := strings.Cut(ref, "//")
+	modulePath, version, _ := strings.Cut(modulePath, "@")
+	if err := module.CheckPath(modulePath); err != nil {
+		return "", fmt.Errorf("template pack %q is neither a contributed pack, a directory nor a go module: %w", ref, err)
+	}
+
+	dir, err := moduleDir(ctx, modulePath, version)
+	if err != nil {
+		return "", fmt.Errorf("could not find template pack %q: %w", ref, err)
+	}
+
+	return filepath.Join(dir, filepath.FromSlash(subDir)), nil
+}
+
+// moduleDir finds a module in the module cache, and downloads it if not found.
+func moduleDir(ctx context.Context, modulePath, version string) (string, error) {
+	cache := moduleCache()
+	escapedPath, err := module.EscapePath(modulePath)
+	if err != nil {
+		return "", err
+	}
+
+	if version == "" {
+		// the highest version in the module cache
+		candidates, _ := filepath.Glob(filepath.Join(cache, filepath.FromSlash(escapedPath)+"@*"))
+		var highest string
+		for _, candidate := range candidates {
+			_, v, _ := strings.Cut(filepath.Base(candidate), "@")
+			if unescaped, err := module.UnescapeVersion(v); err == nil && modsemver.IsValid(unescaped) && modsemver.Compare(unescaped, highest) > 0 {
+				highest = unescaped
+			}
+		}
+		if highest == "" {
+			return downloadModule(ctx, modulePath, "latest")
+		}
+		version = highest
+	}
+
+	escapedVersion, err := module.EscapeVersion(version)
+	if err != nil {
+		return "", err
+	}
+
+	dir := filepath.Join(cache, filepath.FromSlash(escapedPath)+"@"+escapedVersion)
+	if info, err := os.Stat(dir); err == nil && info.IsDir() {
+		return dir, nil
+	}
+
+	return downloadModule(ctx, modulePath, version)
+}
+
+func moduleCache() string {
+	if cache := os.Getenv("GOMODCACHE"); cache != "" {
+		return cache
+	}
+
+	gopath, _, _ := strings.Cut(build.Default.GOPATH, string(os.PathListSeparator))
+
+	return filepath.Join(gopath, "pkg", "mod")
+}
+
+// downloadModule downloads a module into the module cache with the go command.
+func downloadModule(ctx context.Context, modulePath, version string) (string, error) {
+	//nolint:gosec // the module is chosen by the user
+	cmd := exec.CommandContext(ctx, "go", "mod", "download", "-json", modulePath+"@"+version)
+	cmd.Dir = os.TempDir() // run outside of any module
+
+	output, runErr := cmd.Output()
+
+	var downloaded struct {
+		Dir   string
+		Error string
+	}
+	if err := json.Unmarshal(output, &downloaded); err != nil {
+		return "", errors.Join(fmt.Errorf("could not download module %s@%s", modulePath, version), runErr)
+	}
+
+	if downloaded.Error != "" {
+		return "", fmt.Errorf("could not download module %s@%s: %s", modulePath, version, downloaded.Error)
+	}
+
+	return downloaded.Dir, nil
+}
@@ -0,0 +1,232 @@
+// SPDX-FileCopyrightText: Copyright 2015-2025 go-swagger maintainers
+// SPDX-License-Identifier: Apache-2.0
+
+package generator
+
+import (
+	"bytes"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"golang.org/x/mod/modfile"
+
+	"github.com/go-openapi/testify/v2/assert"
+	"github.com/go-openapi/testify/v2/require"
+)
+
+const testPackManifest = `name: mypack
+version: 1.2.0
+generator: ">= 0.30"
+layout:
+  tags:
+    - name: tagdoc
+      source: tagdoc.gotmpl
+      target: "{{ .Target }}"
+      file_name: "{{ .Name }}.md"
+options:
+  - name: with-mocks
+    type: bool
+  - name: title
+    default: API
+imports:
+  - github.com/stretchr/testify
+`
+
+func writeTestPack(t *testing.T, dir, manifest string) {
+	t.Helper()
+
+	require.NoError(t, os.MkdirAll(dir, 0o700))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, TemplatePackManifest), []byte(manifest), readableFile))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "tagdoc.gotmpl"), []byte(`{{ define "tagdoc" }}# {{ .Name }}{{ end }}`), readableFile))
+}
+
+func TestLoadTemplatePack(t *testing.T) {
+	t.Run("should load a pack from a directory", func(t *testing.T) {
+		dir := t.TempDir()
+		writeTestPack(t, dir, testPackManifest)
+
+		pack, err := LoadTemplatePack(t.Context(), dir)
+		require.NoError(t, err)
+		assert.EqualT(t, "mypack 1.2.0", pack.String())
+		assert.EqualT(t, dir, pack.Dir)
+		assert.Equal(t, []string{"github.com/stretchr/testify"}, pack.Imports)
+		require.Len(t, pack.Options, 2)
+		assert.EqualT(t, PackOptionBool, pack.Options[0].Type)
+		assert.EqualT(t, PackOptionString, pack.Options[1].Type)
+		require.Len(t, pack.Layout.Tags, 1)
+		assert.EqualT(t, "tagdoc.gotmpl", pack.Layout.Tags[0].Source)
+	})
+
+	t.Run("should load a contributed pack", func(t *testing.T) {
+		pack, err := LoadTemplatePack(t.Context(), "stratoscale")
+		require.NoError(t, err)
+		assert.EqualT(t, "stratoscale", pack.Name)
+		assert.Empty(t, pack.Dir)
+	})
+
+	t.Run("should load a pack from the module cache", func(t *testing.T) {
+		cache := t.TempDir()
+		t.Setenv("GOMODCACHE", cache)
+		// upper case letters are escaped in the module cache
+		writeTestPack(t, filepath.Join(cache, "example.com", "!acme", "packs@v1.0.0", "server"), testPackManifest)
+		writeTestPack(t, filepath.Join(cache, "example.com", "!acme", "packs@v1.2.0", "server"), testPackManifest)
+
+		pack, err := LoadTemplatePack(t.Context(), "example.com/Acme/packs@v1.0.0//server")
+		require.NoError(t, err)
+		assert.EqualT(t, filepath.Join(cache, "example.com", "!acme", "packs@v1.0.0", "server"), pack.Dir)
+
+		pack, err = LoadTemplatePack(t.Context(), "example.com/Acme/packs//server")
+		require.NoError(t, err)
+		assert.EqualT(t, filepath.Join(cache, "example.com", "!acme", "packs@v1.2.0", "server"), pack.Dir)
+	})
+
+	t.Run("should reject an invalid manifest", func(t *testing.T) {
+		dir := t.TempDir()
+		writeTestPack(t, dir, "name: mypack\noptions:\n  - name: level\n    type: int\n")
+
+		_, err := LoadTemplatePack(t.Context(), dir)
+		require.Error(t, err)
+		assert.StringContainsT(t, err.Error(), `option "level" has an unsupported type "int"`)
+	})
+
+	t.Run("should reject a directory without manifest", func(t *testing.T) {
+		_, err := LoadTemplatePack(t.Context(), t.TempDir())
+		require.Error(t, err)
+		assert.StringContainsT(t, err.Error(), "has no manifest")
+	})
+}
+
+func TestTemplatePack_CheckGenerator(t *testing.T) {
+	pack := &TemplatePack{Name: "mypack", Generator: ">= 0.30, < 0.40"}
+
+	require.NoError(t, pack.CheckGenerator("v0.31.0"))
+	require.NoError(t, pack.CheckGenerator("(devel)"))
+	require.NoError(t, pack.CheckGenerator("v0.29.1-0.20250101000000-abcdef123456"))
+
+	err := pack.CheckGenerator("v0.40.1")
+	require.Error(t, err)
+	assert.StringContainsT(t, err.Error(), "requires a generator >= 0.30, < 0.40")
+}
+
+func TestTemplatePack_OptionValues(t *testing.T) {
+	pack := &TemplatePack{
+		Name: "mypack",
+		Options: []TemplatePackOption{
+			{Name: "with-mocks", Type: PackOptionBool},
+			{Name: "title", Type: PackOptionString, Default: "API"},
+		},
+	}
+
+	values, err := pack.OptionValues(nil)
+	require.NoError(t, err)
+	assert.Equal(t, map[string]any{"with-mocks": false, "title": "API"}, values)
+
+	values, err = pack.OptionValues(map[string]string{"with-mocks": "true", "title": "Todo"})
+	require.NoError(t, err)
+	assert.Equal(t, map[string]any{"with-mocks": true, "title": "Todo"}, values)
+
+	_, err = pack.OptionValues(map[string]string{"with-mocks": "maybe"})
+	require.Error(t, err)
+
+	_, err = pack.OptionValues(map[string]string{"unknown": "x"})
+	require.Error(t, err)
+	assert.StringContainsT(t, err.Error(), `has no option "unknown"`)
+}
+
+func TestGenOpts_TemplatePack(t *testing.T) {
+	defer discardOutput()()
+
+	dir := t.TempDir()
+	writeTestPack(t, dir, testPackManifest)
+
+	opts := new(GenOpts)
+	opts.TemplatePack = dir
+	opts.TemplatePackOptions = map[string]string{"title": "Todo"}
+	require.NoError(t, opts.EnsureDefaults())
+	require.NoError(t, opts.setTemplates())
+
+	assert.Equal(t, map[string]any{"with-mocks": false, "title": "Todo"}, opts.PackOptions)
+	assert.EqualT(t, "mypack", opts.LoadedTemplatePack().Name)
+
+	// the layout of the pack fills the empty sections only
+	require.Len(t, opts.Sections.Tags, 1)
+	assert.EqualT(t, "tagdoc", opts.Sections.Tags[0].Name)
+	require.NotEmpty(t, opts.Sections.Models)
+	assert.EqualT(t, "asset:model", opts.Sections.Models[0].Source)
+
+	_, source, ok := opts.templates.Lookup("tagdoc")
+	require.True(t, ok)
+	assert.EqualT(t, OriginPack, source.Origin)
+
+	t.Run("options without a pack are rejected", func(t *testing.T) {
+		opts := new(GenOpts)
+		opts.TemplatePackOptions = map[string]string{"title": "Todo"}
+		require.Error(t, opts.EnsureDefaults())
+	})
+}
+
+func TestNoticePackImports(t *testing.T) {
+	notice := func(t *testing.T, target string) string {
+		t.Helper()
+
+		var logs bytes.Buffer
+		opts := new(GenOpts)
+		opts.Logger = slog.New(slog.NewTextHandler(&logs, nil))
+		opts.templatePack = &TemplatePack{Name: "mypack", Imports: []string{"github.com/stretchr/testify", "github.com/acme/mocks"}}
+		opts.noticePackImports(target)
+
+		return logs.String()
+	}
+
+	t.Run("should report the packages which the go module does not require", func(t *testing.T) {
+		dir := t.TempDir()
+		require.NoError(t, os.WriteFile(filepath.Join(dir, "go.mod"), []byte("module example.com/api\n\nrequire github.com/stretchr/testify v1.9.0\n"), 0o600))
+
+		logs := notice(t, dir)
+		assert.StringContainsT(t, logs, "level=WARN")
+		assert.StringContainsT(t, logs, "go get github.com/acme/mocks)")
+		assert.NotContains(t, logs, "testify")
+
+		content, err := os.ReadFile(filepath.Join(dir, "go.mod"))
+		require.NoError(t, err)
+		assert.EqualT(t, "module example.com/api\n\nrequire github.com/stretchr/testify v1.9.0\n", string(content))
+	})
+
+	t.Run("should report nothing when the go module requires all the packages", func(t *testing.T) {
+		dir := t.TempDir()
+		require.NoError(t, os.WriteFile(filepath.Join(dir, "go.mod"), []byte("module example.com/api\n\nrequire (\n\tgithub.com/stretchr/testify v1.9.0\n\tgithub.com/acme/mocks v0.1.0\n)\n"), 0o600))
+
+		assert.Empty(t, notice(t, dir))
+	})
+}
+
+func TestMissingImports(t *testing.T) {
+	mod, err := modfile.ParseLax("go.mod", []byte(`module example.com/api
+
+require (
+	github.com/go-openapi/runtime v0.28.0
+	github.com/stretchr/testify v1.9.0
+)
+`), nil)
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{"github.com/acme/mocks", "github.com/acme/log@v1.2.0"}, missingImports(mod, []string{
+		"github.com/stretchr/testify/assert",
+		"github.com/go-openapi/runtime",
+		"github.com/acme/mocks",
+		"example.com/api/models",
+		"github.com/acme/log@v1.2.0",
+		"github.com/stretchr/testify@v1.10.0",
+	}))
+}
+
+func TestFindGoMod(t *testing.T) {
+	dir := t.TempDir()
+	nested := filepath.Join(dir, "restapi", "operations")
+	require.NoError(t, os.MkdirAll(nested, 0o700))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "go.mod"), []byte("module example.com/api\n"), 0o600))
+
+	assert.EqualT(t, filepath.Join(dir, "go.mod"), findGoMod(nested))
+}
@@ -82,8 +82,21 @@ func DefaultFuncMap(lang *language.Options) template.FuncMap {
 		return resolvedDocElemType("object", schema.SwaggerFormat, &schema.resolvedType)
 	}
 	f["docCollectionFormat"] = resolvedDocCollectionFormat
+
+	// TypeScript helpers.
+	f["tsType"] = tsType
+	f["tsAliasedType"] = tsAliasedType
+	f["tsIsInterface"] = tsIsInterface
+	f["tsExtends"] = tsExtends
+	f["tsReturnType"] = tsReturnType
+	f["tsImportsModels"] = tsImportsModels
+	f["tsKey"] = tsKey
+	f["tsComment"] = tsComment
 	f["path"] = errorPath
 
+	// Protocol buffers helpers.
+	f["protoFile"] = protoFileFunc(lang, pascalize)
+
 	// CLI command helpers that depend on generator types.
 	f["cmdName"] = func(in any) (string, error) {
 		op, isOperation := in.(GenOperation)
@@ -178,6 +191,20 @@ func defaultAssets() map[string][]byte {
 
 		"markdown/docs.gotmpl": MustAsset("templates/markdown/docs.gotmpl"),
 
+		// typescript templates
+		"typescript/models.gotmpl":     MustAsset("templates/typescript/models.gotmpl"),
+		"typescript/operations.gotmpl": MustAsset("templates/typescript/operations.gotmpl"),
+		"typescript/runtime.gotmpl":    MustAsset("templates/typescript/runtime.gotmpl"),
+		"typescript/index.gotmpl":      MustAsset("templates/typescript/index.gotmpl"),
+
+		// protocol buffers templates
+		"proto/schema.gotmpl":      MustAsset("templates/proto/schema.gotmpl"),
+		"proto/conversions.gotmpl": MustAsset("templates/proto/conversions.gotmpl"),
+
+		// package documentation and go:generate templates
+		"packages/doc.gotmpl":      MustAsset("templates/packages/doc.gotmpl"),
+		"packages/generate.gotmpl": MustAsset("templates/packages/generate.gotmpl"),
+
 		// cli templates
 		"cli/cli.gotmpl":           MustAsset("templates/cli/cli.gotmpl"),
 		"cli/main.gotmpl":          MustAsset("templates/cli/main.gotmpl"),
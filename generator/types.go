@@ -5,7 +5,6 @@ package generator
 
 import (
 	"fmt"
-	"log"
 	"path/filepath"
 	"reflect"
 	"strings"
@@ -49,6 +48,8 @@ const (
 	xGoEnumCI     = "x-go-enum-ci" // make string enumeration case-insensitive
 
 	xGoOperationTag = "x-go-operation-tag" // additional tag to override generation in operation groups
+	xGoPackage      = "x-go-package"       // package where an operation is generated, when grouped by extension
+	xGoModelPackage = "x-go-model-package" // sub-package of the models package where a definition is generated
 )
 
 type typeResolver struct {
@@ -64,6 +65,10 @@ type typeResolver struct {
 	definitionPkg      string // pkg alias to fill in GenSchema.Pkg
 	mangler            mangling.NameMangler
 	pkgMangler         func(string, string) string
+	logf               func(string, ...any)
+	warnf              func(string, ...any)
+	packages           *modelPackages // sub-packages of the models package, when models are split
+	currentPackage     string         // the sub-package of the models package being generated
 }
 
 func newTypeResolver(pkg string, doc *loads.Document, opts *GenOpts) *typeResolver {
@@ -73,6 +78,9 @@ func newTypeResolver(pkg string, doc *loads.Document, opts *GenOpts) *typeResolv
 		KnownDefs:     make(map[string]struct{}, len(doc.Spec().Definitions)),
 		mangler:       opts.LanguageOpts.Mangler,
 		pkgMangler:    opts.LanguageOpts.ManglePackageName,
+		logf:          opts.logf,
+		warnf:         opts.warnf,
+		packages:      opts.packages,
 	}
 
 	resolver.setDefs()
@@ -83,11 +91,15 @@ func newTypeResolver(pkg string, doc *loads.Document, opts *GenOpts) *typeResolv
 // NewWithModelName clones a type resolver and specifies a new model name.
 func (t *typeResolver) NewWithModelName(name string) *typeResolver {
 	tt := &typeResolver{
-		ModelsPackage: t.ModelsPackage,
-		Doc:           t.Doc,
-		KnownDefs:     make(map[string]struct{}, len(t.Doc.Spec().Definitions)),
-		mangler:       t.mangler,
-		pkgMangler:    t.pkgMangler,
+		ModelsPackage:  t.ModelsPackage,
+		Doc:            t.Doc,
+		KnownDefs:      make(map[string]struct{}, len(t.Doc.Spec().Definitions)),
+		mangler:        t.mangler,
+		pkgMangler:     t.pkgMangler,
+		logf:           t.logf,
+		warnf:          t.warnf,
+		packages:       t.packages,
+		currentPackage: t.currentPackage,
 	}
 
 	tt.setDefs()
@@ -159,7 +171,7 @@ func (t *typeResolver) ResolveSchema(schema *spec.Schema, isAnonymous, isRequire
 				} else {
 					result.ElemType.GoType = extType.Type
 				}
-				result.ElemType.setKind(extType.Hints.Kind)
+				result.ElemType.setKind(extType.Hints.Kind, t.warnf)
 				if result.IsInterface || result.IsStream {
 					result.ElemType.IsNullable = false
 				}
@@ -189,7 +201,7 @@ func (t *typeResolver) ResolveSchema(schema *spec.Schema, isAnonymous, isRequire
 	tpe := t.firstType(schema)
 	var returns bool
 
-	guardValidations(tpe, schema, schema.Type...)
+	guardValidations(t.warnf, tpe, schema, schema.Type...)
 
 	returns, result, err = t.resolveSchemaRef(schema, isRequired)
 
@@ -295,7 +307,7 @@ func (t *typeResolver) setDefs() {
 }
 
 func (t typeResolver) resolveExternalType(ext spec.Extensions) (*externalTypeDefinition, bool) {
-	extType, hasExt := hasExternalType(ext)
+	extType, hasExt := hasExternalType(ext, t.warnf)
 	if !hasExt {
 		return nil, false
 	}
@@ -479,7 +491,7 @@ func (t *typeResolver) resolveFormat(schema *spec.Schema, isAnonymous bool, isRe
 		}
 	}
 
-	guardFormatConflicts(schema.Format, schema)
+	guardFormatConflicts(t.warnf, schema.Format, schema)
 	return returns, result
 }
 
@@ -525,7 +537,7 @@ func (t *typeResolver) firstType(schema *spec.Schema) string {
 	if len(schema.Type) > 1 {
 		// JSON-Schema multiple types, e.g. {"type": [ "object", "array" ]} are not supported.
 		// TODO: should keep the first _supported_ type, e.g. skip null
-		log.Printf("warning: JSON-Schema type definition as array with several types is not supported in %#v. Taking the first type: %s", schema.Type, schema.Type[0])
+		t.warnf("JSON-Schema type definition as array with several types is not supported in %#v. Taking the first type: %s", schema.Type, schema.Type[0])
 	}
 	return schema.Type[0]
 }
@@ -604,7 +616,24 @@ func (t *typeResolver) resolveArray(schema *spec.Schema, isAnonymous, isRequired
 	return result, nil
 }
 
+// withModelSubPackage sets the sub-package of the models package where the current definition is generated.
+func (t *typeResolver) withModelSubPackage(pkg string) *typeResolver {
+	t.currentPackage = pkg
+	return t
+}
+
 func (t *typeResolver) goTypeName(nm string) string {
+	if pkg, ok := t.packages.forDefinition(nm); ok {
+		switch {
+		case t.ModelsPackage == "" && pkg != t.currentPackage:
+			// a model refers to a definition in another model package
+			return strings.Join([]string{t.packages.alias(pkg), t.mangler.ToGoName(nm)}, ".")
+		case t.ModelsPackage != "" && pkg != "":
+			// an operation refers to a definition in a sub-package of the models package
+			return strings.Join([]string{t.packages.alias(pkg), t.mangler.ToGoName(nm)}, ".")
+		}
+	}
+
 	if len(t.knownDefsKept) > 0 {
 		// if a definitions package has been defined, already resolved definitions are
 		// always resolved against their original package (e.g. "models"), and not the
@@ -811,7 +840,7 @@ func (t *typeResolver) shortCircuitResolveExternal(tpe, pkg, alias string, extTy
 	result.SkipExternalValidation = conv.Value(extType.Hints.NoValidation)
 	result.IsNullable = isRequired
 
-	result.setKind(extType.Hints.Kind)
+	result.setKind(extType.Hints.Kind, t.warnf)
 	if result.IsInterface || result.IsStream {
 		result.IsNullable = false
 	}
@@ -896,17 +925,17 @@ func hasEnumCI(ve spec.Extensions) bool {
 	return ok && isEnumCI
 }
 
-func warnSkipValidation(types any) func(string, any) {
+func warnSkipValidation(warnf func(string, ...any), types any) func(string, any) {
 	return func(validation string, value any) {
 		value = reflect.Indirect(reflect.ValueOf(value)).Interface()
-		log.Printf("warning: validation %s (value: %v) not compatible with type %v. Skipped", validation, value, types)
+		warnf("validation %s (value: %v) not compatible with type %v. Skipped", validation, value, types)
 	}
 }
 
 // guardValidations removes (with a warning) validations that don't fit with the schema type.
 //
 // Notice that the "enum" validation is allowed on any type but file.
-func guardValidations(tpe string, schema interface {
+func guardValidations(warnf func(string, ...any), tpe string, schema interface {
 	Validations() spec.SchemaValidations
 	SetValidations(validations spec.SchemaValidations)
 }, types ...string,
@@ -920,29 +949,29 @@ func guardValidations(tpe string, schema interface {
 	}()
 
 	if tpe != array {
-		v.ClearArrayValidations(warnSkipValidation(types))
+		v.ClearArrayValidations(warnSkipValidation(warnf, types))
 	}
 
 	if tpe != str && tpe != file {
-		v.ClearStringValidations(warnSkipValidation(types))
+		v.ClearStringValidations(warnSkipValidation(warnf, types))
 	}
 
 	if tpe != object {
-		v.ClearObjectValidations(warnSkipValidation(types))
+		v.ClearObjectValidations(warnSkipValidation(warnf, types))
 	}
 
 	if tpe != number && tpe != integer {
-		v.ClearNumberValidations(warnSkipValidation(types))
+		v.ClearNumberValidations(warnSkipValidation(warnf, types))
 	}
 
 	if tpe == file {
 		// keep MinLength/MaxLength on file
 		if v.Pattern != "" {
-			warnSkipValidation(types)("pattern", v.Pattern)
+			warnSkipValidation(warnf, types)("pattern", v.Pattern)
 			v.Pattern = ""
 		}
 		if v.HasEnum() {
-			warnSkipValidation(types)("enum", v.Enum)
+			warnSkipValidation(warnf, types)("enum", v.Enum)
 			v.Enum = nil
 		}
 	}
@@ -955,7 +984,7 @@ func guardValidations(tpe string, schema interface {
 //
 // At this moment, validation guards already handle all known conflicts, but for the
 // special case of binary (i.e. io.Reader).
-func guardFormatConflicts(format string, schema interface {
+func guardFormatConflicts(warnf func(string, ...any), format string, schema interface {
 	Validations() spec.SchemaValidations
 	SetValidations(validations spec.SchemaValidations)
 },
@@ -966,9 +995,9 @@ func guardFormatConflicts(format string, schema interface {
 	// for this format, no additional validations are supported
 	if format == "binary" {
 		// no validations supported on binary fields at this moment (io.Reader)
-		v.ClearStringValidations(warnSkipValidation(msg))
+		v.ClearStringValidations(warnSkipValidation(warnf, msg))
 		if v.HasEnum() {
-			warnSkipValidation(msg)
+			warnSkipValidation(warnf, msg)
 			v.Enum = nil
 		}
 		schema.SetValidations(v)
@@ -1032,12 +1061,12 @@ type resolvedType struct {
 	SkipExternalValidation bool
 }
 
-func simpleResolvedType(tn, fmt string, items *spec.Items, v *spec.CommonValidations) (result resolvedType) {
+func simpleResolvedType(tn, fmt string, items *spec.Items, v *spec.CommonValidations, warnf func(string, ...any)) (result resolvedType) {
 	result.SwaggerType = tn
 	result.SwaggerFormat = fmt
 
 	defer func() {
-		guardValidations(result.SwaggerType, v)
+		guardValidations(warnf, result.SwaggerType, v)
 	}()
 
 	if tn == file {
@@ -1050,7 +1079,7 @@ func simpleResolvedType(tn, fmt string, items *spec.Items, v *spec.CommonValidat
 
 	if fmt != "" {
 		defer func() {
-			guardFormatConflicts(result.SwaggerFormat, v)
+			guardFormatConflicts(warnf, result.SwaggerFormat, v)
 		}()
 
 		fmtn := strings.ReplaceAll(fmt, "-", "")
@@ -1085,7 +1114,7 @@ func simpleResolvedType(tn, fmt string, items *spec.Items, v *spec.CommonValidat
 			result.GoType = "[]" + iface
 			return result
 		}
-		res := simpleResolvedType(items.Type, items.Format, items.Items, &items.CommonValidations)
+		res := simpleResolvedType(items.Type, items.Format, items.Items, &items.CommonValidations, warnf)
 		result.GoType = "[]" + res.GoType
 		return result
 	}
@@ -1181,7 +1210,7 @@ func (rt *resolvedType) setIsJSONString(schema *spec.Schema, _ string) {
 	rt.IsJSONString = true
 }
 
-func (rt *resolvedType) setKind(kind string) {
+func (rt *resolvedType) setKind(kind string, warnf func(string, ...any)) {
 	if kind != "" {
 		debugLogf("overriding kind for %s as %s", rt.GoType, kind)
 	}
@@ -1251,7 +1280,7 @@ func (rt *resolvedType) setKind(kind string) {
 	case "":
 		break
 	default:
-		log.Printf("warning: unsupported hint value for external type: %q. Skipped", kind)
+		warnf("unsupported hint value for external type: %q. Skipped", kind)
 	}
 }
 
@@ -1279,7 +1308,7 @@ type externalTypeDefinition struct {
 	Embedded bool
 }
 
-func hasExternalType(ext spec.Extensions) (*externalTypeDefinition, bool) {
+func hasExternalType(ext spec.Extensions, warnf func(string, ...any)) (*externalTypeDefinition, bool) {
 	v, ok := ext[xGoType]
 	if !ok {
 		return nil, false
@@ -1288,7 +1317,7 @@ func hasExternalType(ext spec.Extensions) (*externalTypeDefinition, bool) {
 	var extType externalTypeDefinition
 	err := mapstructure.Decode(v, &extType)
 	if err != nil {
-		log.Printf("warning: x-go-type extension could not be decoded (%v). Skipped", v)
+		warnf("x-go-type extension could not be decoded (%v). Skipped", v)
 		return nil, false
 	}
 
@@ -353,7 +353,7 @@ func TestShortCircuitResolveExternal(t *testing.T) {
 			err = json.Unmarshal([]byte(jazonDoc), &schema)
 			require.NoErrorf(t, err, "fixture %d", i)
 
-			extType, ok := hasExternalType(schema.Extensions)
+			extType, ok := hasExternalType(schema.Extensions, t.Logf)
 			require.TrueTf(t, ok, "fixture %d", i)
 			require.NotNil(t, extType)
 
@@ -484,7 +484,7 @@ func TestGuardValidations(t *testing.T) {
 		t.Run(testCase.Title, func(t *testing.T) {
 			t.Parallel()
 			input := testCase.Type
-			guardValidations(testCase.ResolvedType, input)
+			guardValidations(t.Logf, testCase.ResolvedType, input)
 			if testCase.Asserter != nil {
 				testCase.Asserter(t, input.Validations())
 			}
@@ -537,7 +537,7 @@ func TestGuardFormatConflicts(t *testing.T) {
 		t.Run(testCase.Title, func(t *testing.T) {
 			t.Parallel()
 			input := testCase.Type
-			guardFormatConflicts(testCase.ResolvedType, input)
+			guardFormatConflicts(t.Logf, testCase.ResolvedType, input)
 			if testCase.Asserter != nil {
 				testCase.Asserter(t, input.Validations())
 			}
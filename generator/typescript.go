@@ -0,0 +1,536 @@
+// SPDX-FileCopyrightText: Copyright 2015-2025 go-swagger maintainers
+// SPDX-License-Identifier: Apache-2.0
+
+package generator
+
+import (
+	"encoding/json"
+	"fmt"
+	"go/token"
+	"os"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/go-swagger/go-swagger/generator/internal/language"
+)
+
+// typeScriptModels is the TypeScript module of the models, imported by the modules of operations.
+const typeScriptModels = "models"
+
+// typeScriptReservedWords are the reserved words of TypeScript, which cannot name variables.
+var typeScriptReservedWords = []string{
+	"break", "case", "catch", "class", "const", "continue", "debugger", "default", "delete", "do",
+	"else", "enum", "export", "extends", "false", "finally", "for", "function", "if", "import",
+	"in", "instanceof", "new", "null", "return", "super", "switch", "this", "throw", "true",
+	"try", "typeof", "var", "void", "while", "with", "implements", "interface", "let", "package",
+	"private", "protected", "public", "static", "yield", "await",
+}
+
+// TypeScriptOpts for rendering a spec as TypeScript.
+func TypeScriptOpts() *language.Options {
+	opts := &language.Options{
+		ReservedWords: typeScriptReservedWords,
+	}
+	opts.Init()
+
+	return opts
+}
+
+// TypeScriptSectionOpts for a given opts: the models, a fetch-based client per operation group,
+// and the runtime shared by the clients.
+func TypeScriptSectionOpts(gen *GenOpts) {
+	gen.Sections.Models = nil
+	gen.Sections.PostModels = nil
+	gen.Sections.Operations = nil
+	gen.Sections.SecuritySchemes = nil
+	gen.Sections.Tags = nil
+	gen.Sections.Responses = nil
+	gen.Sections.Parameters = nil
+	gen.LanguageOpts = TypeScriptOpts()
+	gen.Sections.Application = []TemplateOpts{
+		{
+			Name:     "models",
+			Source:   "asset:typescriptModels",
+			Target:   "{{ .Target }}",
+			FileName: typeScriptModels + ".ts",
+		},
+		{
+			Name:     "runtime",
+			Source:   "asset:typescriptRuntime",
+			Target:   "{{ .Target }}",
+			FileName: "runtime.ts",
+		},
+		{
+			Name:     "index",
+			Source:   "asset:typescriptIndex",
+			Target:   "{{ .Target }}",
+			FileName: "index.ts",
+		},
+	}
+	gen.Sections.OperationGroups = []TemplateOpts{
+		{
+			Name:     "operations",
+			Source:   "asset:typescriptOperations",
+			Target:   "{{ joinFilePath .Target \"operations\" }}",
+			FileName: "{{ snakize .Name }}.ts",
+		},
+	}
+}
+
+// GenerateTypeScript generates TypeScript interfaces for the definitions of a swagger specification,
+// and a fetch-based client for its operations.
+func GenerateTypeScript(name string, modelNames, operationIDs []string, opts *GenOpts) error {
+	defer opts.beginGeneration()()
+
+	if opts.LanguageOpts == nil {
+		// names are mangled by the functions of templates with the rules of TypeScript
+		opts.LanguageOpts = TypeScriptOpts()
+	}
+
+	if err := opts.EnsureDefaults(); err != nil {
+		return err
+	}
+	// operation groups are rendered as clients
+	opts.IsClient = true
+	opts.IncludeHandler = true
+	TypeScriptSectionOpts(opts)
+
+	generator, err := newAppGenerator(name, modelNames, operationIDs, opts)
+	if err != nil {
+		return err
+	}
+
+	return generator.GenerateTypeScript()
+}
+
+func (a *appGenerator) GenerateTypeScript() error {
+	app, err := a.makeCodegenApp()
+	if err != nil {
+		return err
+	}
+
+	if a.DumpData {
+		return a.GenOpts.dumpData(os.Stdout, app)
+	}
+
+	start := time.Now()
+	a.GenOpts.openCache()
+	defer a.GenOpts.closeCache()
+
+	jobs := make([]renderJob, 0, len(app.OperationGroups))
+	for _, g := range app.OperationGroups {
+		opg := g
+		jobs = append(jobs, func() error {
+			return a.GenOpts.renderOperationGroup(&opg)
+		})
+	}
+
+	if err := a.GenOpts.renderConcurrently(jobs); err != nil {
+		return err
+	}
+
+	if err := a.GenOpts.renderApplication(&app); err != nil {
+		return err
+	}
+
+	if err := a.GenOpts.saveCache(); err != nil {
+		return err
+	}
+	a.GenOpts.emitSince(start, Event{Kind: EventGenerationDone})
+
+	return nil
+}
+
+// tsSchema gets the schema of the data passed to a TypeScript function of templates.
+func tsSchema(in any) (*GenSchema, error) {
+	switch schema := in.(type) {
+	case GenSchema:
+		return &schema, nil
+	case *GenSchema:
+		return schema, nil
+	case GenDefinition:
+		return &schema.GenSchema, nil
+	case *GenDefinition:
+		if schema == nil {
+			return nil, nil
+		}
+
+		return &schema.GenSchema, nil
+	default:
+		return nil, fmt.Errorf("expected a GenSchema or a GenDefinition, but got: %T", in)
+	}
+}
+
+// tsType renders the TypeScript type of a schema, a parameter or some items.
+//
+// Named types are referred to by name: the models are qualified by the namespace of the models module
+// when they are referred to from another module.
+func tsType(in any) (string, error) {
+	switch data := in.(type) {
+	case GenParameter:
+		return tsParamType(&data), nil
+	case *GenParameter:
+		return tsParamType(data), nil
+	case GenItems:
+		return tsItemsType(&data), nil
+	case *GenItems:
+		return tsItemsType(data), nil
+	}
+
+	schema, err := tsSchema(in)
+	if err != nil {
+		return "", err
+	}
+
+	return tsSchemaType(schema, true), nil
+}
+
+// tsAliasedType renders the TypeScript type defined by a named schema, which is not an interface.
+func tsAliasedType(in any) (string, error) {
+	schema, err := tsSchema(in)
+	if err != nil {
+		return "", err
+	}
+
+	return tsSchemaType(schema, false), nil
+}
+
+// tsIsInterface tells if a named schema is defined as a TypeScript interface, rather than a type alias.
+func tsIsInterface(in any) (bool, error) {
+	schema, err := tsSchema(in)
+	if err != nil || schema == nil {
+		return false, err
+	}
+
+	if schema.IsArray || schema.IsTuple {
+		return false, nil
+	}
+
+	return len(schema.Properties) > 0 || len(schema.AllOf) > 0 || schema.IsSubType || schema.IsComplexObject && !schema.IsMap, nil
+}
+
+// tsExtends renders the interfaces extended by the interface of a named schema, from the named members of its allOf.
+//
+// A polymorphic base type is a union of its subtypes: its subtypes extend the interface of its common properties.
+func tsExtends(in any) (string, error) {
+	schema, err := tsSchema(in)
+	if err != nil || schema == nil {
+		return "", err
+	}
+
+	extends := make([]string, 0, len(schema.AllOf))
+	for i := range schema.AllOf {
+		member := &schema.AllOf[i]
+		if !tsIsNamed(member) {
+			continue
+		}
+
+		name := tsTypeName(member.GoType)
+		if member.IsBaseType {
+			name += "Base"
+		}
+		extends = append(extends, name)
+	}
+
+	return strings.Join(extends, ", "), nil
+}
+
+// tsReturnType renders the type of the result of an operation, from its successful responses.
+//
+// The result of a response without a body is undefined.
+func tsReturnType(op GenOperation) string {
+	types := make([]string, 0, len(op.SuccessResponses))
+	var empty bool
+	for _, response := range op.SuccessResponses {
+		if response.Schema == nil {
+			empty = true
+
+			continue
+		}
+
+		if t := tsSchemaType(response.Schema, true); !slices.Contains(types, t) {
+			types = append(types, t)
+		}
+	}
+
+	if len(types) == 0 {
+		return "void"
+	}
+
+	if empty {
+		types = append(types, "undefined")
+	}
+
+	return strings.Join(types, " | ")
+}
+
+// tsImportsModels tells if the module of an operation group refers to models.
+func tsImportsModels(group GenOperationGroup) bool {
+	for _, op := range group.Operations {
+		for _, param := range op.Params {
+			if tsRefersToModels(param.Schema) {
+				return true
+			}
+		}
+
+		for _, response := range op.SuccessResponses {
+			if tsRefersToModels(response.Schema) {
+				return true
+			}
+		}
+
+		for i := range op.ExtraSchemas {
+			if tsMembersReferToModels(&op.ExtraSchemas[i]) {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+func tsRefersToModels(schema *GenSchema) bool {
+	if schema == nil {
+		return false
+	}
+
+	if tsIsNamed(schema) {
+		return strings.Contains(schema.GoType, ".")
+	}
+
+	return tsMembersReferToModels(schema)
+}
+
+// tsMembersReferToModels tells if the type defined by a schema refers to models.
+func tsMembersReferToModels(schema *GenSchema) bool {
+	for i := range schema.Properties {
+		if tsRefersToModels(&schema.Properties[i]) {
+			return true
+		}
+	}
+
+	for i := range schema.AllOf {
+		if tsRefersToModels(&schema.AllOf[i]) {
+			return true
+		}
+	}
+
+	return tsRefersToModels(schema.Items) || tsRefersToModels(schema.AdditionalProperties) || tsRefersToModels(schema.AdditionalItems)
+}
+
+// tsKey renders the key of a property, quoted when it is not an identifier.
+func tsKey(name string) string {
+	if token.IsIdentifier(name) {
+		return name
+	}
+
+	return tsLiteral(name)
+}
+
+// tsComment renders a documentation comment. The lines after the first one are indented.
+func tsComment(text, indent string) string {
+	text = strings.TrimSpace(strings.ReplaceAll(text, "*/", "*\\/"))
+	if !strings.Contains(text, "\n") {
+		return "/** " + text + " */"
+	}
+
+	lines := strings.Split(text, "\n")
+	var b strings.Builder
+	b.WriteString("/**")
+	for _, line := range lines {
+		b.WriteString("\n" + indent + " *")
+		if line = strings.TrimRight(line, " \t"); line != "" {
+			b.WriteString(" " + line)
+		}
+	}
+	b.WriteString("\n" + indent + " */")
+
+	return b.String()
+}
+
+func tsLiteral(value any) string {
+	b, err := json.Marshal(value)
+	if err != nil {
+		return fmt.Sprintf("%q", fmt.Sprint(value))
+	}
+
+	return string(b)
+}
+
+// tsIsNamed tells if a schema refers to a named type: a model, or a schema defined alongside a model or an operation.
+func tsIsNamed(schema *GenSchema) bool {
+	return schema.GoType != "" && !schema.IsAnonymous && !schema.IsInterface && !schema.IsExternal &&
+		(schema.IsComplexObject || schema.IsAliased)
+}
+
+// tsTypeName is the TypeScript name of a named type: models from another package are qualified by the models module.
+func tsTypeName(goType string) string {
+	if idx := strings.LastIndexByte(goType, '.'); idx >= 0 {
+		return typeScriptModels + "." + goType[idx+1:]
+	}
+
+	return goType
+}
+
+func tsSchemaType(schema *GenSchema, ref bool) string {
+	if schema == nil {
+		return "unknown"
+	}
+
+	t := tsSchemaValueType(schema, ref)
+	if nullable := nullableExtension(schema.Extensions); nullable != nil && *nullable {
+		return t + " | null"
+	}
+
+	return t
+}
+
+func tsSchemaValueType(schema *GenSchema, ref bool) string {
+	switch {
+	case ref && tsIsNamed(schema):
+		return tsTypeName(schema.GoType)
+	case len(schema.Enum) > 0:
+		return tsEnum(schema.Enum)
+	case schema.IsStream:
+		return "Blob"
+	case schema.IsTuple:
+		elems := make([]string, 0, len(schema.Properties)+1)
+		for i := range schema.Properties {
+			elems = append(elems, tsSchemaType(&schema.Properties[i], true))
+		}
+		if schema.HasAdditionalItems && schema.AdditionalItems != nil {
+			elems = append(elems, "..."+tsArray(tsSchemaType(schema.AdditionalItems, true)))
+		}
+
+		return "[" + strings.Join(elems, ", ") + "]"
+	case schema.IsArray:
+		return tsArray(tsSchemaType(schema.Items, true))
+	case schema.IsInterface:
+		return "unknown"
+	case schema.IsMap && !schema.IsComplexObject:
+		if schema.AdditionalProperties == nil {
+			return "Record<string, unknown>"
+		}
+
+		return "Record<string, " + tsSchemaType(schema.AdditionalProperties, true) + ">"
+	case schema.IsComplexObject || len(schema.Properties) > 0 || len(schema.AllOf) > 0:
+		return tsObject(schema)
+	default:
+		return tsPrimitive(schema.SwaggerType, schema.SwaggerFormat)
+	}
+}
+
+// tsObject renders an anonymous object type.
+func tsObject(schema *GenSchema) string {
+	var members []string
+	for i := range schema.AllOf {
+		member := &schema.AllOf[i]
+		if tsIsNamed(member) {
+			members = append(members, tsTypeName(member.GoType))
+
+			continue
+		}
+		members = append(members, tsObject(member))
+	}
+
+	if len(schema.Properties) > 0 || schema.HasAdditionalProperties || len(members) == 0 {
+		fields := make([]string, 0, len(schema.Properties)+1)
+		for i := range schema.Properties {
+			property := &schema.Properties[i]
+			field := tsKey(property.Name)
+			if !property.Required {
+				field += "?"
+			}
+			fields = append(fields, field+": "+tsSchemaType(property, true))
+		}
+
+		if schema.HasAdditionalProperties && schema.AdditionalProperties != nil {
+			fields = append(fields, "[key: string]: "+tsIndexType(schema))
+		}
+
+		if len(fields) == 0 {
+			members = append(members, "Record<string, unknown>")
+		} else {
+			members = append(members, "{ "+strings.Join(fields, "; ")+" }")
+		}
+	}
+
+	return strings.Join(members, " & ")
+}
+
+// tsIndexType renders the type of the additional properties of an object, which must accept its properties too.
+func tsIndexType(schema *GenSchema) string {
+	if len(schema.Properties) > 0 {
+		return "unknown"
+	}
+
+	return tsSchemaType(schema.AdditionalProperties, true)
+}
+
+func tsParamType(param *GenParameter) string {
+	if param.Schema != nil {
+		return tsSchemaType(param.Schema, true)
+	}
+
+	if len(param.Enum) > 0 {
+		return tsEnum(param.Enum)
+	}
+
+	if param.IsArray {
+		return tsArray(tsItemsType(param.Child))
+	}
+
+	return tsPrimitive(param.SwaggerType, param.SwaggerFormat)
+}
+
+func tsItemsType(items *GenItems) string {
+	switch {
+	case items == nil:
+		return "unknown"
+	case len(items.Enum) > 0:
+		return tsEnum(items.Enum)
+	case items.IsArray:
+		return tsArray(tsItemsType(items.Child))
+	default:
+		return tsPrimitive(items.SwaggerType, items.SwaggerFormat)
+	}
+}
+
+func tsPrimitive(swaggerType, swaggerFormat string) string {
+	switch swaggerType {
+	case str:
+		if swaggerFormat == binary {
+			return "Blob"
+		}
+
+		return "string"
+	case integer, number:
+		return "number"
+	case boolean:
+		return "boolean"
+	case file:
+		return "Blob"
+	case object:
+		return "Record<string, unknown>"
+	default:
+		return "unknown"
+	}
+}
+
+func tsEnum(values []any) string {
+	literals := make([]string, 0, len(values))
+	for _, value := range values {
+		literals = append(literals, tsLiteral(value))
+	}
+
+	return strings.Join(literals, " | ")
+}
+
+func tsArray(elem string) string {
+	if strings.ContainsAny(elem, " <&|") {
+		return "Array<" + elem + ">"
+	}
+
+	return elem + "[]"
+}
@@ -0,0 +1,120 @@
+// SPDX-FileCopyrightText: Copyright 2015-2025 go-swagger maintainers
+// SPDX-License-Identifier: Apache-2.0
+
+package generator
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/go-openapi/testify/v2/assert"
+	"github.com/go-openapi/testify/v2/require"
+)
+
+func TestGenerateTypeScript(t *testing.T) {
+	defer discardOutput()()
+
+	opts := testGenOpts()
+	opts.LanguageOpts = nil
+	opts.Spec = "../fixtures/codegen/typescript.yml"
+	opts.Target = t.TempDir()
+	require.NoError(t, GenerateTypeScript("", nil, nil, opts))
+
+	read := func(t *testing.T, name string) string {
+		t.Helper()
+
+		content, err := os.ReadFile(filepath.Join(opts.Target, filepath.FromSlash(name)))
+		require.NoError(t, err)
+
+		return string(content)
+	}
+
+	t.Run("should generate interfaces for definitions", func(t *testing.T) {
+		code := read(t, "models.ts")
+
+		for _, line := range []string{
+			"// Code generated by go-swagger; DO NOT EDIT.",
+			"export type Name = string;",
+			`export type Color = "red" | "green";`,
+			"export type Labels = Record<string, string>;",
+			"export interface Error {\n  code?: number;\n  message?: string;\n}",
+			"/**\n * A pet of the store.\n * Pets are either cats or dogs.\n */\nexport interface PetBase {",
+			"  color?: Color;",
+			"  extra?: unknown;",
+			"  labels?: Labels;",
+			"  name: Name;",
+			"  nickname?: string | null;",
+			"  owner?: PetOwner;",
+			"  petType: string;",
+			`  readonly "x-tag"?: string;`,
+			"export interface PetOwner {\n  name?: string;\n}",
+		} {
+			assert.StringContainsT(t, code, line)
+		}
+	})
+
+	t.Run("should generate discriminated unions for polymorphic types", func(t *testing.T) {
+		code := read(t, "models.ts")
+
+		assertInCode(t, "export type Pet =\n  | Cat\n  | Dog\n  | (PetBase & { petType: \"Pet\" });", code)
+		assertInCode(t, "export interface Cat extends PetBase {\n  huntingSkill?: string;\n  petType: \"Cat\";\n}", code)
+		assertInCode(t, "export interface Dog extends PetBase {\n  packSize: number;\n  petType: \"Dog\";\n}", code)
+	})
+
+	t.Run("should generate a client per operation group", func(t *testing.T) {
+		code := read(t, "operations/pets.ts")
+
+		for _, line := range []string{
+			`import type * as models from "../models";`,
+			`import { type ClientOptions, send } from "../runtime";`,
+			"export interface GetPetParams {\n  xRequestID?: string;\n  id: number;\n  tags?: string[];\n}",
+			"export interface UploadPhotoCreatedBody {\n  url?: string;\n}",
+			`  mode?: "fast" | "slow";`,
+			"  photo: Blob;",
+			"export class PetsClient {",
+			"  /** gets a pet */\n  async getPet(params: GetPetParams, init?: RequestInit): Promise<models.Pet> {",
+			`        queryParams: [{ name: "tags", value: params.tags, collectionFormat: "pipes" }],`,
+			`        headerParams: [{ name: "X-Request-Id", value: params.xRequestID }],`,
+			"  async replacePet(params: ReplacePetParams, init?: RequestInit): Promise<models.Pet | undefined> {",
+			"        body: params.pet,",
+			`        consumes: "application/json",`,
+			`        formParams: [{ name: "mode", value: params.mode }, { name: "photo", value: params.photo }],`,
+			`        consumes: "multipart/form-data",`,
+		} {
+			assert.StringContainsT(t, code, line)
+		}
+
+		monitoring := read(t, "operations/monitoring.ts")
+		assert.StringNotContainsT(t, monitoring, "../models")
+		assert.StringContainsT(t, monitoring, "async health(init?: RequestInit): Promise<void> {")
+		assert.StringContainsT(t, monitoring, `produces: ["text/plain"],`)
+	})
+
+	t.Run("should generate the runtime and the facade of the clients", func(t *testing.T) {
+		runtime := read(t, "runtime.ts")
+		assert.StringContainsT(t, runtime, `export const defaultBaseUrl = "https://api.example.com/v1";`)
+		assert.StringContainsT(t, runtime, "export async function send<T>(")
+
+		index := read(t, "index.ts")
+		for _, line := range []string{
+			`export * as models from "./models";`,
+			`export * from "./operations/pets";`,
+			"export class PetStoreClient {",
+			"  readonly monitoring: MonitoringClient;",
+			"    this.pets = new PetsClient(options);",
+		} {
+			assert.StringContainsT(t, index, line)
+		}
+	})
+}
+
+func TestTSComment(t *testing.T) {
+	assert.EqualT(t, "/** a comment */", tsComment(" a comment\n", "  "))
+	assert.EqualT(t, "/**\n   * a\n   *\n   * b *\\/\n   */", tsComment("a\n\nb */", "  "))
+}
+
+func TestTSKey(t *testing.T) {
+	assert.EqualT(t, "name", tsKey("name"))
+	assert.EqualT(t, `"x-name"`, tsKey("x-name"))
+}
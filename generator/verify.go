@@ -0,0 +1,168 @@
+// SPDX-FileCopyrightText: Copyright 2015-2025 go-swagger maintainers
+// SPDX-License-Identifier: Apache-2.0
+
+package generator
+
+import (
+	"fmt"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/go-openapi/jsonpointer"
+	"golang.org/x/tools/go/packages"
+)
+
+// verifyMode is what is loaded to type-check the generated packages.
+const verifyMode = packages.NeedName | packages.NeedFiles | packages.NeedImports | packages.NeedTypes | packages.NeedSyntax
+
+// generatedFiles are the files produced by a generation, by absolute path, with the event which reported them.
+type generatedFiles map[string]Event
+
+func (f generatedFiles) collect(e Event) {
+	if e.Path == "" || (e.Kind != EventFileWritten && e.Kind != EventFileSkipped) || e.Reason == SkipReasonCondition {
+		return
+	}
+
+	if abs, err := filepath.Abs(e.Path); err == nil {
+		f[abs] = e
+	}
+}
+
+// packageDirs lists the directories of the generated go files.
+func (f generatedFiles) packageDirs() []string {
+	var dirs []string
+	for file := range f {
+		if filepath.Ext(file) != ".go" || strings.HasSuffix(file, "_test.go") {
+			continue
+		}
+
+		if dir := filepath.Dir(file); !slices.Contains(dirs, dir) {
+			dirs = append(dirs, dir)
+		}
+	}
+	slices.Sort(dirs)
+
+	return dirs
+}
+
+// origin tells which template and which element of the spec produced the code at some position, e.g. "/tmp/models/pet.go:12:3".
+func (f generatedFiles) origin(pos string) (Event, bool) {
+	for file, e := range f {
+		if e.Template != "" && strings.HasPrefix(pos, file+":") {
+			return e, true
+		}
+	}
+
+	return Event{}, false
+}
+
+// verify type-checks the packages of the generated go files.
+//
+// Type errors are reported with the template and the element of the spec which produced the offending code.
+func (g *GenOpts) verify(target string, files generatedFiles) error {
+	dirs := files.packageDirs()
+	if len(dirs) == 0 {
+		return nil
+	}
+
+	g.logf("verifying %d generated packages", len(dirs))
+	start := time.Now()
+
+	cfg := &packages.Config{
+		Mode:    verifyMode,
+		Context: g.context(),
+		Dir:     target,
+	}
+	pkgs, err := packages.Load(cfg, dirs...)
+	if err != nil {
+		return fmt.Errorf("could not load the generated packages: %w", err)
+	}
+
+	var problems []string
+	for _, pkg := range pkgs {
+		for _, pkgErr := range packageErrors(pkg) {
+			problems = append(problems, files.describe(target, pkgErr))
+		}
+	}
+
+	if len(problems) > 0 {
+		return fmt.Errorf("the generated code does not type-check, with %d errors:\n%s", len(problems), strings.Join(problems, "\n"))
+	}
+
+	g.emitSince(start, Event{Kind: EventCodeVerified, Total: len(pkgs)})
+
+	return nil
+}
+
+// packageErrors are the errors found in a package: the go command reports again the errors found
+// when type-checking, so its errors are only retained when the package could not be type-checked.
+func packageErrors(pkg *packages.Package) []packages.Error {
+	var listErrs, checkErrs []packages.Error
+	for _, pkgErr := range pkg.Errors {
+		if pkgErr.Kind == packages.ListError {
+			listErrs = append(listErrs, pkgErr)
+
+			continue
+		}
+
+		checkErrs = append(checkErrs, pkgErr)
+	}
+
+	if len(checkErrs) > 0 {
+		return checkErrs
+	}
+
+	return listErrs
+}
+
+// describe reports an error found in the generated code, with its origin.
+func (f generatedFiles) describe(target string, pkgErr packages.Error) string {
+	pos := pkgErr.Pos
+	if pos == "" || pos == "-" {
+		return pkgErr.Msg
+	}
+
+	e, found := f.origin(pos)
+	if rel, err := filepath.Rel(target, pos); err == nil && !strings.HasPrefix(rel, "..") {
+		pos = filepath.ToSlash(rel)
+	}
+
+	if !found {
+		return pos + ": " + pkgErr.Msg
+	}
+
+	origin := "template " + e.Template
+	if e.Element != "" {
+		origin += " for " + e.Element
+	}
+
+	if e.Explain != nil && e.Explain.Resolved.File != "" {
+		origin += ", from " + e.Explain.Resolved.File
+	}
+
+	return fmt.Sprintf("%s: %s (generated by %s)", pos, pkgErr.Msg, origin)
+}
+
+// specElement tells which element of the spec some template data is built from, e.g. "#/definitions/Pet".
+func specElement(data any) string {
+	switch d := data.(type) {
+	case *GenApp:
+		return "#"
+	case *GenDefinition:
+		return "#/definitions/" + jsonpointer.Escape(d.Name)
+	case GenDefinition:
+		return "#/definitions/" + jsonpointer.Escape(d.Name)
+	case *GenOperation:
+		return "#/paths/" + jsonpointer.Escape(d.Path) + "/" + strings.ToLower(d.Method)
+	case *GenOperationGroup:
+		return "operation group " + d.Name
+	case *GenSecurityScheme:
+		return "#/securityDefinitions/" + jsonpointer.Escape(d.ID)
+	case *GenTag:
+		return "tag " + d.Name
+	default:
+		return ""
+	}
+}
@@ -0,0 +1,98 @@
+// SPDX-FileCopyrightText: Copyright 2015-2025 go-swagger maintainers
+// SPDX-License-Identifier: Apache-2.0
+
+package generator
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/go-openapi/testify/v2/assert"
+	"github.com/go-openapi/testify/v2/require"
+)
+
+func TestRunWithHooks_Verify(t *testing.T) {
+	defer discardOutput()()
+
+	fakeGeneration := func(content string) func(*GenOpts) error {
+		return func(opts *GenOpts) error {
+			file := filepath.Join(opts.Target, "models", "pet.go")
+			if err := os.MkdirAll(filepath.Dir(file), readAllDir); err != nil {
+				return err
+			}
+			if err := os.WriteFile(file, []byte(content), readableFile); err != nil {
+				return err
+			}
+
+			opts.emit(Event{Kind: EventFileWritten, Path: file, Template: "definition", Element: "#/definitions/Pet"})
+			opts.emit(Event{Kind: EventFileSkipped, Path: filepath.Join(opts.Target, "README.md"), Reason: SkipReasonExists})
+
+			return nil
+		}
+	}
+
+	verifyOpts := func(t *testing.T) *GenOpts {
+		opts := testGenOpts()
+		opts.Target = t.TempDir()
+		opts.Verify = true
+		require.NoError(t, os.WriteFile(filepath.Join(opts.Target, "go.mod"), []byte("module verify\n"), readableFile))
+
+		return opts
+	}
+
+	t.Run("should verify the generated packages", func(t *testing.T) {
+		opts := verifyOpts(t)
+		var verified []Event
+		opts.Events = func(e Event) {
+			if e.Kind == EventCodeVerified {
+				verified = append(verified, e)
+			}
+		}
+
+		require.NoError(t, RunWithHooks(opts, fakeGeneration("package models\n\ntype Pet struct{}\n")))
+		require.Len(t, verified, 1)
+		assert.EqualT(t, 1, verified[0].Total)
+	})
+
+	t.Run("should report type errors with the template and the spec element", func(t *testing.T) {
+		opts := verifyOpts(t)
+
+		err := RunWithHooks(opts, fakeGeneration("package models\n\nfunc Validate() error {\n\treturn missing\n}\n"))
+		require.Error(t, err)
+		assert.StringContainsT(t, err.Error(), "the generated code does not type-check, with 1 errors:\n")
+		assert.StringContainsT(t, err.Error(), "models/pet.go:4:9: undefined: missing (generated by template definition for #/definitions/Pet)")
+	})
+
+	t.Run("should report type errors in files up to date with their template", func(t *testing.T) {
+		opts := verifyOpts(t)
+		file := filepath.Join(opts.Target, "models", "pet.go")
+		require.NoError(t, os.MkdirAll(filepath.Dir(file), readAllDir))
+		require.NoError(t, os.WriteFile(file, []byte("package models\n\nvar _ = missing\n"), readableFile))
+
+		err := RunWithHooks(opts, func(opts *GenOpts) error {
+			opts.emit(Event{Kind: EventFileSkipped, Path: file, Template: "definition", Element: "#/definitions/Pet", Reason: SkipReasonUpToDate})
+
+			return nil
+		})
+		require.Error(t, err)
+		assert.StringContainsT(t, err.Error(), "models/pet.go:3:9: undefined: missing (generated by template definition for #/definitions/Pet)")
+	})
+
+	t.Run("should not verify without the option", func(t *testing.T) {
+		opts := verifyOpts(t)
+		opts.Verify = false
+
+		require.NoError(t, RunWithHooks(opts, fakeGeneration("package models\n\nvar _ = missing\n")))
+	})
+}
+
+func TestSpecElement(t *testing.T) {
+	assert.EqualT(t, "#", specElement(&GenApp{}))
+	assert.EqualT(t, "#/definitions/Pet", specElement(&GenDefinition{GenSchema: GenSchema{Name: "Pet"}}))
+	assert.EqualT(t, "#/definitions/a~1b", specElement(GenDefinition{GenSchema: GenSchema{Name: "a/b"}}))
+	assert.EqualT(t, "#/paths/~1pets~1{id}/get", specElement(&GenOperation{Path: "/pets/{id}", Method: "GET"}))
+	assert.EqualT(t, "operation group pets", specElement(&GenOperationGroup{Name: "pets"}))
+	assert.EqualT(t, "#/securityDefinitions/api_key", specElement(&GenSecurityScheme{ID: "api_key"}))
+	assert.EqualT(t, "", specElement(&GenResponse{}))
+}
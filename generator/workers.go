@@ -0,0 +1,75 @@
+// SPDX-FileCopyrightText: Copyright 2015-2025 go-swagger maintainers
+// SPDX-License-Identifier: Apache-2.0
+
+package generator
+
+import (
+	"errors"
+	"runtime"
+	"sync"
+)
+
+// renderJob renders and formats one item (model, operation, operation group).
+type renderJob func() error
+
+// concurrency returns the maximum number of rendering jobs run in parallel.
+func (g *GenOpts) concurrency() int {
+	if g.Concurrency > 0 {
+		return g.Concurrency
+	}
+
+	return runtime.GOMAXPROCS(0)
+}
+
+// renderConcurrently runs rendering jobs in a bounded pool of workers.
+//
+// Every job is run, even when some fail. Errors are reported in the order of the jobs,
+// regardless of the order in which they complete, so the outcome is deterministic.
+//
+// Generated files do not depend on the order of execution: each job writes its own files.
+//
+// When the generation is cancelled, pending jobs are abandoned and the cancellation error is returned.
+func (g *GenOpts) renderConcurrently(jobs []renderJob) error {
+	g.emit(Event{Kind: EventItemsPlanned, Total: len(jobs)})
+
+	ctx := g.context()
+	workers := min(g.concurrency(), len(jobs))
+	if workers <= 1 {
+		errs := make([]error, 0, len(jobs))
+		for _, job := range jobs {
+			if err := ctx.Err(); err != nil {
+				return err
+			}
+			errs = append(errs, job())
+		}
+
+		return errors.Join(errs...)
+	}
+
+	errs := make([]error, len(jobs))
+	indices := make(chan int)
+
+	var wg sync.WaitGroup
+	for range workers {
+		wg.Go(func() {
+			for i := range indices {
+				if ctx.Err() != nil {
+					continue
+				}
+				errs[i] = jobs[i]()
+			}
+		})
+	}
+
+	for i := range jobs {
+		indices <- i
+	}
+	close(indices)
+	wg.Wait()
+
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	return errors.Join(errs...)
+}
@@ -0,0 +1,118 @@
+// SPDX-FileCopyrightText: Copyright 2015-2025 go-swagger maintainers
+// SPDX-License-Identifier: Apache-2.0
+
+package generator
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sync/atomic"
+	"testing"
+
+	"github.com/go-openapi/testify/v2/assert"
+	"github.com/go-openapi/testify/v2/require"
+)
+
+func TestRenderConcurrently(t *testing.T) {
+	makeJobs := func(n int, failing ...int) ([]renderJob, *atomic.Int32) {
+		var count atomic.Int32
+		jobs := make([]renderJob, 0, n)
+		for i := range n {
+			jobs = append(jobs, func() error {
+				count.Add(1)
+				for _, f := range failing {
+					if f == i {
+						return fmt.Errorf("job %d failed", i)
+					}
+				}
+
+				return nil
+			})
+		}
+
+		return jobs, &count
+	}
+
+	for _, concurrency := range []int{1, 4} {
+		t.Run(fmt.Sprintf("with %d workers", concurrency), func(t *testing.T) {
+			opts := &GenOpts{}
+			opts.Concurrency = concurrency
+
+			t.Run("should run all jobs", func(t *testing.T) {
+				jobs, count := makeJobs(50)
+				require.NoError(t, opts.renderConcurrently(jobs))
+				assert.EqualT(t, int32(50), count.Load())
+			})
+
+			t.Run("should report errors in the order of jobs", func(t *testing.T) {
+				jobs, count := makeJobs(50, 42, 3, 17)
+				err := opts.renderConcurrently(jobs)
+				require.Error(t, err)
+				assert.EqualT(t, "job 3 failed\njob 17 failed\njob 42 failed", err.Error())
+				assert.EqualT(t, int32(50), count.Load())
+			})
+		})
+	}
+
+	t.Run("should accept no jobs", func(t *testing.T) {
+		require.NoError(t, (&GenOpts{}).renderConcurrently(nil))
+	})
+}
+
+func TestGenerateServer_Concurrent(t *testing.T) {
+	defer discardOutput()()
+
+	generate := func(concurrency int) string {
+		// the same target name in both runs, since the target location is mentioned in generated comments
+		target := filepath.Join(t.TempDir(), "concurrent")
+		require.NoError(t, os.MkdirAll(target, readableDir))
+		require.NoError(t, os.WriteFile(filepath.Join(target, "go.mod"), []byte("module concurrent\n"), readableFile))
+
+		opts := testGenOpts()
+		opts.Spec = "../fixtures/codegen/todolist.simple.yml"
+		opts.Target = target
+		opts.Concurrency = concurrency
+		require.NoError(t, GenerateServer("", nil, nil, opts))
+
+		return target
+	}
+
+	sequential := generate(1)
+	concurrent := generate(8)
+
+	t.Run("should produce the same files regardless of concurrency", func(t *testing.T) {
+		var count int
+		require.NoError(t, filepath.WalkDir(sequential, func(pth string, d fs.DirEntry, err error) error {
+			if err != nil || d.IsDir() || filepath.Base(filepath.Dir(pth)) == cacheDir {
+				return err
+			}
+
+			rel, err := filepath.Rel(sequential, pth)
+			if err != nil {
+				return err
+			}
+
+			expected, err := os.ReadFile(pth)
+			if err != nil {
+				return err
+			}
+
+			actual, err := os.ReadFile(filepath.Join(concurrent, rel))
+			if errors.Is(err, fs.ErrNotExist) {
+				return fmt.Errorf("missing file %s in concurrent generation", rel)
+			}
+			if err != nil {
+				return err
+			}
+
+			assert.EqualT(t, string(expected), string(actual), "file %s differs", rel)
+			count++
+
+			return nil
+		}))
+		assert.Greater(t, count, 10)
+	})
+}
@@ -5,11 +5,14 @@ go 1.25.0
 toolchain go1.26.1
 
 require (
+	github.com/Masterminds/semver/v3 v3.5.0
 	github.com/Masterminds/sprig/v3 v3.3.0
+	github.com/fsnotify/fsnotify v1.10.1
 	github.com/go-openapi/analysis v0.25.2
 	github.com/go-openapi/codescan v0.34.0
 	github.com/go-openapi/errors v0.22.8
 	github.com/go-openapi/inflect v0.21.6
+	github.com/go-openapi/jsonpointer v0.23.1
 	github.com/go-openapi/loads v0.23.4
 	github.com/go-openapi/runtime v0.32.3
 	github.com/go-openapi/runtime/server-middleware v0.32.1
@@ -35,6 +38,7 @@ require (
 	github.com/spf13/viper v1.21.0
 	github.com/toqueteos/webbrowser v1.2.1
 	go.yaml.in/yaml/v3 v3.0.4
+	golang.org/x/mod v0.36.0
 	golang.org/x/net v0.55.0
 	golang.org/x/tools v0.45.0
 	gopkg.in/yaml.v2 v2.4.0
@@ -43,12 +47,9 @@ require (
 require (
 	dario.cat/mergo v1.0.2 // indirect
 	github.com/Masterminds/goutils v1.1.1 // indirect
-	github.com/Masterminds/semver/v3 v3.5.0 // indirect
 	github.com/davecgh/go-spew v1.1.2-0.20180830191138-d8f796af33cc // indirect
 	github.com/docker/go-units v0.5.0 // indirect
 	github.com/felixge/httpsnoop v1.0.4 // indirect
-	github.com/fsnotify/fsnotify v1.10.1 // indirect
-	github.com/go-openapi/jsonpointer v0.23.1 // indirect
 	github.com/go-openapi/jsonreference v0.21.6 // indirect
 	github.com/go-openapi/swag/fileutils v0.26.0 // indirect
 	github.com/go-openapi/swag/jsonname v0.26.0 // indirect
@@ -69,7 +70,6 @@ require (
 	github.com/spf13/pflag v1.0.10 // indirect
 	github.com/subosito/gotenv v1.6.0 // indirect
 	golang.org/x/crypto v0.52.0 // indirect
-	golang.org/x/mod v0.36.0 // indirect
 	golang.org/x/sync v0.20.0 // indirect
 	golang.org/x/sys v0.45.0 // indirect
 	golang.org/x/text v0.37.0 // indirect